@@ -1,10 +1,15 @@
 package main
 
 import (
+	"encoding/json"
 	"main/pkg"
 	configPkg "main/pkg/config"
+	databasePkg "main/pkg/database"
 	"main/pkg/fs"
 	"main/pkg/logger"
+	"main/pkg/replay"
+	"main/pkg/utils"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -34,8 +39,147 @@ func ExecuteValidateConfig(configPath string) {
 	logger.GetDefaultLogger().Info().Msg("Provided config is valid.")
 }
 
+func GetChainDatabase(configPath string, chain string, filesystem fs.FS) *databasePkg.Database {
+	config, err := configPkg.GetConfig(configPath, filesystem)
+	if err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not load config!")
+	}
+
+	if err := config.Validate(); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Config is invalid!")
+	}
+
+	if _, found := utils.Find(config.ChainConfigs, func(c *configPkg.ChainConfig) bool {
+		return c.Name == chain
+	}); !found {
+		logger.GetDefaultLogger().Panic().Str("chain", chain).Msg("Chain is not found in config!")
+	}
+
+	log := logger.GetLogger(config.LogConfig)
+	database := databasePkg.NewDatabase(*log, config.DatabaseConfig)
+	database.Init()
+
+	return database
+}
+
+func ExecuteExport(configPath string, chain string, outputPath string) {
+	filesystem := &fs.OsFS{}
+	database := GetChainDatabase(configPath, chain, filesystem)
+
+	dump, err := database.ExportChain(chain)
+	if err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not export chain!")
+	}
+
+	file, err := filesystem.Create(outputPath)
+	if err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not create output file!")
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(dump); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not write output file!")
+	}
+
+	if err := file.Close(); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not close output file!")
+	}
+
+	logger.GetDefaultLogger().Info().
+		Str("chain", chain).
+		Int("notifiers", len(dump.Notifiers)).
+		Int("events", len(dump.Events)).
+		Int("data", len(dump.Data)).
+		Msg("Exported chain.")
+}
+
+func ExecuteReplay(configPath string, chain string, inputPath string) {
+	filesystem := &fs.OsFS{}
+
+	config, err := configPkg.GetConfig(configPath, filesystem)
+	if err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not load config!")
+	}
+
+	if err := config.Validate(); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Config is invalid!")
+	}
+
+	chainConfig, found := utils.Find(config.ChainConfigs, func(c *configPkg.ChainConfig) bool {
+		return c.Name == chain
+	})
+	if !found {
+		logger.GetDefaultLogger().Panic().Str("chain", chain).Msg("Chain is not found in config!")
+	}
+
+	chainConfig.RecalculateMissedBlocksGroups()
+	log := logger.GetLogger(config.LogConfig)
+
+	var source replay.Source
+
+	if inputPath != "" {
+		content, err := filesystem.ReadFile(inputPath)
+		if err != nil {
+			logger.GetDefaultLogger().Panic().Err(err).Msg("Could not read input file!")
+		}
+
+		var dump replay.Dump
+		if err := json.Unmarshal(content, &dump); err != nil {
+			logger.GetDefaultLogger().Panic().Err(err).Msg("Could not parse input file!")
+		}
+
+		source = replay.NewDumpSource(&dump)
+	} else {
+		database := databasePkg.NewDatabase(*log, config.DatabaseConfig)
+		database.Init()
+		source = replay.NewDatabaseSource(database, chain)
+	}
+
+	replayedEvents, err := replay.NewReplayer(*log, chainConfig, source).Replay()
+	if err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not replay blocks!")
+	}
+
+	if err := replay.PrintEvents(os.Stdout, replayedEvents); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not print events!")
+	}
+}
+
+func ExecuteImport(configPath string, chain string, inputPath string) {
+	filesystem := &fs.OsFS{}
+
+	content, err := filesystem.ReadFile(inputPath)
+	if err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not read input file!")
+	}
+
+	var dump databasePkg.ChainDump
+	if err := json.Unmarshal(content, &dump); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not parse input file!")
+	}
+
+	database := GetChainDatabase(configPath, chain, filesystem)
+
+	if err := database.ImportChain(chain, &dump); err != nil {
+		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not import chain!")
+	}
+
+	logger.GetDefaultLogger().Info().
+		Str("chain", chain).
+		Int("notifiers", len(dump.Notifiers)).
+		Int("events", len(dump.Events)).
+		Int("data", len(dump.Data)).
+		Msg("Imported chain.")
+}
+
 func main() {
-	var ConfigPath string
+	var (
+		ConfigPath string
+		ChainName  string
+		FilePath   string
+	)
 
 	rootCmd := &cobra.Command{
 		Use:     "missed-blocks-checker --config [config path]",
@@ -55,13 +199,63 @@ func main() {
 		},
 	}
 
+	exportCmd := &cobra.Command{
+		Use:     "export --config [config path] --chain [chain name] --output [output path]",
+		Long:    "Export chain's notifiers, events and data to a JSON file.",
+		Version: version,
+		Run: func(cmd *cobra.Command, args []string) {
+			ExecuteExport(ConfigPath, ChainName, FilePath)
+		},
+	}
+
+	importCmd := &cobra.Command{
+		Use:     "import --config [config path] --chain [chain name] --input [input path]",
+		Long:    "Import chain's notifiers, events and data from a JSON file created by export.",
+		Version: version,
+		Run: func(cmd *cobra.Command, args []string) {
+			ExecuteImport(ConfigPath, ChainName, FilePath)
+		},
+	}
+
+	replayCmd := &cobra.Command{
+		Use:     "replay --config [config path] --chain [chain name] [--input [dump path]]",
+		Long:    "Replay stored blocks with the chain config and print events that would have been sent.",
+		Version: version,
+		Run: func(cmd *cobra.Command, args []string) {
+			ExecuteReplay(ConfigPath, ChainName, FilePath)
+		},
+	}
+
 	rootCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
 	_ = rootCmd.MarkPersistentFlagRequired("config")
 
 	validateConfigCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
 	_ = validateConfigCmd.MarkPersistentFlagRequired("config")
 
+	exportCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
+	exportCmd.PersistentFlags().StringVar(&ChainName, "chain", "", "Chain name")
+	exportCmd.PersistentFlags().StringVar(&FilePath, "output", "", "Output file path")
+	_ = exportCmd.MarkPersistentFlagRequired("config")
+	_ = exportCmd.MarkPersistentFlagRequired("chain")
+	_ = exportCmd.MarkPersistentFlagRequired("output")
+
+	importCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
+	importCmd.PersistentFlags().StringVar(&ChainName, "chain", "", "Chain name")
+	importCmd.PersistentFlags().StringVar(&FilePath, "input", "", "Input file path")
+	_ = importCmd.MarkPersistentFlagRequired("config")
+	_ = importCmd.MarkPersistentFlagRequired("chain")
+	_ = importCmd.MarkPersistentFlagRequired("input")
+
+	replayCmd.PersistentFlags().StringVar(&ConfigPath, "config", "", "Config file path")
+	replayCmd.PersistentFlags().StringVar(&ChainName, "chain", "", "Chain name")
+	replayCmd.PersistentFlags().StringVar(&FilePath, "input", "", "Blocks dump path, blocks are taken from the database if not set")
+	_ = replayCmd.MarkPersistentFlagRequired("config")
+	_ = replayCmd.MarkPersistentFlagRequired("chain")
+
 	rootCmd.AddCommand(validateConfigCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(replayCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.GetDefaultLogger().Panic().Err(err).Msg("Could not start application")
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -72,3 +73,115 @@ func TestStartConfigProvided(t *testing.T) {
 	os.Args = []string{"cmd", "--config", "../assets/config-invalid.toml"}
 	main()
 }
+
+func writeTempConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	content, err := os.ReadFile("../assets/valid.toml")
+	require.NoError(t, err)
+
+	content = []byte(strings.ReplaceAll(string(content), "database.sqlite", dir+"/database.sqlite"))
+	configPath := dir + "/config.toml"
+	require.NoError(t, os.WriteFile(configPath, content, 0o600))
+
+	return configPath
+}
+
+//nolint:paralleltest // disabled
+func TestExportNoChainProvided(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "export", "--config", "../assets/valid.toml", "--output", "dump.json"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestExportChainNotFound(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "export", "--config", writeTempConfig(t), "--chain", "unknown", "--output", "dump.json"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestImportFileNotFound(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "import", "--config", writeTempConfig(t), "--chain", "cosmos", "--input", "../assets/not-found.json"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestImportFileInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "import", "--config", writeTempConfig(t), "--chain", "cosmos", "--input", "../assets/invalid.json"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestExportAndImport(t *testing.T) {
+	configPath := writeTempConfig(t)
+	dumpPath := t.TempDir() + "/dump.json"
+
+	os.Args = []string{"cmd", "export", "--config", configPath, "--chain", "cosmos", "--output", dumpPath}
+	main()
+
+	content, err := os.ReadFile(dumpPath)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"chain": "cosmos"`)
+
+	os.Args = []string{"cmd", "import", "--config", writeTempConfig(t), "--chain", "cosmos", "--input", dumpPath}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestReplayInputInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "replay", "--config", writeTempConfig(t), "--chain", "cosmos", "--input", "../assets/invalid.json"}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestReplayFromDump(t *testing.T) {
+	dumpPath := t.TempDir() + "/dump.json"
+	require.NoError(t, os.WriteFile(dumpPath, []byte(`{"validators":[],"blocks":[{"Height":1},{"Height":2}]}`), 0o600))
+
+	os.Args = []string{"cmd", "replay", "--config", writeTempConfig(t), "--chain", "cosmos", "--input", dumpPath}
+	main()
+}
+
+//nolint:paralleltest // disabled
+func TestReplayFromDatabase(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	// there's no snapshot to take validators from in an empty database
+	os.Args = []string{"cmd", "replay", "--config", writeTempConfig(t), "--chain", "cosmos"}
+	main()
+}
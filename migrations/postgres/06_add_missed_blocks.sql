@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS missed_blocks (
+    chain TEXT NOT NULL,
+    validator TEXT NOT NULL,
+    height BIGINT NOT NULL,
+    time BIGINT NOT NULL,
+    PRIMARY KEY (chain, validator, height)
+);
+
+CREATE INDEX IF NOT EXISTS missed_blocks_height ON missed_blocks (chain, height);
+
+-- +goose Down
+DROP TABLE missed_blocks;
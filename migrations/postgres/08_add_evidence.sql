@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS evidence (
+    chain TEXT NOT NULL,
+    validator_address TEXT NOT NULL,
+    operator_address TEXT NOT NULL,
+    height BIGINT NOT NULL,
+    block_height BIGINT NOT NULL,
+    time BIGINT NOT NULL,
+    PRIMARY KEY (chain, validator_address, height)
+);
+
+-- +goose Down
+DROP TABLE evidence;
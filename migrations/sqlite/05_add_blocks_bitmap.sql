@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS validators_dictionary (
+    chain TEXT NOT NULL,
+    id INTEGER NOT NULL,
+    address TEXT NOT NULL,
+    PRIMARY KEY (chain, id),
+    UNIQUE (chain, address)
+);
+
+ALTER TABLE blocks ADD COLUMN bitmap BLOB;
+
+-- +goose Down
+ALTER TABLE blocks DROP COLUMN bitmap;
+DROP TABLE validators_dictionary;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS reports_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    chain TEXT NOT NULL,
+    reporter TEXT NOT NULL,
+    height BIGINT NOT NULL,
+    message TEXT NOT NULL,
+    status TEXT NOT NULL,
+    attempts BIGINT NOT NULL,
+    sent_chunks BIGINT NOT NULL DEFAULT 0,
+    next_attempt_at BIGINT NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS reports_outbox_pending ON reports_outbox (chain, status, next_attempt_at);
+
+-- +goose Down
+DROP TABLE reports_outbox;
@@ -1,11 +1,23 @@
 package pkg
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	configPkg "main/pkg/config"
+	"main/pkg/constants"
 	databasePkg "main/pkg/database"
 	"main/pkg/fs"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
+	"main/pkg/utils"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -13,11 +25,24 @@ import (
 type App struct {
 	Logger         zerolog.Logger
 	Config         *configPkg.Config
+	ConfigPath     string
+	FS             fs.FS
 	Database       *databasePkg.Database
 	MetricsManager *metrics.Manager
 	Version        string
 
 	AppManagers []*AppManager
+
+	configBytes []byte
+	stopFuncs   map[string]func()
+	discovered  chan discoveredConsumers
+	mutex       sync.Mutex
+}
+
+// discoveredConsumers are consumer chains found on a provider chain by its consumers discovery.
+type discoveredConsumers struct {
+	provider  string
+	consumers []*configPkg.ChainConfig
 }
 
 func NewApp(configPath string, filesystem fs.FS, version string) *App {
@@ -34,6 +59,8 @@ func NewApp(configPath string, filesystem fs.FS, version string) *App {
 		chainConfig.RecalculateMissedBlocksGroups()
 	}
 
+	configBytes, _ := filesystem.ReadFile(configPath)
+
 	logger := loggerPkg.GetLogger(config.LogConfig).
 		With().
 		Str("component", "app_manager").
@@ -42,28 +69,46 @@ func NewApp(configPath string, filesystem fs.FS, version string) *App {
 	metricsManager := metrics.NewManager(logger, config.MetricsConfig)
 	database := databasePkg.NewDatabase(logger, config.DatabaseConfig)
 
-	appManagers := make([]*AppManager, len(config.ChainConfigs))
-	for index, chainConfig := range config.ChainConfigs {
-		appManagers[index] = NewAppManager(
-			logger,
-			chainConfig,
-			version,
-			metricsManager,
-			database,
-		)
-	}
-
-	return &App{
+	app := &App{
 		Logger:         logger,
 		Config:         config,
+		ConfigPath:     configPath,
+		FS:             filesystem,
 		Database:       database,
 		MetricsManager: metricsManager,
 		Version:        version,
-		AppManagers:    appManagers,
+		configBytes:    configBytes,
+		stopFuncs:      map[string]func(){},
+		discovered:     make(chan discoveredConsumers, constants.DiscoveredConsumersQueueSize),
 	}
+
+	app.AppManagers = make([]*AppManager, len(config.ChainConfigs))
+	for index, chainConfig := range config.ChainConfigs {
+		app.AppManagers[index] = app.NewAppManager(chainConfig)
+	}
+
+	return app
 }
 
+func (a *App) NewAppManager(chainConfig *configPkg.ChainConfig) *AppManager {
+	return NewAppManager(
+		a.Logger,
+		chainConfig,
+		a.Config.WatchdogConfig,
+		a.Config.OutboxConfig,
+		a.Version,
+		a.MetricsManager,
+		a.Database,
+		a.FS,
+		a.OnConsumersDiscovered,
+	)
+}
+
+// Start runs all chains until SIGINT or SIGTERM is received, then shuts down gracefully.
 func (a *App) Start() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	a.Database.Init()
 	go a.MetricsManager.Start()
 
@@ -72,9 +117,369 @@ func (a *App) Start() {
 	}
 	a.MetricsManager.LogAppVersion(a.Version)
 
+	a.mutex.Lock()
+	for _, appManager := range a.AppManagers {
+		a.StartAppManager(appManager)
+	}
+	a.mutex.Unlock()
+
+	go a.WatchConfig(ctx)
+	go a.WatchConsumers(ctx)
+
+	<-ctx.Done()
+	a.Logger.Info().Msg("Got shutdown signal, stopping")
+	a.Stop()
+}
+
+// Stop stops all chains, waiting for blocks and snapshots being processed, and the metrics
+// server, sending the offline notice before if it's enabled. If it takes longer than
+// the shutdown timeout, it gives up waiting.
+func (a *App) Stop() {
+	done := make(chan struct{})
+
+	go func() {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+
+		var wg sync.WaitGroup
+
+		for _, appManager := range a.AppManagers {
+			// taking stop funcs here, as chains are stopped concurrently
+			stop, ok := a.stopFuncs[appManager.Config.Name]
+			delete(a.stopFuncs, appManager.Config.Name)
+
+			wg.Add(1)
+			go func(appManager *AppManager) {
+				defer wg.Done()
+
+				if a.Config.ShutdownConfig.OfflineNotice.Bool {
+					appManager.SendOfflineNotice()
+				}
+
+				if ok {
+					stop()
+				}
+			}(appManager)
+		}
+
+		wg.Wait()
+		a.MetricsManager.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		a.Logger.Info().Msg("Stopped gracefully")
+	case <-time.After(a.Config.ShutdownConfig.Timeout * time.Second):
+		a.Logger.Warn().
+			Dur("timeout", a.Config.ShutdownConfig.Timeout*time.Second).
+			Msg("Could not stop in time, exiting anyway")
+	}
+}
+
+func (a *App) StartAppManager(appManager *AppManager) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	a.stopFuncs[appManager.Config.Name] = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		appManager.Start(ctx)
+		close(done)
+	}()
+}
+
+func (a *App) StopAppManager(appManager *AppManager) {
+	if stop, ok := a.stopFuncs[appManager.Config.Name]; ok {
+		stop()
+		delete(a.stopFuncs, appManager.Config.Name)
+	}
+}
+
+// WatchConfig reloads the config when SIGHUP is received or when the config file
+// is changed, until the context is done.
+func (a *App) WatchConfig(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(constants.ConfigWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			a.Logger.Info().Msg("Got SIGHUP, reloading config")
+			a.TryReload()
+		case <-ticker.C:
+			if a.IsConfigChanged() {
+				a.Logger.Info().Str("path", a.ConfigPath).Msg("Config file has changed, reloading config")
+				a.TryReload()
+			}
+		}
+	}
+}
+
+func (a *App) IsConfigChanged() bool {
+	configBytes, err := a.FS.ReadFile(a.ConfigPath)
+	if err != nil {
+		a.Logger.Warn().Err(err).Msg("Could not read config file")
+		return false
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return !bytes.Equal(configBytes, a.configBytes)
+}
+
+func (a *App) TryReload() {
+	if err := a.Reload(); err != nil {
+		a.Logger.Error().Err(err).Msg("Could not reload config, keeping the previous one")
+		return
+	}
+
+	a.Logger.Info().Msg("Config reloaded")
+}
+
+// Reload reads the config again and applies it: added chains are started, removed chains
+// are stopped, changed chains are either updated in place or restarted if the changes
+// cannot be applied to a running chain. If the new config is invalid, the current one is kept.
+func (a *App) Reload() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	configBytes, err := a.FS.ReadFile(a.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("could not read config: %s", err)
+	}
+
+	// remembering the contents even if the config is invalid, so it's not reloaded
+	// again until it's changed
+	a.configBytes = configBytes
+
+	config, err := configPkg.GetConfig(a.ConfigPath, a.FS)
+	if err != nil {
+		return fmt.Errorf("could not load config: %s", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("provided config is invalid: %s", err)
+	}
+
+	for _, chainConfig := range config.ChainConfigs {
+		chainConfig.RecalculateMissedBlocksGroups()
+	}
+
+	if !reflect.DeepEqual(config.LogConfig, a.Config.LogConfig) ||
+		!reflect.DeepEqual(config.DatabaseConfig, a.Config.DatabaseConfig) ||
+		!reflect.DeepEqual(config.MetricsConfig, a.Config.MetricsConfig) ||
+		!reflect.DeepEqual(config.WatchdogConfig, a.Config.WatchdogConfig) ||
+		!reflect.DeepEqual(config.OutboxConfig, a.Config.OutboxConfig) {
+		a.Logger.Warn().Msg("Log, database, metrics, watchdog and outbox config changes require a restart, not applying them")
+	}
+
+	// discovered consumers are kept running as long as their provider chain discovers them
+	discovered := make([]*AppManager, 0)
+
 	for _, appManager := range a.AppManagers {
-		go appManager.Start()
+		if appManager.Config.DiscoveredFrom != "" {
+			provider, providerFound := utils.Find(config.ChainConfigs, func(c *configPkg.ChainConfig) bool {
+				return c.Name == appManager.Config.DiscoveredFrom
+			})
+			_, conflictFound := utils.Find(config.ChainConfigs, func(c *configPkg.ChainConfig) bool {
+				return c.Name == appManager.Config.Name
+			})
+
+			if providerFound && provider.IsConsumerDiscoveryEnabled() && !conflictFound {
+				discovered = append(discovered, appManager)
+				continue
+			}
+
+			a.Logger.Info().
+				Str("chain", appManager.Config.Name).
+				Str("provider", appManager.Config.DiscoveredFrom).
+				Msg("Consumer chain is not discovered anymore, stopping it")
+			a.StopAppManager(appManager)
+			continue
+		}
+
+		_, found := utils.Find(config.ChainConfigs, func(c *configPkg.ChainConfig) bool {
+			return c.Name == appManager.Config.Name
+		})
+
+		if !found {
+			a.Logger.Info().Str("chain", appManager.Config.Name).Msg("Chain was removed, stopping it")
+			a.StopAppManager(appManager)
+		}
+	}
+
+	appManagers := make([]*AppManager, len(config.ChainConfigs))
+
+	for index, chainConfig := range config.ChainConfigs {
+		appManager, found := utils.Find(a.AppManagers, func(m *AppManager) bool {
+			return m.Config.Name == chainConfig.Name && m.Config.DiscoveredFrom == ""
+		})
+
+		if !found {
+			a.Logger.Info().Str("chain", chainConfig.Name).Msg("Chain was added, starting it")
+			appManagers[index] = a.NewAppManager(chainConfig)
+			a.MetricsManager.SetDefaultMetrics(chainConfig)
+			a.StartAppManager(appManagers[index])
+			continue
+		}
+
+		appManagers[index] = a.UpdateAppManager(appManager, chainConfig)
+	}
+
+	appManagers = append(appManagers, discovered...)
+
+	config.LogConfig = a.Config.LogConfig
+	config.DatabaseConfig = a.Config.DatabaseConfig
+	config.MetricsConfig = a.Config.MetricsConfig
+	config.WatchdogConfig = a.Config.WatchdogConfig
+	config.OutboxConfig = a.Config.OutboxConfig
+
+	// in-place changes are applied to the existing chain config, keeping it
+	// so the running chain and the app config point to the same one
+	config.ChainConfigs = utils.Map(appManagers, func(m *AppManager) *configPkg.ChainConfig {
+		return m.Config
+	})
+
+	a.Config = config
+	a.AppManagers = appManagers
+
+	return nil
+}
+
+// UpdateAppManager applies the changed chain config to a running chain, either in place
+// or by restarting it, and returns the chain running with the new config.
+func (a *App) UpdateAppManager(appManager *AppManager, chainConfig *configPkg.ChainConfig) *AppManager {
+	changedFields := appManager.Config.GetChangedFields(chainConfig)
+	if len(changedFields) == 0 {
+		return appManager
+	}
+
+	if configPkg.IsHotReloadable(changedFields) {
+		a.Logger.Info().
+			Str("chain", chainConfig.Name).
+			Strs("fields", changedFields).
+			Msg("Chain config has changed, applying changes")
+		appManager.ApplyConfig(chainConfig)
+		return appManager
+	}
+
+	a.Logger.Info().
+		Str("chain", chainConfig.Name).
+		Strs("fields", changedFields).
+		Msg("Chain config has changed, restarting chain")
+
+	a.StopAppManager(appManager)
+	newAppManager := a.NewAppManager(chainConfig)
+	newAppManager.SnapshotManager.CopyFrom(appManager.SnapshotManager)
+	a.StartAppManager(newAppManager)
+
+	return newAppManager
+}
+
+// OnConsumersDiscovered is called by a provider chain each time its consumers are discovered.
+// Consumers are started or stopped separately, as the provider chain cannot wait for it.
+func (a *App) OnConsumersDiscovered(provider string, consumers []*configPkg.ChainConfig) {
+	select {
+	case a.discovered <- discoveredConsumers{provider: provider, consumers: consumers}:
+	default:
+		a.Logger.Warn().
+			Str("provider", provider).
+			Msg("Too many discovered consumers are not processed yet, skipping")
+	}
+}
+
+// WatchConsumers starts and stops discovered consumer chains until the context is done.
+func (a *App) WatchConsumers(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case discovered := <-a.discovered:
+			a.SyncConsumers(discovered.provider, discovered.consumers)
+		}
+	}
+}
+
+// SyncConsumers starts consumer chains discovered on a provider chain that are not running yet,
+// applies changes to the ones already running and stops the ones that were not discovered.
+func (a *App) SyncConsumers(provider string, consumers []*configPkg.ChainConfig) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// the provider chain might have been removed while its consumers were discovered
+	providerManager, found := utils.Find(a.AppManagers, func(m *AppManager) bool {
+		return m.Config.Name == provider && m.Config.DiscoveredFrom == ""
+	})
+	if !found || !providerManager.Config.IsConsumerDiscoveryEnabled() {
+		return
+	}
+
+	appManagers := make([]*AppManager, 0, len(a.AppManagers))
+
+	for _, appManager := range a.AppManagers {
+		if appManager.Config.DiscoveredFrom != provider {
+			appManagers = append(appManagers, appManager)
+			continue
+		}
+
+		_, found := utils.Find(consumers, func(c *configPkg.ChainConfig) bool {
+			return c.Name == appManager.Config.Name
+		})
+
+		if !found {
+			a.Logger.Info().
+				Str("chain", appManager.Config.Name).
+				Str("provider", provider).
+				Msg("Consumer chain is not discovered anymore, stopping it")
+			a.StopAppManager(appManager)
+			continue
+		}
+
+		appManagers = append(appManagers, appManager)
+	}
+
+	for _, consumer := range consumers {
+		index := slices.IndexFunc(appManagers, func(m *AppManager) bool {
+			return m.Config.Name == consumer.Name
+		})
+
+		if index == -1 {
+			a.Logger.Info().
+				Str("chain", consumer.Name).
+				Str("provider", provider).
+				Str("consumer_id", consumer.ConsumerID).
+				Msg("Consumer chain was discovered, starting it")
+			appManager := a.NewAppManager(consumer)
+			a.MetricsManager.SetDefaultMetrics(consumer)
+			a.StartAppManager(appManager)
+			appManagers = append(appManagers, appManager)
+			continue
+		}
+
+		if appManagers[index].Config.DiscoveredFrom != provider {
+			a.Logger.Warn().
+				Str("chain", consumer.Name).
+				Str("provider", provider).
+				Msg("Chain with the same name is already running, not starting discovered consumer chain")
+			continue
+		}
+
+		appManagers[index] = a.UpdateAppManager(appManagers[index], consumer)
 	}
 
-	select {}
+	a.AppManagers = appManagers
+	a.Config.ChainConfigs = utils.Map(appManagers, func(m *AppManager) *configPkg.ChainConfig {
+		return m.Config
+	})
 }
@@ -1,12 +1,19 @@
 package pkg
 
 import (
+	"context"
 	"fmt"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	dataPkg "main/pkg/data"
 	databasePkg "main/pkg/database"
+	"main/pkg/discovery"
+	"main/pkg/events"
+	"main/pkg/fs"
+	"main/pkg/grpc"
+	"main/pkg/http"
 	"main/pkg/metrics"
+	"main/pkg/outbox"
 	populatorsPkg "main/pkg/populators"
 	reportersPkg "main/pkg/reporters"
 	"main/pkg/reporters/discord"
@@ -16,6 +23,7 @@ import (
 	"main/pkg/tendermint"
 	"main/pkg/types"
 	"main/pkg/utils"
+	"main/pkg/watchdog"
 	"sync"
 	"time"
 
@@ -29,42 +37,83 @@ type AppManager struct {
 	DataManager        *dataPkg.Manager
 	StateManager       *statePkg.Manager
 	SnapshotManager    *snapshotPkg.Manager
-	WebsocketManager   *tendermint.WebsocketManager
+	BlockSource        tendermint.BlockSource
 	MetricsManager     *metrics.Manager
 	Populators         map[constants.PopulatorType]*populatorsPkg.Wrapper
 	Reporters          []reportersPkg.Reporter
+	Watchdog           *watchdog.Watchdog
+	Outbox             *outbox.Outbox
 	IsPopulatingBlocks bool
 
 	mutex         sync.Mutex
 	snapshotMutex sync.Mutex
+	wg            sync.WaitGroup
 }
 
 func NewAppManager(
 	logger zerolog.Logger,
 	config *configPkg.ChainConfig,
+	watchdogConfig configPkg.WatchdogConfig,
+	outboxConfig configPkg.OutboxConfig,
 	version string,
 	metricsManager *metrics.Manager,
 	database *databasePkg.Database,
+	filesystem fs.FS,
+	onConsumersDiscovered func(provider string, consumers []*configPkg.ChainConfig),
 ) *AppManager {
 	managerLogger := logger.
 		With().
 		Str("chain", config.Name).
 		Logger()
 
-	dataManager := dataPkg.NewManager(managerLogger, config, metricsManager)
+	pools := http.NewPools(managerLogger, metricsManager, config)
+	grpcPools := grpc.NewPools(managerLogger, metricsManager, config)
+	dataManager := dataPkg.NewManager(managerLogger, config, pools, grpcPools)
 	snapshotManager := snapshotPkg.NewManager(managerLogger, config, metricsManager)
 	stateManager := statePkg.NewManager(managerLogger, config, metricsManager, snapshotManager, database)
-	websocketManager := tendermint.NewWebsocketManager(managerLogger, config, metricsManager)
+	blockSource := tendermint.GetBlockSource(managerLogger, config, metricsManager, pools)
 
 	reporters := []reportersPkg.Reporter{
-		telegram.NewReporter(config, version, managerLogger, stateManager, metricsManager, snapshotManager),
-		discord.NewReporter(config, version, managerLogger, stateManager, metricsManager, snapshotManager),
-	}
+		telegram.NewReporter(config, version, managerLogger, stateManager, metricsManager, snapshotManager, dataManager),
+		discord.NewReporter(config, version, managerLogger, stateManager, metricsManager, snapshotManager, dataManager),
+	}
+
+	appWatchdog := watchdog.NewWatchdog(managerLogger, watchdogConfig, config, reporters, []watchdog.Pool{
+		pools.RPC,
+		pools.ProviderRPC,
+		pools.LCD,
+		pools.ProviderLCD,
+		grpcPools.GRPC,
+		grpcPools.ProviderGRPC,
+	})
+
+	reportsOutbox := outbox.NewOutbox(
+		managerLogger,
+		outboxConfig,
+		config.Name,
+		database,
+		reporters,
+		appWatchdog.OnReportSent,
+	)
 
 	populators := map[constants.PopulatorType]*populatorsPkg.Wrapper{
-		constants.PopulatorSlashingParams: populatorsPkg.NewWrapper(
-			populatorsPkg.NewSlashingParamsPopulator(config, dataManager, stateManager, metricsManager, managerLogger),
-			config.Intervals.SlashingParams*time.Second,
+		constants.PopulatorNodesLag: populatorsPkg.NewWrapper(
+			populatorsPkg.NewNodesLagPopulator(dataManager),
+			config.Intervals.NodesLag*time.Second,
+			managerLogger,
+		),
+		constants.PopulatorSoftOptOutThreshold: populatorsPkg.NewWrapper(
+			populatorsPkg.NewSoftOptOutThresholdPopulator(config, dataManager, stateManager, managerLogger),
+			config.Intervals.SoftOptOutThreshold*time.Second,
+			managerLogger,
+		),
+		constants.PopulatorConsumersDiscovery: populatorsPkg.NewWrapper(
+			populatorsPkg.NewConsumersDiscoveryPopulator(
+				config,
+				discovery.NewDiscovery(managerLogger, config, filesystem, dataManager),
+				onConsumersDiscovered,
+			),
+			config.Intervals.ConsumersDiscovery*time.Second,
 			managerLogger,
 		),
 		constants.PopulatorTrimDatabase: populatorsPkg.NewWrapper(
@@ -72,24 +121,53 @@ func NewAppManager(
 			config.Intervals.Trim*time.Second,
 			managerLogger,
 		),
+		constants.PopulatorReconciliation: populatorsPkg.NewWrapper(
+			populatorsPkg.NewReconciliationPopulator(config, stateManager, metricsManager, managerLogger),
+			config.Intervals.Reconciliation*time.Second,
+			managerLogger,
+		),
 	}
 
-	return &AppManager{
+	appManager := &AppManager{
 		Logger:             managerLogger,
 		Config:             config,
 		DataManager:        dataManager,
 		Database:           database,
 		StateManager:       stateManager,
 		SnapshotManager:    snapshotManager,
-		WebsocketManager:   websocketManager,
+		BlockSource:        blockSource,
 		MetricsManager:     metricsManager,
 		Reporters:          reporters,
+		Watchdog:           appWatchdog,
+		Outbox:             reportsOutbox,
 		Populators:         populators,
 		IsPopulatingBlocks: false,
 	}
+
+	// these populators send reports via the app manager, so they are added once it's created
+	appManager.Populators[constants.PopulatorSlashingParams] = populatorsPkg.NewWrapper(
+		populatorsPkg.NewSlashingParamsPopulator(
+			config,
+			dataManager,
+			stateManager,
+			metricsManager,
+			appManager.SendReport,
+			managerLogger,
+		),
+		config.Intervals.SlashingParams*time.Second,
+		managerLogger,
+	)
+	appManager.Populators[constants.PopulatorUnjailReminders] = populatorsPkg.NewWrapper(
+		populatorsPkg.NewUnjailRemindersPopulator(config, stateManager, appManager.SendReport, managerLogger),
+		config.Intervals.UnjailReminders*time.Second,
+		managerLogger,
+	)
+
+	return appManager
 }
 
-func (a *AppManager) Start() {
+// Start runs the chain until the context is done.
+func (a *AppManager) Start(ctx context.Context) {
 	a.StateManager.Init()
 
 	a.MetricsManager.LogSlashingParams(
@@ -115,21 +193,85 @@ func (a *AppManager) Start() {
 	}
 
 	for _, populator := range a.Populators {
-		go populator.Start()
+		a.wg.Add(1)
+		go func(populator *populatorsPkg.Wrapper) {
+			defer a.wg.Done()
+			populator.Start(ctx)
+		}(populator)
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.ListenForEvents(ctx)
+	}()
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		a.Outbox.Start(ctx)
+	}()
+
+	go a.Watchdog.Start(ctx)
+
+	a.PopulateInBackground(ctx)
+
+	<-ctx.Done()
+	a.Stop()
+}
+
+// Stop waits for the blocks, snapshots and populators being processed, if any,
+// so nothing is interrupted in the middle of a database write, then stops reporters
+// and closes the nodes connections.
+func (a *AppManager) Stop() {
+	a.Logger.Info().Msg("Stopping chain...")
+	a.wg.Wait()
+
+	for _, reporter := range a.Reporters {
+		reporter.Stop()
+	}
+
+	a.DataManager.Stop()
+
+	a.Logger.Info().Msg("Chain is stopped")
+}
+
+// SendOfflineNotice notifies the reporters' channels that alerts will not be sent anymore.
+func (a *AppManager) SendOfflineNotice() {
+	for _, reporter := range a.Reporters {
+		if !reporter.Enabled() {
+			continue
+		}
+
+		if err := reporter.SendOfflineNotice(); err != nil {
+			a.Logger.Error().
+				Err(err).
+				Str("name", string(reporter.Name())).
+				Msg("Error sending offline notice")
+		}
 	}
+}
+
+// ApplyConfig applies the chain config changes that do not require a restart.
+func (a *AppManager) ApplyConfig(config *configPkg.ChainConfig) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 
-	go a.ListenForEvents()
-	go a.PopulateInBackground()
+	a.snapshotMutex.Lock()
+	defer a.snapshotMutex.Unlock()
 
-	select {}
+	a.Config.ApplyHotReloadable(config)
+	a.MetricsManager.LogChainInfo(a.Config.Name, a.Config.GetName())
 }
 
-func (a *AppManager) ListenForEvents() {
-	a.WebsocketManager.Listen()
+func (a *AppManager) ListenForEvents(ctx context.Context) {
+	a.BlockSource.Listen(ctx)
 
 	for {
 		select {
-		case result := <-a.WebsocketManager.Channel:
+		case <-ctx.Done():
+			return
+		case result := <-a.BlockSource.GetChannel():
 			a.ProcessEvent(result)
 		}
 	}
@@ -177,11 +319,96 @@ func (a *AppManager) ProcessEvent(emittable types.WebsocketEmittable) {
 		a.Logger.Error().
 			Err(err).
 			Msg("Error inserting new block")
+	} else {
+		a.Watchdog.OnBlockProcessed()
 	}
 
+	a.ProcessEvidence(block)
 	a.ProcessSnapshot(block)
 }
 
+// ProcessEvidence reports double signs right when the evidence gets included in a block,
+// without waiting for the validator to get tombstoned. Evidence is saved, so each one
+// is reported once even if a block is processed multiple times.
+func (a *AppManager) ProcessEvidence(block *types.Block) {
+	if len(block.Evidence) == 0 {
+		return
+	}
+
+	validators := a.StateManager.GetValidators()
+
+	// validators are not fetched yet when backfilling blocks right after the start
+	if len(validators) == 0 {
+		if err := a.UpdateValidators(a.StateManager.GetLastBlockHeight() - 1); err != nil {
+			a.Logger.Error().
+				Err(err).
+				Int64("height", block.Height).
+				Msg("Error updating validators to process evidence")
+			return
+		}
+
+		validators = a.StateManager.GetValidators()
+	}
+	report := &types.Report{Events: make([]types.ReportEvent, 0)}
+
+	for _, evidence := range block.Evidence {
+		var validator *types.Validator
+		for _, v := range validators {
+			if v.ConsensusAddressHex == evidence.ValidatorAddress {
+				validator = v
+				break
+			}
+		}
+
+		if validator != nil {
+			evidence.OperatorAddress = validator.OperatorAddress
+		}
+
+		inserted, err := a.StateManager.SaveEvidence(evidence)
+		if err != nil {
+			a.Logger.Error().
+				Err(err).
+				Int64("height", evidence.Height).
+				Str("address", evidence.ValidatorAddress).
+				Msg("Error saving evidence")
+			continue
+		}
+
+		if !inserted {
+			a.Logger.Debug().
+				Int64("height", evidence.Height).
+				Str("address", evidence.ValidatorAddress).
+				Msg("Evidence was processed before, skipping")
+			continue
+		}
+
+		if validator == nil {
+			a.Logger.Warn().
+				Int64("height", evidence.Height).
+				Str("address", evidence.ValidatorAddress).
+				Msg("Got evidence for a validator that is not found")
+			continue
+		}
+
+		a.Logger.Info().
+			Int64("height", evidence.Height).
+			Str("valoper", validator.OperatorAddress).
+			Str("moniker", validator.Moniker).
+			Msg("Validator has double signed")
+
+		report.Events = append(report.Events, events.ValidatorDoubleSigned{
+			Validator: validator,
+			Evidence:  evidence,
+		})
+	}
+
+	if report.Empty() {
+		return
+	}
+
+	a.SendReport(block.Height, report)
+}
+
 func (a *AppManager) ProcessSnapshot(block *types.Block) {
 	a.snapshotMutex.Lock()
 	defer a.snapshotMutex.Unlock()
@@ -218,6 +445,7 @@ func (a *AppManager) ProcessSnapshot(block *types.Block) {
 	}
 
 	snapshot, err := a.StateManager.GetSnapshot()
+	a.Watchdog.OnSnapshotGenerated(err)
 	if err != nil {
 		a.Logger.Error().Err(err).Msg("Error generating snapshot")
 		return
@@ -242,12 +470,9 @@ func (a *AppManager) ProcessSnapshot(block *types.Block) {
 	}
 
 	a.SnapshotManager.CommitNewSnapshot(block.Height, snapshot)
-	if err := a.StateManager.SaveSnapshot(&snapshotPkg.Info{
-		Height:   block.Height,
-		Snapshot: snapshot,
-	}); err != nil {
-		a.Logger.Error().Err(err).Msg("Could not save latest snapshot to database")
-	}
+
+	// saved once the report is generated, so the group jumps waiting to be confirmed are saved as well
+	defer a.SaveSnapshot(block.Height)
 
 	olderHeight := a.SnapshotManager.GetOlderHeight()
 	if olderHeight >= block.Height {
@@ -280,21 +505,36 @@ func (a *AppManager) ProcessSnapshot(block *types.Block) {
 			Msg("Report entries")
 	}
 
-	if err := a.StateManager.SaveReport(block.Height, report); err != nil {
+	a.SendReport(block.Height, report)
+}
+
+// SaveSnapshot stores the latest snapshot, so it's compared with the next one after a restart.
+func (a *AppManager) SaveSnapshot(height int64) {
+	snapshot, ok := a.SnapshotManager.GetNewerSnapshot()
+	if !ok {
+		return
+	}
+
+	if err := a.StateManager.SaveSnapshot(&snapshotPkg.Info{
+		Height:   height,
+		Snapshot: *snapshot,
+	}); err != nil {
+		a.Logger.Error().Err(err).Msg("Could not save latest snapshot to database")
+	}
+}
+
+// SendReport stores the report events and adds the report to the outbox to be sent by reporters.
+func (a *AppManager) SendReport(height int64, report *types.Report) {
+	if err := a.StateManager.SaveReport(height, report); err != nil {
 		a.Logger.Error().
 			Err(err).
 			Msg("Error saving report to database")
 	}
 
-	for _, reporter := range a.Reporters {
-		if reporter.Enabled() {
-			if err := reporter.Send(report); err != nil {
-				a.Logger.Error().
-					Err(err).
-					Str("name", string(reporter.Name())).
-					Msg("Error sending report")
-			}
-		}
+	if err := a.Outbox.Enqueue(height, report); err != nil {
+		a.Logger.Error().
+			Err(err).
+			Msg("Error adding report to outbox")
 	}
 }
 
@@ -308,30 +548,42 @@ func (a *AppManager) UpdateValidators(height int64) error {
 	return nil
 }
 
-func (a *AppManager) PopulateInBackground() {
+func (a *AppManager) PopulateInBackground(ctx context.Context) {
+	a.wg.Add(2)
+
 	// Start populating blocks in background
-	go a.PopulateBlocks()
+	go func() {
+		defer a.wg.Done()
+		a.PopulateBlocks(ctx)
+	}()
 
 	// Setting timers
-	go a.SyncBlocks()
+	go func() {
+		defer a.wg.Done()
+		a.SyncBlocks(ctx)
+	}()
 }
 
-func (a *AppManager) SyncBlocks() {
+func (a *AppManager) SyncBlocks(ctx context.Context) {
 	if a.Config.Intervals.Blocks == 0 {
 		a.Logger.Info().Msg("Blocks continuous population is disabled.")
 		return
 	}
 
 	blocksTicker := time.NewTicker(a.Config.Intervals.Blocks * time.Second)
+	defer blocksTicker.Stop()
+
 	for {
 		select {
+		case <-ctx.Done():
+			return
 		case <-blocksTicker.C:
-			a.PopulateBlocks()
+			a.PopulateBlocks(ctx)
 		}
 	}
 }
 
-func (a *AppManager) PopulateBlocks() {
+func (a *AppManager) PopulateBlocks(ctx context.Context) {
 	if a.IsPopulatingBlocks {
 		a.Logger.Info().Msg("AppManager is populating blocks already, not populating again")
 		return
@@ -339,23 +591,25 @@ func (a *AppManager) PopulateBlocks() {
 
 	a.IsPopulatingBlocks = true
 
+	// blocks fetched here are not received live, for example ones produced while the app was down,
+	// so the double signs evidence they include is processed once they are stored
+	evidenceBlocks := make([]*types.Block, 0)
+	defer func() {
+		for _, evidenceBlock := range evidenceBlocks {
+			a.ProcessEvidence(evidenceBlock)
+		}
+	}()
+
 	// Populating latest block
 	a.Logger.Info().Msg("Populating blocks...")
 
-	blockRaw, err := a.DataManager.GetBlock(0)
+	block, err := a.BlockSource.GetBlock(0)
 	if err != nil {
 		a.Logger.Error().Err(err).Msg("Error querying for last block")
 		a.IsPopulatingBlocks = false
 		return
 	}
 
-	block, err := blockRaw.Result.Block.ToBlock()
-	if err != nil {
-		a.Logger.Warn().Msg("Error parsing block")
-		a.IsPopulatingBlocks = false
-		return
-	}
-
 	lastStateHeight := a.StateManager.GetLastBlockHeight()
 	if lastStateHeight > block.Height {
 		a.Logger.Info().
@@ -390,6 +644,10 @@ func (a *AppManager) PopulateBlocks() {
 		return
 	}
 
+	if len(block.Evidence) > 0 {
+		evidenceBlocks = append(evidenceBlocks, block)
+	}
+
 	// Populating blocks
 	if a.StateManager.GetLastBlockHeight() == 0 {
 		a.Logger.Warn().Msg("Latest block is not set, cannot populate blocks.")
@@ -409,6 +667,12 @@ func (a *AppManager) PopulateBlocks() {
 	blocksChunks := utils.SplitIntoChunks(missingBlocks, a.Config.Pagination.BlocksSearch)
 
 	for _, chunk := range blocksChunks {
+		if ctx.Err() != nil {
+			a.Logger.Info().Msg("Chain is stopping, not populating blocks further")
+			a.IsPopulatingBlocks = false
+			return
+		}
+
 		count := a.StateManager.GetBlocksCountSinceLatest(a.Config.StoreBlocks)
 
 		a.Logger.Info().
@@ -418,14 +682,33 @@ func (a *AppManager) PopulateBlocks() {
 			Ints64("blocks", chunk).
 			Msg("Fetching more blocks...")
 
-		blocks, allValidators, errs := a.DataManager.GetBlocksAndValidatorsAtHeights(chunk)
+		var (
+			wg            sync.WaitGroup
+			blocks        map[int64]*types.Block
+			blocksErrs    []error
+			allValidators map[int64]map[string]bool
+			validatorErrs []error
+		)
+
+		wg.Add(2)
+		go func() {
+			blocks, blocksErrs = a.BlockSource.GetBlocks(chunk)
+			wg.Done()
+		}()
+
+		go func() {
+			allValidators, validatorErrs = a.DataManager.GetActiveSetsAtHeights(chunk)
+			wg.Done()
+		}()
+
+		wg.Wait()
 
-		if len(errs) > 0 {
+		if errs := append(blocksErrs, validatorErrs...); len(errs) > 0 {
 			a.Logger.Error().Errs("errors", errs).Msg("Error querying for blocks")
 		}
 
 		for _, height := range chunk {
-			blockRaw, found := blocks[height]
+			block, found := blocks[height]
 			if !found {
 				a.Logger.Error().
 					Int64("height", height).
@@ -441,14 +724,6 @@ func (a *AppManager) PopulateBlocks() {
 				continue
 			}
 
-			block, err := blockRaw.Result.Block.ToBlock()
-			if err != nil {
-				a.Logger.Error().
-					Err(err).
-					Msg("Error getting older block")
-				continue
-			}
-
 			block.SetValidators(validators)
 
 			a.mutex.Lock()
@@ -463,6 +738,10 @@ func (a *AppManager) PopulateBlocks() {
 			}
 
 			a.mutex.Unlock()
+
+			if len(block.Evidence) > 0 {
+				evidenceBlocks = append(evidenceBlocks, block)
+			}
 		}
 
 		a.Logger.Debug().Int("len", len(blocks)).Msg("Inserted all blocks")
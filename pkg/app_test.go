@@ -0,0 +1,245 @@
+package pkg
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	databasePkg "main/pkg/database"
+	"main/pkg/fs"
+	"os"
+	"testing"
+
+	"github.com/creasty/defaults"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+const testAppConfigChains = `
+[[chains]]
+name = "cosmos"
+rpc-endpoints = ["https://rpc.cosmos.quokkastake.io"]
+
+[[chains]]
+name = "sentinel"
+rpc-endpoints = ["https://rpc.sentinel.quokkastake.io"]
+`
+
+func writeTestAppConfig(t *testing.T, path, chains string) {
+	t.Helper()
+
+	content := "[log]\nlevel = \"fatal\"\n\n[database]\ntype = \"sqlite\"\npath = \"database.sqlite\"\n" + chains
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func getTestApp(t *testing.T) (*App, string) {
+	t.Helper()
+
+	configPath := t.TempDir() + "/config.toml"
+	writeTestAppConfig(t, configPath, testAppConfigChains)
+
+	return NewApp(configPath, &fs.OsFS{}, "1.2.3"), configPath
+}
+
+func TestAppReloadInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	app, configPath := getTestApp(t)
+	config := app.Config
+
+	writeTestAppConfig(t, configPath, "")
+	require.True(t, app.IsConfigChanged())
+
+	err := app.Reload()
+	require.Error(t, err)
+	require.Equal(t, config, app.Config)
+	require.Len(t, app.AppManagers, 2)
+	require.False(t, app.IsConfigChanged())
+}
+
+func TestAppReloadConfigNotFound(t *testing.T) {
+	t.Parallel()
+
+	app, configPath := getTestApp(t)
+	require.NoError(t, os.Remove(configPath))
+	require.False(t, app.IsConfigChanged())
+
+	err := app.Reload()
+	require.Error(t, err)
+	require.Len(t, app.AppManagers, 2)
+}
+
+func TestAppReloadNoChanges(t *testing.T) {
+	t.Parallel()
+
+	app, _ := getTestApp(t)
+	appManagers := app.AppManagers
+	require.False(t, app.IsConfigChanged())
+
+	err := app.Reload()
+	require.NoError(t, err)
+	require.Equal(t, appManagers, app.AppManagers)
+}
+
+func TestAppReloadHotReloadable(t *testing.T) {
+	t.Parallel()
+
+	app, configPath := getTestApp(t)
+	appManager := app.AppManagers[0]
+
+	writeTestAppConfig(t, configPath, `
+[[chains]]
+name = "cosmos"
+pretty-name = "Cosmos Hub"
+rpc-endpoints = ["https://rpc.cosmos.quokkastake.io"]
+thresholds = [0, 50, 100]
+emoji-start = ["🟡", "🔴"]
+emoji-end = ["🟢", "🟡"]
+
+[[chains]]
+name = "sentinel"
+rpc-endpoints = ["https://rpc.sentinel.quokkastake.io"]
+`)
+
+	err := app.Reload()
+	require.NoError(t, err)
+	require.Len(t, app.AppManagers, 2)
+	require.Same(t, appManager, app.AppManagers[0])
+	require.Same(t, appManager.Config, app.Config.ChainConfigs[0])
+	require.Equal(t, "Cosmos Hub", appManager.Config.GetName())
+	require.Len(t, appManager.Config.MissedBlocksGroups, 2)
+	require.Equal(t, "🔴", appManager.Config.MissedBlocksGroups[1].EmojiStart)
+}
+
+func TestAppReloadRemovedChain(t *testing.T) {
+	t.Parallel()
+
+	app, configPath := getTestApp(t)
+	appManager := app.AppManagers[1]
+
+	writeTestAppConfig(t, configPath, `
+[[chains]]
+name = "sentinel"
+rpc-endpoints = ["https://rpc.sentinel.quokkastake.io"]
+`)
+
+	err := app.Reload()
+	require.NoError(t, err)
+	require.Len(t, app.AppManagers, 1)
+	require.Len(t, app.Config.ChainConfigs, 1)
+	require.Same(t, appManager, app.AppManagers[0])
+}
+
+func TestAppReloadKeepsGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	app, configPath := getTestApp(t)
+
+	content := "[log]\nlevel = \"debug\"\n\n[database]\ntype = \"sqlite\"\npath = \"other.sqlite\"\n" + testAppConfigChains
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	err := app.Reload()
+	require.NoError(t, err)
+	require.Equal(t, "fatal", app.Config.LogConfig.LogLevel)
+	require.Equal(t, "database.sqlite", app.Config.DatabaseConfig.Path)
+}
+
+func TestAppStopNotStarted(t *testing.T) {
+	t.Parallel()
+
+	app, _ := getTestApp(t)
+	app.Config.ShutdownConfig.OfflineNotice = null.BoolFrom(true)
+	app.Stop()
+}
+
+const testAppConfigProvider = `
+[[chains]]
+name = "cosmos"
+rpc-endpoints = ["https://rpc.cosmos.quokkastake.io"]
+consumer-ids = ["*"]
+consumers-mapping = "consumers.toml"
+
+[[chains]]
+name = "sentinel"
+rpc-endpoints = ["https://rpc.sentinel.quokkastake.io"]
+`
+
+func getTestDiscoveredConsumer(name string) *configPkg.ChainConfig {
+	consumer := &configPkg.ChainConfig{
+		Name:                 name,
+		RPCEndpoints:         []string{"https://rpc.neutron.quokkastake.io"},
+		IsConsumer:           null.BoolFrom(true),
+		ConsumerID:           "0",
+		ProviderRPCEndpoints: []string{"https://rpc.cosmos.quokkastake.io"},
+		DiscoveredFrom:       "cosmos",
+	}
+	defaults.MustSet(consumer)
+	consumer.RecalculateMissedBlocksGroups()
+
+	return consumer
+}
+
+func getTestProviderApp(t *testing.T) (*App, string) {
+	t.Helper()
+
+	app, configPath := getTestApp(t)
+
+	// chains are started on reload and discovery, so they need a working database
+	app.Database = databasePkg.NewDatabase(app.Logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	app.Database.Init()
+
+	writeTestAppConfig(t, configPath, testAppConfigProvider)
+	require.NoError(t, app.Reload())
+
+	return app, configPath
+}
+
+func TestAppSyncConsumers(t *testing.T) {
+	t.Parallel()
+
+	app, _ := getTestProviderApp(t)
+	defer app.Stop()
+
+	// not a provider chain
+	app.SyncConsumers("sentinel", []*configPkg.ChainConfig{getTestDiscoveredConsumer("neutron")})
+	require.Len(t, app.AppManagers, 2)
+
+	app.SyncConsumers("cosmos", []*configPkg.ChainConfig{
+		getTestDiscoveredConsumer("neutron"),
+		getTestDiscoveredConsumer("sentinel"),
+	})
+	require.Len(t, app.AppManagers, 3)
+	require.Len(t, app.Config.ChainConfigs, 3)
+	require.Equal(t, "neutron", app.AppManagers[2].Config.Name)
+	require.Empty(t, app.AppManagers[1].Config.DiscoveredFrom)
+
+	appManager := app.AppManagers[2]
+	app.SyncConsumers("cosmos", []*configPkg.ChainConfig{getTestDiscoveredConsumer("neutron")})
+	require.Len(t, app.AppManagers, 3)
+	require.Same(t, appManager, app.AppManagers[2])
+
+	app.SyncConsumers("cosmos", []*configPkg.ChainConfig{})
+	require.Len(t, app.AppManagers, 2)
+	require.Len(t, app.Config.ChainConfigs, 2)
+}
+
+func TestAppReloadKeepsDiscoveredConsumers(t *testing.T) {
+	t.Parallel()
+
+	app, configPath := getTestProviderApp(t)
+	defer app.Stop()
+
+	app.SyncConsumers("cosmos", []*configPkg.ChainConfig{getTestDiscoveredConsumer("neutron")})
+	require.Len(t, app.AppManagers, 3)
+	appManager := app.AppManagers[2]
+
+	require.NoError(t, app.Reload())
+	require.Len(t, app.AppManagers, 3)
+	require.Same(t, appManager, app.AppManagers[2])
+
+	writeTestAppConfig(t, configPath, testAppConfigChains)
+	require.NoError(t, app.Reload())
+	require.Len(t, app.AppManagers, 2)
+	require.Len(t, app.Config.ChainConfigs, 2)
+}
@@ -4,12 +4,27 @@ import (
 	"errors"
 	"fmt"
 	"main/pkg/constants"
+	"main/pkg/types"
 	"main/pkg/utils"
+	"reflect"
 	"strings"
+	"time"
 
 	"gopkg.in/guregu/null.v4"
 )
 
+// HotReloadableFields are chain config fields that are read every time they are used,
+// so changing them does not require restarting the chain.
+var HotReloadableFields = []string{
+	"pretty-name",
+	"snapshots-interval",
+	"threshold-mode",
+	"thresholds",
+	"emoji-start",
+	"emoji-end",
+	"explorer",
+}
+
 type ChainPagination struct {
 	BlocksSearch   int    `default:"100"  toml:"blocks-search"`
 	ValidatorsList uint64 `default:"1000" toml:"validators-list"`
@@ -24,23 +39,49 @@ type ChainConfig struct {
 	MinSignedPerWindow float64         `default:"0.05"       toml:"min-signed-per-window"`
 	SnapshotsInterval  int64           `default:"1"          toml:"snapshots-interval"`
 	FirstBlock         int64           `default:"1"          toml:"first-block"`
+	MaxNodeLag         int64           `default:"10"         toml:"max-node-lag"`
+	BlockSource        string          `default:"websocket"  toml:"block-source"`
 	Pagination         ChainPagination `toml:"pagination"`
 	Intervals          IntervalsConfig `toml:"intervals"`
 
+	Reconciliation ReconciliationConfig `toml:"reconciliation"`
+
 	IsConsumer              null.Bool `default:"false"                  toml:"consumer"`
 	ProviderRPCEndpoints    []string  `toml:"provider-rpc-endpoints"`
 	ConsumerValidatorPrefix string    `toml:"consumer-validator-prefix"`
 	ConsumerID              string    `toml:"consumer-id"`
+	ConsumerSoftOptOut      float64   `default:"0.05"                   toml:"consumer-soft-opt-out"`
+
+	// ChainID is used to match consumer chains discovered on a provider chain with the consumers mapping.
+	ChainID              string   `toml:"chain-id"`
+	ConsumerIDs          []string `toml:"consumer-ids"`
+	ConsumersMappingPath string   `toml:"consumers-mapping"`
+	// DiscoveredFrom is the name of the provider chain this consumer chain was discovered on,
+	// empty for chains set in the config.
+	DiscoveredFrom string `toml:"-"`
+
+	// SlashFractionDowntime, SlashFractionDoubleSign and DowntimeJailDuration are taken
+	// from the chain slashing params, they are unknown if fetching slashing params is disabled.
+	SlashFractionDowntime   float64       `toml:"-"`
+	SlashFractionDoubleSign float64       `toml:"-"`
+	DowntimeJailDuration    time.Duration `toml:"-"`
 
 	FetcherType          string   `default:"cosmos-rpc"          toml:"fetcher-type"`
 	LCDEndpoints         []string `toml:"lcd-endpoints"`
 	ProviderLCDEndpoints []string `toml:"provider-lcd-endpoints"`
 
+	GRPCEndpoints         []string      `toml:"grpc-endpoints"`
+	ProviderGRPCEndpoints []string      `toml:"provider-grpc-endpoints"`
+	GRPCTLSConfig         GRPCTLSConfig `toml:"grpc-tls"`
+
 	MissedBlocksGroups MissedBlocksGroups `toml:"-"`
+	ThresholdMode      string             `default:"window"                                                                                      toml:"threshold-mode"`
 	Thresholds         []float64          `default:"[0, 0.5, 1, 5, 10, 25, 50, 75, 90, 100]"                                                    toml:"thresholds"`
 	EmojisStart        []string           `default:"[\"🟡\", \"🟡\", \"🟡\", \"🟠\", \"🟠\", \"🟠\", \"🔴\", \"🔴\", \"🔴\"]"                            toml:"emoji-start"`
 	EmojisEnd          []string           `default:"[\"🟢\", \"🟡\", \"🟡\", \"🟡\", \"🟡\", \"🟠\", \"🟠\", \"🟠\", \"🟠\"]"                            toml:"emoji-end"`
 
+	SigningWindows []SigningWindowConfig `toml:"signing-windows"`
+
 	ExplorerConfig ExplorerConfig `toml:"explorer"`
 	TelegramConfig TelegramConfig `toml:"telegram"`
 	DiscordConfig  DiscordConfig  `toml:"discord"`
@@ -62,6 +103,37 @@ func (c *ChainConfig) GetBlocksMissCount() int64 {
 	return int64(float64(c.BlocksWindow) * c.MinSignedPerWindow)
 }
 
+func (c *ChainConfig) GetSlashingParams() types.SlashingParams {
+	return types.SlashingParams{
+		SignedBlocksWindow:      c.BlocksWindow,
+		MinSignedPerWindow:      c.MinSignedPerWindow,
+		DowntimeJailDuration:    c.DowntimeJailDuration,
+		SlashFractionDowntime:   c.SlashFractionDowntime,
+		SlashFractionDoubleSign: c.SlashFractionDoubleSign,
+	}
+}
+
+// SetSlashingParams overwrites the slashing params with the ones taken from the chain,
+// recalculating missed blocks groups as they depend on the blocks window.
+func (c *ChainConfig) SetSlashingParams(params types.SlashingParams) {
+	c.BlocksWindow = params.SignedBlocksWindow
+	c.MinSignedPerWindow = params.MinSignedPerWindow
+	c.DowntimeJailDuration = params.DowntimeJailDuration
+	c.SlashFractionDowntime = params.SlashFractionDowntime
+	c.SlashFractionDoubleSign = params.SlashFractionDoubleSign
+	c.RecalculateMissedBlocksGroups()
+}
+
+// GetSoftOptOutThreshold returns the share of the voting power of the bottom validators
+// that are not required to sign blocks. It's always 0 for sovereign chains.
+func (c *ChainConfig) GetSoftOptOutThreshold() float64 {
+	if !c.IsConsumer.Bool {
+		return 0
+	}
+
+	return c.ConsumerSoftOptOut
+}
+
 func (c *ChainConfig) Validate() error {
 	if c.Name == "" {
 		return errors.New("chain name is not provided")
@@ -75,48 +147,74 @@ func (c *ChainConfig) Validate() error {
 		)
 	}
 
+	if !utils.Contains(constants.GetBlockSources(), c.BlockSource) {
+		return fmt.Errorf(
+			"expected block-source to be one of %s, but got %s",
+			strings.Join(constants.GetBlockSources(), ", "),
+			c.BlockSource,
+		)
+	}
+
 	if len(c.RPCEndpoints) == 0 {
 		return errors.New("chain has 0 RPC endpoints")
 	}
 
+	if c.BlockSource == constants.BlockSourcePoll && c.Intervals.Poll <= 0 {
+		return errors.New("block-source is poll, but poll interval is not positive")
+	}
+
 	if c.FetcherType == constants.FetcherTypeCosmosLCD && len(c.LCDEndpoints) == 0 {
 		return errors.New("chain has 0 LCD endpoints")
 	}
 
-	if len(c.Thresholds) <= 2 {
-		return errors.New("not enough thresholds provided")
+	if c.FetcherType == constants.FetcherTypeCosmosGRPC && len(c.GRPCEndpoints) == 0 {
+		return errors.New("chain has 0 gRPC endpoints")
 	}
 
-	if len(c.Thresholds) != len(c.EmojisStart)+1 {
-		return fmt.Errorf("got %d start emojis but %d thresholds", len(c.EmojisStart), len(c.Thresholds))
+	// unset threshold-mode is treated as window, the same way as when it's omitted in the config file
+	if c.ThresholdMode != "" && !utils.Contains(constants.GetThresholdModes(), c.ThresholdMode) {
+		return fmt.Errorf(
+			"expected threshold-mode to be one of %s, but got %s",
+			strings.Join(constants.GetThresholdModes(), ", "),
+			c.ThresholdMode,
+		)
 	}
 
-	if len(c.Thresholds) != len(c.EmojisEnd)+1 {
-		return fmt.Errorf("got %d end emojis but %d thresholds", len(c.EmojisStart), len(c.Thresholds))
+	if err := ValidateThresholds(
+		c.Thresholds,
+		c.EmojisStart,
+		c.EmojisEnd,
+		c.ThresholdMode == constants.ThresholdModeAbsolute,
+	); err != nil {
+		return err
 	}
 
-	if c.Thresholds[0] != 0 {
-		return fmt.Errorf("first threshold should be 0, but got %.2f", c.Thresholds[0])
+	if c.Reconciliation.Tolerance < 0 {
+		return fmt.Errorf("reconciliation tolerance should not be negative, but got %d", c.Reconciliation.Tolerance)
 	}
 
-	if c.Thresholds[len(c.Thresholds)-1] != 100 {
-		return fmt.Errorf("last threshold should be 100, but got %.2f", c.Thresholds[len(c.Thresholds)-1])
-	}
+	windowsNames := make([]string, 0, len(c.SigningWindows))
 
-	for index, threshold := range c.Thresholds {
-		if index == 0 {
-			continue
+	for index := range c.SigningWindows {
+		window := &c.SigningWindows[index]
+		if err := window.Validate(); err != nil {
+			return err
 		}
 
-		if threshold <= c.Thresholds[index-1] {
+		if window.Name == constants.SlashingWindowName || utils.Contains(windowsNames, window.Name) {
+			return fmt.Errorf("signing window name %s is duplicate or reserved", window.Name)
+		}
+
+		if window.Blocks > c.StoreBlocks {
 			return fmt.Errorf(
-				"threshold at index %d is less than threshold at index %d: %.2f <= %.2f",
-				index,
-				index-1,
-				threshold,
-				c.Thresholds[index-1],
+				"signing window %s: blocks should not exceed store-blocks, but got %d > %d",
+				window.Name,
+				window.Blocks,
+				c.StoreBlocks,
 			)
 		}
+
+		windowsNames = append(windowsNames, window.Name)
 	}
 
 	if c.IsConsumer.Bool {
@@ -128,35 +226,161 @@ func (c *ChainConfig) Validate() error {
 			return errors.New("chain is a consumer, but has 0 provider LCD endpoints")
 		}
 
+		if c.FetcherType == constants.FetcherTypeCosmosGRPC && len(c.ProviderGRPCEndpoints) == 0 {
+			return errors.New("chain is a consumer, but has 0 provider gRPC endpoints")
+		}
+
 		if c.ConsumerID == "" {
 			return errors.New("chain is a consumer, but consumer id is not provided")
 		}
+
+		if len(c.ConsumerIDs) > 0 {
+			return errors.New("consumer-ids can only be set for provider chains")
+		}
+
+		if c.ConsumerSoftOptOut < 0 || c.ConsumerSoftOptOut >= 1 {
+			return fmt.Errorf(
+				"consumer-soft-opt-out should be between 0 and 1, but got %.2f",
+				c.ConsumerSoftOptOut,
+			)
+		}
+	}
+
+	if len(c.ConsumerIDs) > 0 && c.ConsumersMappingPath == "" {
+		return errors.New("consumer-ids are set, but consumers-mapping is not provided")
 	}
 
 	return nil
 }
 
+// IsConsumerDiscoveryEnabled returns true if consumer chains of this provider chain
+// should be discovered and started automatically.
+func (c *ChainConfig) IsConsumerDiscoveryEnabled() bool {
+	return len(c.ConsumerIDs) > 0
+}
+
+// IsConsumerIncluded returns true if a consumer chain discovered on this provider chain
+// matches consumer-ids, either by its consumer ID or chain ID, or if all consumers are included.
+func (c *ChainConfig) IsConsumerIncluded(consumerID string, chainID string) bool {
+	return utils.Contains(c.ConsumerIDs, constants.ConsumerIDsAll) ||
+		utils.Contains(c.ConsumerIDs, consumerID) ||
+		utils.Contains(c.ConsumerIDs, chainID)
+}
+
 func (c *ChainConfig) RecalculateMissedBlocksGroups() {
-	totalRange := float64(c.BlocksWindow) + 1 // from 0 till max blocks allowed, including
-
-	groupsCount := len(c.Thresholds) - 1
-	groups := make([]*MissedBlocksGroup, groupsCount)
-
-	for i := 0; i < groupsCount; i++ {
-		start := totalRange * c.Thresholds[i] / 100
-		end := totalRange*c.Thresholds[i+1]/100 - 1
-
-		groups[i] = &MissedBlocksGroup{
-			Start:      int64(start),
-			End:        int64(end),
-			EmojiStart: c.EmojisStart[i],
-			EmojiEnd:   c.EmojisEnd[i],
-			DescStart:  fmt.Sprintf("is skipping blocks (> %.1f%%)", c.Thresholds[i]),
-			DescEnd:    fmt.Sprintf("is recovering (< %.1f%%)", c.Thresholds[i+1]),
+	c.MissedBlocksGroups = NewMissedBlocksGroups(
+		c.Thresholds,
+		c.EmojisStart,
+		c.EmojisEnd,
+		c.BlocksWindow,
+		c.GetThresholdBlocks,
+		c.FormatThreshold,
+	)
+}
+
+// GetMissedBlocksBase returns the amount of blocks threshold percents are relative to:
+// the jail budget in jail-budget threshold-mode, and the blocks window otherwise.
+func (c *ChainConfig) GetMissedBlocksBase() int64 {
+	if c.ThresholdMode == constants.ThresholdModeJailBudget {
+		// that's how many blocks a validator can miss in the window before getting jailed
+		return c.GetBlocksSignCount()
+	}
+
+	return c.BlocksWindow
+}
+
+// GetThresholdBlocks converts a threshold into the missed blocks count.
+func (c *ChainConfig) GetThresholdBlocks(threshold float64) float64 {
+	if c.ThresholdMode == constants.ThresholdModeAbsolute {
+		return threshold
+	}
+
+	totalRange := float64(c.GetMissedBlocksBase()) + 1 // from 0 till max blocks allowed, including
+	return totalRange * threshold / 100
+}
+
+func (c *ChainConfig) FormatThreshold(threshold float64) string {
+	switch c.ThresholdMode {
+	case constants.ThresholdModeAbsolute:
+		return fmt.Sprintf("%d blocks", int64(threshold))
+	case constants.ThresholdModeJailBudget:
+		return fmt.Sprintf("%.1f%% of jail limit", threshold)
+	default:
+		return fmt.Sprintf("%.1f%%", threshold)
+	}
+}
+
+// FormatMissedBlocksPercent returns the missed blocks count as percent of the jail budget
+// in jail-budget threshold-mode, and as percent of the blocks window otherwise.
+func (c *ChainConfig) FormatMissedBlocksPercent(missed int64) string {
+	percent := float64(missed) / float64(c.GetMissedBlocksBase()) * 100
+
+	if c.ThresholdMode == constants.ThresholdModeJailBudget {
+		return fmt.Sprintf("%.2f%% of jail limit", percent)
+	}
+
+	return fmt.Sprintf("%.2f%%", percent)
+}
+
+// GetPopulatedFields returns toml names of the fields which are periodically overwritten
+// with the values taken from the chain, so their values in the config file are not used.
+func (c *ChainConfig) GetPopulatedFields() []string {
+	populated := []string{}
+
+	if c.Intervals.SlashingParams > 0 {
+		populated = append(populated, "blocks-window", "min-signed-per-window")
+	}
+
+	if c.IsConsumer.Bool && c.Intervals.SoftOptOutThreshold > 0 {
+		populated = append(populated, "consumer-soft-opt-out")
+	}
+
+	return populated
+}
+
+// GetChangedFields returns toml names of the fields which differ between two configs,
+// ignoring the ones populated from the chain.
+func (c *ChainConfig) GetChangedFields(other *ChainConfig) []string {
+	changed := []string{}
+	populated := c.GetPopulatedFields()
+
+	currentValue := reflect.ValueOf(c).Elem()
+	otherValue := reflect.ValueOf(other).Elem()
+	configType := currentValue.Type()
+
+	for i := 0; i < configType.NumField(); i++ {
+		name := configType.Field(i).Tag.Get("toml")
+		if name == "" || name == "-" || utils.Contains(populated, name) {
+			continue
+		}
+
+		if !reflect.DeepEqual(currentValue.Field(i).Interface(), otherValue.Field(i).Interface()) {
+			changed = append(changed, name)
 		}
 	}
 
-	groups[0].DescEnd = fmt.Sprintf("is recovered (< %.1f%%)", c.Thresholds[1])
+	return changed
+}
+
+// IsHotReloadable returns true if all the changed fields can be applied
+// without restarting the chain.
+func IsHotReloadable(changedFields []string) bool {
+	notReloadable := utils.Filter(changedFields, func(field string) bool {
+		return !utils.Contains(HotReloadableFields, field)
+	})
+
+	return len(notReloadable) == 0
+}
 
-	c.MissedBlocksGroups = groups
+// ApplyHotReloadable copies the fields that can be changed without a restart
+// from another config and recalculates missed blocks groups.
+func (c *ChainConfig) ApplyHotReloadable(other *ChainConfig) {
+	c.PrettyName = other.PrettyName
+	c.SnapshotsInterval = other.SnapshotsInterval
+	c.ThresholdMode = other.ThresholdMode
+	c.Thresholds = other.Thresholds
+	c.EmojisStart = other.EmojisStart
+	c.EmojisEnd = other.EmojisEnd
+	c.ExplorerConfig = other.ExplorerConfig
+	c.RecalculateMissedBlocksGroups()
 }
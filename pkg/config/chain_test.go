@@ -38,6 +38,16 @@ func TestGetChainBlocksMissCount(t *testing.T) {
 	assert.Equal(t, int64(500), config.GetBlocksMissCount(), "Blocks miss count does not match!")
 }
 
+func TestGetChainSoftOptOutThreshold(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{ConsumerSoftOptOut: 0.05}
+	assert.InDelta(t, 0, config.GetSoftOptOutThreshold(), 0.001)
+
+	config.IsConsumer = null.BoolFrom(true)
+	assert.InDelta(t, 0.05, config.GetSoftOptOutThreshold(), 0.001)
+}
+
 func TestValidateChainWithoutName(t *testing.T) {
 	t.Parallel()
 
@@ -72,6 +82,7 @@ func TestValidateConsumerChainWithoutChainID(t *testing.T) {
 	config := &ChainConfig{
 		Name:                 "chain",
 		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
 		RPCEndpoints:         []string{"endpoint"},
 		IsConsumer:           null.BoolFrom(true),
 		ProviderRPCEndpoints: []string{"endpoint"},
@@ -86,6 +97,7 @@ func TestValidateNotEnoughThresholds(t *testing.T) {
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{0, 100},
 		EmojisStart:  []string{"x"},
@@ -101,6 +113,7 @@ func TestValidateNotEnoughStartEmojis(t *testing.T) {
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{0, 50, 100},
 		EmojisStart:  []string{"x"},
@@ -116,6 +129,7 @@ func TestValidateNotEnoughEndEmojis(t *testing.T) {
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{0, 50, 100},
 		EmojisStart:  []string{"x", "y"},
@@ -131,6 +145,7 @@ func TestValidateFirstThresholdNotZero(t *testing.T) {
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{1, 50, 100},
 		EmojisStart:  []string{"x", "y"},
@@ -146,6 +161,7 @@ func TestValidateLastThresholdNotHundred(t *testing.T) {
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{0, 50, 95},
 		EmojisStart:  []string{"x", "y"},
@@ -161,6 +177,7 @@ func TestValidateThresholdsInconsistent(t *testing.T) {
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{0, 75, 25, 100},
 		EmojisStart:  []string{"x", "y", "z"},
@@ -170,6 +187,57 @@ func TestValidateThresholdsInconsistent(t *testing.T) {
 	require.Error(t, err, "Error should be present!")
 }
 
+func TestValidateInvalidThresholdMode(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:          "chain",
+		RPCEndpoints:  []string{"endpoint"},
+		FetcherType:   "cosmos-rpc",
+		BlockSource:   "websocket",
+		ThresholdMode: "nonexistent",
+		Thresholds:    []float64{0, 50, 100},
+		EmojisStart:   []string{"x", "y"},
+		EmojisEnd:     []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.ErrorContains(t, err, "expected threshold-mode to be one of")
+}
+
+func TestValidateAbsoluteThresholdNotWhole(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:          "chain",
+		RPCEndpoints:  []string{"endpoint"},
+		FetcherType:   "cosmos-rpc",
+		BlockSource:   "websocket",
+		ThresholdMode: "absolute",
+		Thresholds:    []float64{0, 50.5, 100},
+		EmojisStart:   []string{"x", "y"},
+		EmojisEnd:     []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.ErrorContains(t, err, "threshold at index 1 should be a whole blocks count")
+}
+
+func TestValidateAbsoluteThresholdsValid(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:          "chain",
+		RPCEndpoints:  []string{"endpoint"},
+		FetcherType:   "cosmos-rpc",
+		BlockSource:   "websocket",
+		ThresholdMode: "absolute",
+		Thresholds:    []float64{0, 10, 250},
+		EmojisStart:   []string{"x", "y"},
+		EmojisEnd:     []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.NoError(t, err)
+}
+
 func TestValidateInvalidFetcherType(t *testing.T) {
 	t.Parallel()
 
@@ -185,6 +253,40 @@ func TestValidateInvalidFetcherType(t *testing.T) {
 	require.Error(t, err, "Error should be present!")
 }
 
+func TestValidateInvalidBlockSource(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"endpoint"},
+		FetcherType:  "cosmos-rpc",
+		BlockSource:  "nonexistent",
+		Thresholds:   []float64{0, 50, 100},
+		EmojisStart:  []string{"x", "y"},
+		EmojisEnd:    []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+	require.ErrorContains(t, err, "expected block-source to be one of")
+}
+
+func TestValidatePollWithoutInterval(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"endpoint"},
+		FetcherType:  "cosmos-rpc",
+		BlockSource:  "poll",
+		Thresholds:   []float64{0, 50, 100},
+		EmojisStart:  []string{"x", "y"},
+		EmojisEnd:    []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+	require.ErrorContains(t, err, "poll interval is not positive")
+}
+
 func TestValidateLCDWithoutLCDEndpoints(t *testing.T) {
 	t.Parallel()
 
@@ -192,6 +294,7 @@ func TestValidateLCDWithoutLCDEndpoints(t *testing.T) {
 		Name:         "chain",
 		RPCEndpoints: []string{"endpoint"},
 		FetcherType:  "cosmos-lcd",
+		BlockSource:  "websocket",
 		Thresholds:   []float64{0, 50, 100},
 		EmojisStart:  []string{"x", "y"},
 		EmojisEnd:    []string{"x", "y"},
@@ -207,6 +310,7 @@ func TestValidateChainValid(t *testing.T) {
 		Name:         "chain",
 		RPCEndpoints: []string{"endpoint"},
 		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
 		Thresholds:   []float64{0, 50, 100},
 		EmojisStart:  []string{"x", "y"},
 		EmojisEnd:    []string{"x", "y"},
@@ -215,12 +319,75 @@ func TestValidateChainValid(t *testing.T) {
 	require.NoError(t, err, "Error should not be present!")
 }
 
+func TestValidateChainNegativeReconciliationTolerance(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:           "chain",
+		RPCEndpoints:   []string{"endpoint"},
+		FetcherType:    "cosmos-rpc",
+		BlockSource:    "websocket",
+		StoreBlocks:    20000,
+		Thresholds:     []float64{0, 50, 100},
+		EmojisStart:    []string{"x", "y"},
+		EmojisEnd:      []string{"x", "y"},
+		Reconciliation: ReconciliationConfig{Tolerance: -1},
+	}
+
+	err := config.Validate()
+	require.ErrorContains(t, err, "reconciliation tolerance should not be negative")
+}
+
+func TestValidateChainSigningWindows(t *testing.T) {
+	t.Parallel()
+
+	window := func(name string, blocks int64) SigningWindowConfig {
+		return SigningWindowConfig{
+			Name:        name,
+			Blocks:      blocks,
+			Thresholds:  []float64{0, 50, 100},
+			EmojisStart: []string{"x", "y"},
+			EmojisEnd:   []string{"x", "y"},
+		}
+	}
+
+	testCases := map[string][]SigningWindowConfig{
+		"blocks should be positive":         {window("short", 0)},
+		"short is duplicate or reserved":    {window("short", 100), window("short", 200)},
+		"slashing is duplicate or reserved": {window("slashing", 100)},
+		"should not exceed store-blocks":    {window("short", 20001)},
+		"":                                  {window("short", 100), window("medium", 1000)},
+	}
+
+	for expected, windows := range testCases {
+		config := &ChainConfig{
+			Name:           "chain",
+			RPCEndpoints:   []string{"endpoint"},
+			FetcherType:    "cosmos-rpc",
+			BlockSource:    "websocket",
+			StoreBlocks:    20000,
+			Thresholds:     []float64{0, 50, 100},
+			EmojisStart:    []string{"x", "y"},
+			EmojisEnd:      []string{"x", "y"},
+			SigningWindows: windows,
+		}
+
+		err := config.Validate()
+		if expected == "" {
+			require.NoError(t, err, "Error should not be present!")
+		} else {
+			require.ErrorContains(t, err, expected)
+		}
+	}
+}
+
 func TestValidateLCDChainValid(t *testing.T) {
 	t.Parallel()
 
 	config := &ChainConfig{
 		Name:         "chain",
 		FetcherType:  "cosmos-lcd",
+		BlockSource:  "websocket",
 		RPCEndpoints: []string{"endpoint"},
 		LCDEndpoints: []string{"endpoint"},
 		Thresholds:   []float64{0, 50, 100},
@@ -237,6 +404,7 @@ func TestValidateConsumerChainWithoutEndpoints(t *testing.T) {
 	config := &ChainConfig{
 		Name:                 "chain",
 		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
 		RPCEndpoints:         []string{"endpoint"},
 		IsConsumer:           null.BoolFrom(true),
 		ProviderRPCEndpoints: []string{},
@@ -255,6 +423,7 @@ func TestValidateConsumerChainWithoutChainId(t *testing.T) {
 	config := &ChainConfig{
 		Name:                 "chain",
 		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
 		RPCEndpoints:         []string{"endpoint"},
 		IsConsumer:           null.BoolFrom(true),
 		ProviderRPCEndpoints: []string{"endpoint"},
@@ -273,6 +442,7 @@ func TestValidateLCDConsumerChainWithoutProviderLCDEndpoints(t *testing.T) {
 	config := &ChainConfig{
 		Name:                 "chain",
 		FetcherType:          "cosmos-lcd",
+		BlockSource:          "websocket",
 		RPCEndpoints:         []string{"endpoint"},
 		LCDEndpoints:         []string{"endpoint"},
 		IsConsumer:           null.BoolFrom(true),
@@ -292,6 +462,7 @@ func TestValidateConsumerChainValid(t *testing.T) {
 	config := &ChainConfig{
 		Name:                 "chain",
 		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
 		RPCEndpoints:         []string{"endpoint"},
 		IsConsumer:           null.BoolFrom(true),
 		ProviderRPCEndpoints: []string{"endpoint"},
@@ -304,12 +475,108 @@ func TestValidateConsumerChainValid(t *testing.T) {
 	require.NoError(t, err, "Error should not be present!")
 }
 
+func TestValidateConsumerChainInvalidSoftOptOut(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:                 "chain",
+		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
+		RPCEndpoints:         []string{"endpoint"},
+		IsConsumer:           null.BoolFrom(true),
+		ProviderRPCEndpoints: []string{"endpoint"},
+		ConsumerID:           "chain",
+		ConsumerSoftOptOut:   1,
+		Thresholds:           []float64{0, 50, 100},
+		EmojisStart:          []string{"x", "y"},
+		EmojisEnd:            []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+	require.ErrorContains(t, err, "consumer-soft-opt-out should be between 0 and 1")
+}
+
+func TestValidateConsumerChainWithConsumerIDs(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:                 "chain",
+		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
+		RPCEndpoints:         []string{"endpoint"},
+		IsConsumer:           null.BoolFrom(true),
+		ProviderRPCEndpoints: []string{"endpoint"},
+		ConsumerID:           "chain",
+		ConsumerIDs:          []string{"*"},
+		ConsumersMappingPath: "consumers.toml",
+		Thresholds:           []float64{0, 50, 100},
+		EmojisStart:          []string{"x", "y"},
+		EmojisEnd:            []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+	require.ErrorContains(t, err, "consumer-ids can only be set for provider chains")
+}
+
+func TestValidateProviderChainNoConsumersMapping(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:         "chain",
+		FetcherType:  "cosmos-rpc",
+		BlockSource:  "websocket",
+		RPCEndpoints: []string{"endpoint"},
+		ConsumerIDs:  []string{"*"},
+		Thresholds:   []float64{0, 50, 100},
+		EmojisStart:  []string{"x", "y"},
+		EmojisEnd:    []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+	require.ErrorContains(t, err, "consumer-ids are set, but consumers-mapping is not provided")
+}
+
+func TestValidateProviderChainWithConsumerIDsValid(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:                 "chain",
+		FetcherType:          "cosmos-rpc",
+		BlockSource:          "websocket",
+		RPCEndpoints:         []string{"endpoint"},
+		ConsumerIDs:          []string{"*"},
+		ConsumersMappingPath: "consumers.toml",
+		Thresholds:           []float64{0, 50, 100},
+		EmojisStart:          []string{"x", "y"},
+		EmojisEnd:            []string{"x", "y"},
+	}
+	require.NoError(t, config.Validate())
+}
+
+func TestChainIsConsumerIncluded(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{Name: "chain"}
+	require.False(t, config.IsConsumerDiscoveryEnabled())
+	require.False(t, config.IsConsumerIncluded("0", "neutron-1"))
+
+	config.ConsumerIDs = []string{"0", "stride-1"}
+	require.True(t, config.IsConsumerDiscoveryEnabled())
+	require.True(t, config.IsConsumerIncluded("0", "neutron-1"))
+	require.True(t, config.IsConsumerIncluded("1", "stride-1"))
+	require.False(t, config.IsConsumerIncluded("2", "unknown-1"))
+
+	config.ConsumerIDs = []string{"*"}
+	require.True(t, config.IsConsumerIncluded("2", "unknown-1"))
+}
+
 func TestValidateLCDConsumerChainValid(t *testing.T) {
 	t.Parallel()
 
 	config := &ChainConfig{
 		Name:                 "chain",
 		FetcherType:          "cosmos-lcd",
+		BlockSource:          "websocket",
 		RPCEndpoints:         []string{"endpoint"},
 		IsConsumer:           null.BoolFrom(true),
 		LCDEndpoints:         []string{"endpoint"},
@@ -322,3 +589,240 @@ func TestValidateLCDConsumerChainValid(t *testing.T) {
 	err := config.Validate()
 	require.NoError(t, err, "Error should not be present!")
 }
+
+func TestValidateGRPCWithoutGRPCEndpoints(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"endpoint"},
+		FetcherType:  "cosmos-grpc",
+		BlockSource:  "websocket",
+		Thresholds:   []float64{0, 50, 100},
+		EmojisStart:  []string{"x", "y"},
+		EmojisEnd:    []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+}
+
+func TestValidateGRPCConsumerChainWithoutProviderGRPCEndpoints(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:          "chain",
+		FetcherType:   "cosmos-grpc",
+		BlockSource:   "websocket",
+		RPCEndpoints:  []string{"endpoint"},
+		GRPCEndpoints: []string{"endpoint"},
+		IsConsumer:    null.BoolFrom(true),
+		ConsumerID:    "chain",
+		Thresholds:    []float64{0, 50, 100},
+		EmojisStart:   []string{"x", "y"},
+		EmojisEnd:     []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.Error(t, err, "Error should be present!")
+}
+
+func TestValidateGRPCConsumerChainValid(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:                  "chain",
+		FetcherType:           "cosmos-grpc",
+		BlockSource:           "websocket",
+		RPCEndpoints:          []string{"endpoint"},
+		IsConsumer:            null.BoolFrom(true),
+		GRPCEndpoints:         []string{"endpoint"},
+		ProviderGRPCEndpoints: []string{"endpoint"},
+		ConsumerID:            "chain",
+		Thresholds:            []float64{0, 50, 100},
+		EmojisStart:           []string{"x", "y"},
+		EmojisEnd:             []string{"x", "y"},
+	}
+	err := config.Validate()
+	require.NoError(t, err, "Error should not be present!")
+}
+
+func TestChainGetChangedFields(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+		Thresholds:   []float64{0, 50, 100},
+	}
+	other := &ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+		Thresholds:   []float64{0, 25, 100},
+		ExplorerConfig: ExplorerConfig{
+			MintscanPrefix: "cosmos",
+		},
+		MissedBlocksGroups: MissedBlocksGroups{{Start: 0, End: 1}},
+	}
+
+	require.Equal(t, []string{"thresholds", "explorer"}, config.GetChangedFields(other))
+	require.Empty(t, config.GetChangedFields(config))
+}
+
+func TestChainGetChangedFieldsPopulated(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:               "chain",
+		IsConsumer:         null.BoolFrom(true),
+		BlocksWindow:       100,
+		MinSignedPerWindow: 0.5,
+		ConsumerSoftOptOut: 0.1,
+		Intervals:          IntervalsConfig{SlashingParams: 300, SoftOptOutThreshold: 300},
+	}
+	other := &ChainConfig{
+		Name:               "chain",
+		IsConsumer:         null.BoolFrom(true),
+		BlocksWindow:       10000,
+		MinSignedPerWindow: 0.05,
+		ConsumerSoftOptOut: 0.05,
+		Intervals:          IntervalsConfig{SlashingParams: 300, SoftOptOutThreshold: 300},
+	}
+
+	require.Equal(
+		t,
+		[]string{"blocks-window", "min-signed-per-window", "consumer-soft-opt-out"},
+		config.GetPopulatedFields(),
+	)
+	require.Empty(t, config.GetChangedFields(other))
+
+	config.Intervals.SlashingParams = 0
+	require.Equal(t, []string{"blocks-window", "min-signed-per-window", "intervals"}, config.GetChangedFields(other))
+}
+
+func TestChainIsHotReloadable(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsHotReloadable([]string{}))
+	require.True(t, IsHotReloadable([]string{"thresholds", "emoji-start", "explorer"}))
+	require.False(t, IsHotReloadable([]string{"thresholds", "rpc-endpoints"}))
+}
+
+func TestChainApplyHotReloadable(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		Name:         "chain",
+		BlocksWindow: 100,
+		Thresholds:   []float64{0, 50, 100},
+		EmojisStart:  []string{"x", "x"},
+		EmojisEnd:    []string{"y", "y"},
+	}
+	config.RecalculateMissedBlocksGroups()
+
+	config.ApplyHotReloadable(&ChainConfig{
+		Name:              "other",
+		PrettyName:        "Chain",
+		SnapshotsInterval: 10,
+		Thresholds:        []float64{0, 10, 100},
+		EmojisStart:       []string{"a", "b"},
+		EmojisEnd:         []string{"c", "d"},
+		ExplorerConfig:    ExplorerConfig{MintscanPrefix: "cosmos"},
+	})
+
+	require.Equal(t, "chain", config.Name)
+	require.Equal(t, "Chain", config.PrettyName)
+	require.Equal(t, int64(10), config.SnapshotsInterval)
+	require.Equal(t, "cosmos", config.ExplorerConfig.MintscanPrefix)
+	require.Len(t, config.MissedBlocksGroups, 2)
+	require.Equal(t, int64(10), config.MissedBlocksGroups[1].Start)
+	require.Equal(t, "b", config.MissedBlocksGroups[1].EmojiStart)
+}
+
+func TestChainRecalculateMissedBlocksGroupsWindow(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		BlocksWindow:       1000,
+		MinSignedPerWindow: 0.95,
+		ThresholdMode:      "window",
+		Thresholds:         []float64{0, 50, 100},
+		EmojisStart:        []string{"x", "y"},
+		EmojisEnd:          []string{"x", "y"},
+	}
+	config.RecalculateMissedBlocksGroups()
+
+	require.NoError(t, config.MissedBlocksGroups.Validate(config.BlocksWindow))
+	require.Equal(t, MissedBlocksGroups{
+		{Start: 0, End: 499, EmojiStart: "x", EmojiEnd: "x", DescStart: "is skipping blocks (> 0.0%)", DescEnd: "is recovered (< 50.0%)"},
+		{Start: 500, End: 1000, EmojiStart: "y", EmojiEnd: "y", DescStart: "is skipping blocks (> 50.0%)", DescEnd: "is recovering (< 100.0%)"},
+	}, config.MissedBlocksGroups)
+	require.Equal(t, "25.00%", config.FormatMissedBlocksPercent(250))
+}
+
+func TestChainRecalculateMissedBlocksGroupsJailBudget(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		BlocksWindow:       1000,
+		MinSignedPerWindow: 0.75,
+		ThresholdMode:      "jail-budget",
+		Thresholds:         []float64{0, 50, 100},
+		EmojisStart:        []string{"x", "y"},
+		EmojisEnd:          []string{"x", "y"},
+	}
+	config.RecalculateMissedBlocksGroups()
+
+	require.NoError(t, config.MissedBlocksGroups.Validate(config.BlocksWindow))
+	require.Equal(t, MissedBlocksGroups{
+		{
+			Start:      0,
+			End:        124,
+			EmojiStart: "x",
+			EmojiEnd:   "x",
+			DescStart:  "is skipping blocks (> 0.0% of jail limit)",
+			DescEnd:    "is recovered (< 50.0% of jail limit)",
+		},
+		{
+			Start:      125,
+			End:        1000,
+			EmojiStart: "y",
+			EmojiEnd:   "y",
+			DescStart:  "is skipping blocks (> 50.0% of jail limit)",
+			DescEnd:    "is recovering (< 100.0% of jail limit)",
+		},
+	}, config.MissedBlocksGroups)
+	require.Equal(t, "24.80% of jail limit", config.FormatMissedBlocksPercent(62))
+}
+
+func TestChainRecalculateMissedBlocksGroupsAbsolute(t *testing.T) {
+	t.Parallel()
+
+	config := &ChainConfig{
+		BlocksWindow:  1000,
+		ThresholdMode: "absolute",
+		Thresholds:    []float64{0, 10, 250},
+		EmojisStart:   []string{"x", "y"},
+		EmojisEnd:     []string{"x", "y"},
+	}
+	config.RecalculateMissedBlocksGroups()
+
+	require.NoError(t, config.MissedBlocksGroups.Validate(config.BlocksWindow))
+	require.Equal(t, MissedBlocksGroups{
+		{
+			Start:      0,
+			End:        9,
+			EmojiStart: "x",
+			EmojiEnd:   "x",
+			DescStart:  "is skipping blocks (> 0 blocks)",
+			DescEnd:    "is recovered (< 10 blocks)",
+		},
+		{
+			Start:      10,
+			End:        1000,
+			EmojiStart: "y",
+			EmojiEnd:   "y",
+			DescStart:  "is skipping blocks (> 10 blocks)",
+			DescEnd:    "is recovering (< 250 blocks)",
+		},
+	}, config.MissedBlocksGroups)
+	require.Equal(t, "25.00%", config.FormatMissedBlocksPercent(250))
+}
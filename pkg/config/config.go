@@ -14,6 +14,9 @@ type Config struct {
 	ChainConfigs   []*ChainConfig `toml:"chains"`
 	DatabaseConfig DatabaseConfig `toml:"database"`
 	MetricsConfig  MetricsConfig  `toml:"metrics"`
+	ShutdownConfig ShutdownConfig `toml:"shutdown"`
+	WatchdogConfig WatchdogConfig `toml:"watchdog"`
+	OutboxConfig   OutboxConfig   `toml:"outbox"`
 }
 
 func (config *Config) Validate() error {
@@ -46,6 +46,11 @@ func TestLoadConfigValid(t *testing.T) {
 
 	require.NoError(t, err)
 	require.NotNil(t, config)
+
+	// signing windows get the default thresholds and emojis
+	require.Len(t, config.ChainConfigs[0].SigningWindows, 1)
+	require.NoError(t, config.ChainConfigs[0].SigningWindows[0].Validate())
+	require.Equal(t, []float64{0, 10, 50, 100}, config.ChainConfigs[0].SigningWindows[0].Thresholds)
 }
 
 func TestValidateConfigEmptyChains(t *testing.T) {
@@ -76,6 +81,7 @@ func TestValidateConfigInvalidDatabase(t *testing.T) {
 			{
 				Name:         "chain",
 				FetcherType:  "cosmos-rpc",
+				BlockSource:  "websocket",
 				RPCEndpoints: []string{"https://example.com"},
 				Thresholds:   []float64{0, 50, 100},
 				EmojisStart:  []string{"x", "y"},
@@ -97,6 +103,7 @@ func TestValidateConfigValid(t *testing.T) {
 			{
 				Name:         "chain",
 				FetcherType:  "cosmos-rpc",
+				BlockSource:  "websocket",
 				RPCEndpoints: []string{"https://example.com"},
 				Thresholds:   []float64{0, 50, 100},
 				EmojisStart:  []string{"x", "y"},
@@ -109,3 +116,38 @@ func TestValidateConfigValid(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestLoadConsumersMappingErrorReading(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := configPkg.GetConsumersMapping("nonexistent.toml", &TmpFSInterface{})
+
+	require.Error(t, err)
+	require.Nil(t, mapping)
+}
+
+func TestLoadConsumersMappingInvalidToml(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := configPkg.GetConsumersMapping("invalid.toml", &TmpFSInterface{})
+
+	require.Error(t, err)
+	require.Nil(t, mapping)
+}
+
+func TestLoadConsumersMappingValid(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := configPkg.GetConsumersMapping("consumers-mapping.toml", &TmpFSInterface{})
+
+	require.NoError(t, err)
+	require.Len(t, mapping.Consumers, 3)
+	require.Equal(t, int64(20000), mapping.Consumers[0].StoreBlocks)
+
+	consumer, found := mapping.FindByChainID("stride-1")
+	require.True(t, found)
+	require.Equal(t, "stride", consumer.ExplorerConfig.MintscanPrefix)
+
+	_, found = mapping.FindByChainID("unknown-1")
+	require.False(t, found)
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"main/pkg/fs"
+	"main/pkg/utils"
+
+	"github.com/BurntSushi/toml"
+	"github.com/creasty/defaults"
+)
+
+// ConsumersMapping describes consumer chains that can be discovered on a provider chain,
+// matched by chain-id: their endpoints, reporters and other params. Consumer ID and provider
+// endpoints are taken from the provider chain, so they should not be set there.
+type ConsumersMapping struct {
+	Consumers []*ChainConfig `toml:"consumers"`
+}
+
+func GetConsumersMapping(path string, filesystem fs.FS) (*ConsumersMapping, error) {
+	mappingBytes, err := filesystem.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &ConsumersMapping{}
+	if _, err = toml.Decode(string(mappingBytes), mapping); err != nil {
+		return nil, err
+	}
+	defaults.MustSet(mapping)
+
+	return mapping, nil
+}
+
+func (m *ConsumersMapping) FindByChainID(chainID string) (*ChainConfig, bool) {
+	return utils.Find(m.Consumers, func(c *ChainConfig) bool {
+		return c.ChainID == chainID
+	})
+}
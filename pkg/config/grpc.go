@@ -0,0 +1,7 @@
+package config
+
+type GRPCTLSConfig struct {
+	Enabled            bool   `toml:"enabled"`
+	InsecureSkipVerify bool   `toml:"insecure-skip-verify"`
+	CAFile             string `toml:"ca-file"`
+}
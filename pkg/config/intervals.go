@@ -3,7 +3,13 @@ package config
 import "time"
 
 type IntervalsConfig struct {
-	Blocks         time.Duration `default:"30"  toml:"blocks"`
-	Trim           time.Duration `default:"300" toml:"trim"`
-	SlashingParams time.Duration `default:"300" toml:"slashing-params"`
+	Blocks              time.Duration `default:"30"  toml:"blocks"`
+	Trim                time.Duration `default:"300" toml:"trim"`
+	SlashingParams      time.Duration `default:"300" toml:"slashing-params"`
+	NodesLag            time.Duration `default:"60"  toml:"nodes-lag"`
+	Poll                time.Duration `default:"5"   toml:"poll"`
+	SoftOptOutThreshold time.Duration `default:"300" toml:"soft-opt-out-threshold"`
+	ConsumersDiscovery  time.Duration `default:"300" toml:"consumers-discovery"`
+	UnjailReminders     time.Duration `default:"300" toml:"unjail-reminders"`
+	Reconciliation      time.Duration `default:"300" toml:"reconciliation"`
 }
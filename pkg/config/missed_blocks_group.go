@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
 )
 
 type MissedBlocksGroup struct {
@@ -16,6 +17,88 @@ type MissedBlocksGroup struct {
 
 type MissedBlocksGroups []*MissedBlocksGroup
 
+// ValidateThresholds checks the thresholds and emojis missed blocks groups are built from.
+// Absolute thresholds are whole blocks counts, otherwise they are percents, the last of which is 100.
+func ValidateThresholds(thresholds []float64, emojisStart, emojisEnd []string, absolute bool) error {
+	if len(thresholds) <= 2 {
+		return errors.New("not enough thresholds provided")
+	}
+
+	if len(thresholds) != len(emojisStart)+1 {
+		return fmt.Errorf("got %d start emojis but %d thresholds", len(emojisStart), len(thresholds))
+	}
+
+	if len(thresholds) != len(emojisEnd)+1 {
+		return fmt.Errorf("got %d end emojis but %d thresholds", len(emojisEnd), len(thresholds))
+	}
+
+	if thresholds[0] != 0 {
+		return fmt.Errorf("first threshold should be 0, but got %.2f", thresholds[0])
+	}
+
+	if !absolute && thresholds[len(thresholds)-1] != 100 {
+		return fmt.Errorf("last threshold should be 100, but got %.2f", thresholds[len(thresholds)-1])
+	}
+
+	for index, threshold := range thresholds {
+		if index == 0 {
+			continue
+		}
+
+		if absolute && threshold != math.Trunc(threshold) {
+			return fmt.Errorf("threshold at index %d should be a whole blocks count, but got %.2f", index, threshold)
+		}
+
+		if threshold <= thresholds[index-1] {
+			return fmt.Errorf(
+				"threshold at index %d is less than threshold at index %d: %.2f <= %.2f",
+				index,
+				index-1,
+				threshold,
+				thresholds[index-1],
+			)
+		}
+	}
+
+	return nil
+}
+
+// NewMissedBlocksGroups builds missed blocks groups from validated thresholds, converting them
+// into missed blocks counts with toBlocks and describing them with format.
+// The last group covers the whole window.
+func NewMissedBlocksGroups(
+	thresholds []float64,
+	emojisStart []string,
+	emojisEnd []string,
+	window int64,
+	toBlocks func(threshold float64) float64,
+	format func(threshold float64) string,
+) MissedBlocksGroups {
+	groupsCount := len(thresholds) - 1
+	groups := make(MissedBlocksGroups, groupsCount)
+
+	for i := 0; i < groupsCount; i++ {
+		groups[i] = &MissedBlocksGroup{
+			Start:      int64(toBlocks(thresholds[i])),
+			End:        int64(toBlocks(thresholds[i+1])) - 1,
+			EmojiStart: emojisStart[i],
+			EmojiEnd:   emojisEnd[i],
+			DescStart:  "is skipping blocks (> " + format(thresholds[i]) + ")",
+			DescEnd:    "is recovering (< " + format(thresholds[i+1]) + ")",
+		}
+	}
+
+	groups[0].DescEnd = "is recovered (< " + format(thresholds[1]) + ")"
+
+	// a validator can miss more blocks than the jail budget or the last absolute threshold
+	// before the jail is processed, so the last group should cover the whole window
+	if lastGroup := groups[groupsCount-1]; lastGroup.End < window {
+		lastGroup.End = window
+	}
+
+	return groups
+}
+
 // Validate checks that MissedBlocksGroup is an array of sorted MissedBlocksGroup
 // covering each interval.
 // Example (start - end), given that window = 300:
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type OutboxConfig struct {
+	MaxAttempts int64         `default:"100" toml:"max-attempts"`
+	BaseBackoff time.Duration `default:"5"   toml:"base-backoff"`
+	MaxBackoff  time.Duration `default:"600" toml:"max-backoff"`
+}
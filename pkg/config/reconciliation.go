@@ -0,0 +1,14 @@
+package config
+
+import "gopkg.in/guregu/null.v4"
+
+// ReconciliationConfig sets how validators' missed blocks counted locally are cross-checked
+// with the on-chain missed blocks counter, which runs every intervals.reconciliation.
+type ReconciliationConfig struct {
+	// Tolerance is how many missed blocks the counters can differ by without being reported,
+	// as the signing info is fetched at a bit different height than the latest block.
+	Tolerance int64 `default:"10" toml:"tolerance"`
+	// Refetch sets whether to remove the blocks a validator is counted as missed at locally,
+	// if it has more missed blocks than on chain, so they are fetched again.
+	Refetch null.Bool `default:"false" toml:"refetch"`
+}
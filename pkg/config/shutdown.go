@@ -0,0 +1,12 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type ShutdownConfig struct {
+	Timeout       time.Duration `default:"30"    toml:"timeout"`
+	OfflineNotice null.Bool     `default:"false" toml:"offline-notice"`
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SigningWindowConfig is an additional window missed blocks are tracked in alongside
+// the slashing window, with its own thresholds, so a short window can alert quickly
+// when a validator goes down while the slashing window tracks the jail risk.
+type SigningWindowConfig struct {
+	Name        string    `toml:"name"`
+	Blocks      int64     `toml:"blocks"`
+	Thresholds  []float64 `default:"[0, 10, 50, 100]"                toml:"thresholds"`
+	EmojisStart []string  `default:"[\"🟡\", \"🟠\", \"🔴\"]" toml:"emoji-start"`
+	EmojisEnd   []string  `default:"[\"🟢\", \"🟡\", \"🟠\"]" toml:"emoji-end"`
+}
+
+func (w *SigningWindowConfig) Validate() error {
+	if w.Name == "" {
+		return errors.New("signing window name is not provided")
+	}
+
+	if w.Blocks <= 0 {
+		return fmt.Errorf("signing window %s: blocks should be positive, but got %d", w.Name, w.Blocks)
+	}
+
+	if err := ValidateThresholds(w.Thresholds, w.EmojisStart, w.EmojisEnd, false); err != nil {
+		return fmt.Errorf("signing window %s: %w", w.Name, err)
+	}
+
+	return nil
+}
+
+// GetMissedBlocksGroups returns missed blocks groups for this window,
+// its thresholds are percents of the window blocks.
+func (w *SigningWindowConfig) GetMissedBlocksGroups() MissedBlocksGroups {
+	totalRange := float64(w.Blocks) + 1 // from 0 till all blocks in the window missed, including
+
+	return NewMissedBlocksGroups(
+		w.Thresholds,
+		w.EmojisStart,
+		w.EmojisEnd,
+		w.Blocks,
+		func(threshold float64) float64 {
+			return totalRange * threshold / 100
+		},
+		func(threshold float64) string {
+			return fmt.Sprintf("%.1f%% of last %d blocks", threshold, w.Blocks)
+		},
+	)
+}
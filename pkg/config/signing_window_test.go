@@ -0,0 +1,110 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningWindowValidateInvalid(t *testing.T) {
+	t.Parallel()
+
+	windows := map[string]SigningWindowConfig{
+		"name is not provided": {Blocks: 100},
+		"blocks should be positive": {
+			Name: "short",
+		},
+		"not enough thresholds provided": {
+			Name:        "short",
+			Blocks:      100,
+			Thresholds:  []float64{0, 100},
+			EmojisStart: []string{"x"},
+			EmojisEnd:   []string{"x"},
+		},
+		"start emojis": {
+			Name:        "short",
+			Blocks:      100,
+			Thresholds:  []float64{0, 50, 100},
+			EmojisStart: []string{"x"},
+			EmojisEnd:   []string{"x", "y"},
+		},
+		"end emojis": {
+			Name:        "short",
+			Blocks:      100,
+			Thresholds:  []float64{0, 50, 100},
+			EmojisStart: []string{"x", "y"},
+			EmojisEnd:   []string{"x"},
+		},
+		"first threshold should be 0": {
+			Name:        "short",
+			Blocks:      100,
+			Thresholds:  []float64{1, 50, 100},
+			EmojisStart: []string{"x", "y"},
+			EmojisEnd:   []string{"x", "y"},
+		},
+		"last threshold should be 100": {
+			Name:        "short",
+			Blocks:      100,
+			Thresholds:  []float64{0, 50, 90},
+			EmojisStart: []string{"x", "y"},
+			EmojisEnd:   []string{"x", "y"},
+		},
+		"threshold at index 2 is less than threshold at index 1": {
+			Name:        "short",
+			Blocks:      100,
+			Thresholds:  []float64{0, 50, 50, 100},
+			EmojisStart: []string{"x", "y", "z"},
+			EmojisEnd:   []string{"x", "y", "z"},
+		},
+	}
+
+	for expected, window := range windows {
+		err := window.Validate()
+		require.Error(t, err, "Error should be present!")
+		require.ErrorContains(t, err, expected)
+	}
+}
+
+func TestSigningWindowValidateValid(t *testing.T) {
+	t.Parallel()
+
+	window := SigningWindowConfig{
+		Name:        "short",
+		Blocks:      100,
+		Thresholds:  []float64{0, 50, 100},
+		EmojisStart: []string{"x", "y"},
+		EmojisEnd:   []string{"x", "y"},
+	}
+
+	require.NoError(t, window.Validate(), "Error should not be present!")
+}
+
+func TestSigningWindowGetMissedBlocksGroups(t *testing.T) {
+	t.Parallel()
+
+	window := SigningWindowConfig{
+		Name:        "short",
+		Blocks:      100,
+		Thresholds:  []float64{0, 10, 50, 100},
+		EmojisStart: []string{"a", "b", "c"},
+		EmojisEnd:   []string{"d", "e", "f"},
+	}
+
+	groups := window.GetMissedBlocksGroups()
+	require.NoError(t, groups.Validate(100), "Error should not be present!")
+	require.Len(t, groups, 3)
+
+	assert.Equal(t, int64(0), groups[0].Start)
+	assert.Equal(t, int64(9), groups[0].End)
+	assert.Equal(t, int64(10), groups[1].Start)
+	assert.Equal(t, int64(49), groups[1].End)
+	assert.Equal(t, int64(50), groups[2].Start)
+	assert.Equal(t, int64(100), groups[2].End)
+
+	assert.Equal(t, "b", groups[1].EmojiStart)
+	assert.Equal(t, "e", groups[1].EmojiEnd)
+	assert.Equal(t, "is skipping blocks (> 10.0% of last 100 blocks)", groups[1].DescStart)
+	assert.Equal(t, "is recovering (< 50.0% of last 100 blocks)", groups[1].DescEnd)
+	assert.Equal(t, "is recovered (< 10.0% of last 100 blocks)", groups[0].DescEnd)
+}
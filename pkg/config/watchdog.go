@@ -0,0 +1,16 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type WatchdogConfig struct {
+	Enabled                   null.Bool     `default:"true" toml:"enabled"`
+	NoBlocksTimeout           time.Duration `default:"600"  toml:"no-blocks-timeout"`
+	RPCFailuresThreshold      int64         `default:"10"   toml:"rpc-failures-threshold"`
+	SnapshotFailuresThreshold int64         `default:"10"   toml:"snapshot-failures-threshold"`
+	ReporterFailuresThreshold int64         `default:"3"    toml:"reporter-failures-threshold"`
+	FallbackWebhookURL        string        `toml:"fallback-webhook-url"`
+}
@@ -1,10 +1,13 @@
 package constants
 
+import "time"
+
 type EventName string
 type ReporterName string
 type QueryType string
 type FormatType string
 type PopulatorType string
+type OutboxStatus string
 
 const (
 	NewBlocksQuery = "tm.event='NewBlock'"
@@ -12,20 +15,32 @@ const (
 	ValidatorSigned       = 2
 	ValidatorNilSignature = 3
 
+	DuplicateVoteEvidenceType = "tendermint/DuplicateVoteEvidence"
+	// The evidence hash of blocks without evidence, which is the hash of an empty list.
+	EmptyEvidenceHash = "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"
+
 	PrometheusMetricsPrefix = "missed_blocks_checker_"
 
-	EventValidatorActive            EventName = "ValidatorActive"
-	EventValidatorGroupChanged      EventName = "ValidatorGroupChanged"
-	EventValidatorInactive          EventName = "ValidatorInactive"
-	EventValidatorJailed            EventName = "ValidatorJailed"
-	EventValidatorUnjailed          EventName = "ValidatorUnjailed"
-	EventValidatorTombstoned        EventName = "ValidatorTombstoned"
-	EventValidatorCreated           EventName = "ValidatorCreated"
-	EventValidatorJoinedSignatory   EventName = "ValidatorJoinedSignatory"
-	EventValidatorLeftSignatory     EventName = "ValidatorLeftSignatory"
-	EventValidatorChangedKey        EventName = "ValidatorChangedKey"
-	EventValidatorChangedMoniker    EventName = "ValidatorChangedMoniker"
-	EventValidatorChangedCommission EventName = "ValidatorChangedCommission"
+	EventValidatorActive              EventName = "ValidatorActive"
+	EventValidatorGroupChanged        EventName = "ValidatorGroupChanged"
+	EventValidatorInactive            EventName = "ValidatorInactive"
+	EventValidatorJailed              EventName = "ValidatorJailed"
+	EventValidatorUnjailed            EventName = "ValidatorUnjailed"
+	EventValidatorCanUnjail           EventName = "ValidatorCanUnjail"
+	EventValidatorTombstoned          EventName = "ValidatorTombstoned"
+	EventValidatorDoubleSigned        EventName = "ValidatorDoubleSigned"
+	EventValidatorCreated             EventName = "ValidatorCreated"
+	EventValidatorJoinedSignatory     EventName = "ValidatorJoinedSignatory"
+	EventValidatorOptedIn             EventName = "ValidatorOptedIn"
+	EventValidatorOptedOut            EventName = "ValidatorOptedOut"
+	EventValidatorLeftSignatory       EventName = "ValidatorLeftSignatory"
+	EventValidatorChangedKey          EventName = "ValidatorChangedKey"
+	EventValidatorAssignedConsumerKey EventName = "ValidatorAssignedConsumerKey"
+	EventValidatorChangedConsumerKey  EventName = "ValidatorChangedConsumerKey"
+	EventValidatorRemovedConsumerKey  EventName = "ValidatorRemovedConsumerKey"
+	EventValidatorChangedMoniker      EventName = "ValidatorChangedMoniker"
+	EventValidatorChangedCommission   EventName = "ValidatorChangedCommission"
+	EventSlashingParamsChanged        EventName = "SlashingParamsChanged"
 
 	TelegramReporterName ReporterName = "telegram"
 	DiscordReporterName  ReporterName = "discord"
@@ -36,9 +51,16 @@ const (
 	QueryTypeConsumerAddrs QueryType = "consumer_addrs"
 
 	QueryTypeSlashingParams QueryType = "slashing_params"
+	QueryTypeConsumerParams QueryType = "consumer_params"
+	QueryTypeConsumerChain  QueryType = "consumer_chain"
+	QueryTypeConsumerChains QueryType = "consumer_chains"
+	QueryTypeOptedIn        QueryType = "opted_in_validators"
 
 	QueryTypeHistoricalValidators QueryType = "historical_validators"
 	QueryTypeBlock                QueryType = "block"
+	QueryTypeStatus               QueryType = "status"
+	QueryTypeBlockchain           QueryType = "blockchain"
+	QueryTypeCommits              QueryType = "commits"
 
 	FormatTypeHTML     FormatType = "html"
 	FormatTypeMarkdown FormatType = "markdown"
@@ -47,29 +69,104 @@ const (
 	DatabaseTypeSqlite   string = "sqlite"
 	DatabaseTypePostgres string = "postgres"
 
-	FetcherTypeCosmosRPC string = "cosmos-rpc"
-	FetcherTypeCosmosLCD string = "cosmos-lcd"
+	FetcherTypeCosmosRPC  string = "cosmos-rpc"
+	FetcherTypeCosmosLCD  string = "cosmos-lcd"
+	FetcherTypeCosmosGRPC string = "cosmos-grpc"
+
+	BlockSourceWebsocket string = "websocket"
+	BlockSourceRange     string = "range"
+	BlockSourcePoll      string = "poll"
+
+	ThresholdModeWindow     string = "window"
+	ThresholdModeJailBudget string = "jail-budget"
+	ThresholdModeAbsolute   string = "absolute"
 
-	PopulatorSlashingParams = "slashing-params-populator"
-	PopulatorTrimDatabase   = "trim-database-populator"
+	PoolRPC          = "rpc"
+	PoolProviderRPC  = "provider-rpc"
+	PoolLCD          = "lcd"
+	PoolProviderLCD  = "provider-lcd"
+	PoolGRPC         = "grpc"
+	PoolProviderGRPC = "provider-grpc"
+
+	PopulatorSlashingParams      = "slashing-params-populator"
+	PopulatorTrimDatabase        = "trim-database-populator"
+	PopulatorNodesLag            = "nodes-lag-populator"
+	PopulatorSoftOptOutThreshold = "soft-opt-out-threshold-populator"
+	PopulatorConsumersDiscovery  = "consumers-discovery-populator"
+	PopulatorUnjailReminders     = "unjail-reminders-populator"
+	PopulatorReconciliation      = "reconciliation-populator"
+
+	ConsumerIDsAll      = "*"
+	ConsumerChainsLimit = 1000
+
+	// How many consumers discoveries can wait to be applied, if it's exceeded, the next ones are skipped.
+	DiscoveredConsumersQueueSize = 10
 
 	LastEventsCount = 30
+
+	MissedBlocksDefaultCount = 50
+	MissedBlocksMaxCount     = 1000
+
+	LegacyBlocksMigrationBatchSize = 1000
+
+	// How many latest blocks are kept in memory, older ones are loaded from the database when needed.
+	StateRecentBlocksCount   = 100
+	StateBlocksLoadBatchSize = 1000
+
+	SignaturesWindowKey = "signatures-window"
+	UnjailRemindersKey  = "unjail-reminders"
+	SlashingParamsKey   = "slashing-params"
+	// The label of the slashing window, to distinguish it from additional signing windows.
+	SlashingWindowName = "slashing"
+
+	// How often the config file is checked for changes.
+	ConfigWatchInterval = 10 * time.Second
+
+	// How often the watchdog checks whether the checker is degraded.
+	WatchdogCheckInterval = time.Minute
+
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+	OutboxStatusFailed  OutboxStatus = "failed"
+
+	// How often the outbox checks for reports to retry, new reports are delivered right away.
+	OutboxDeliverInterval = 5 * time.Second
+	OutboxBatchSize       = 100
+	// Delivered and failed reports are kept for that long, then removed.
+	OutboxKeepDuration = 7 * 24 * time.Hour
+	OutboxTrimInterval = time.Hour
+
+	// How often a jailed validator that can unjail but hasn't yet is reminded about it.
+	UnjailReminderRepeatInterval = 24 * time.Hour
+	// How many times a validator is reminded about unjailing at most.
+	UnjailReminderMaxCount = 7
+	// Validators that could unjail longer ago than that are reminded about only if someone
+	// is subscribed to them, so long abandoned validators are not reminded about.
+	UnjailReminderMaxAge = 7 * 24 * time.Hour
 )
 
 func GetEventNames() []EventName {
 	return []EventName{
+		EventValidatorDoubleSigned,
 		EventValidatorTombstoned,
 		EventValidatorJailed,
 		EventValidatorInactive,
 		EventValidatorUnjailed,
+		EventValidatorCanUnjail,
 		EventValidatorActive,
 		EventValidatorLeftSignatory,
 		EventValidatorJoinedSignatory,
+		EventValidatorOptedIn,
+		EventValidatorOptedOut,
 		EventValidatorChangedKey,
+		EventValidatorAssignedConsumerKey,
+		EventValidatorChangedConsumerKey,
+		EventValidatorRemovedConsumerKey,
 		EventValidatorChangedMoniker,
 		EventValidatorChangedCommission,
 		EventValidatorCreated,
 		EventValidatorGroupChanged,
+		EventSlashingParamsChanged,
 	}
 }
 
@@ -80,9 +177,26 @@ func GetDatabaseTypes() []string {
 	}
 }
 
+func GetBlockSources() []string {
+	return []string{
+		BlockSourceWebsocket,
+		BlockSourceRange,
+		BlockSourcePoll,
+	}
+}
+
+func GetThresholdModes() []string {
+	return []string{
+		ThresholdModeWindow,
+		ThresholdModeJailBudget,
+		ThresholdModeAbsolute,
+	}
+}
+
 func GetFetcherTypes() []string {
 	return []string{
 		FetcherTypeCosmosRPC,
 		FetcherTypeCosmosLCD,
+		FetcherTypeCosmosGRPC,
 	}
 }
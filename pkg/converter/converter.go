@@ -65,6 +65,9 @@ func (c *Converter) ValidatorFromCosmosValidator(
 		valSigningInfo = &types.SigningInfo{
 			MissedBlocksCounter: signingInfo.MissedBlocksCounter,
 			Tombstoned:          signingInfo.Tombstoned,
+			JailedUntil:         signingInfo.JailedUntil,
+			StartHeight:         signingInfo.StartHeight,
+			IndexOffset:         signingInfo.IndexOffset,
 		}
 	}
 
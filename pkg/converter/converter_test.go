@@ -46,9 +46,14 @@ func TestConverterFromCosmosValidator(t *testing.T) {
 		UnbondingOnHoldRefCount: 0,
 	}
 
+	jailedUntil := time.Unix(1700000000, 0)
+
 	val := converter.ValidatorFromCosmosValidator(src, &slashingTypes.ValidatorSigningInfo{
 		Tombstoned:          false,
 		MissedBlocksCounter: 10,
+		JailedUntil:         jailedUntil,
+		StartHeight:         123,
+		IndexOffset:         456,
 	})
 
 	assert.Equal(t, &types.Validator{
@@ -65,6 +70,9 @@ func TestConverterFromCosmosValidator(t *testing.T) {
 		SigningInfo: &types.SigningInfo{
 			MissedBlocksCounter: 10,
 			Tombstoned:          false,
+			JailedUntil:         jailedUntil,
+			StartHeight:         123,
+			IndexOffset:         456,
 		},
 		VotingPower:                  math.LegacyNewDec(1386400.000000000000000000),
 		VotingPowerPercent:           0,
@@ -4,10 +4,12 @@ import (
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/data/fetchers"
-	"main/pkg/metrics"
+	"main/pkg/grpc"
+	"main/pkg/http"
 
 	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	consumerTypes "github.com/cosmos/interchain-security/v6/x/ccv/consumer/types"
 	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
 	"github.com/rs/zerolog"
 )
@@ -19,16 +21,27 @@ type Fetcher interface {
 		height int64,
 	) (*providerTypes.QueryAllPairsValConsAddrByConsumerResponse, error)
 	GetSlashingParams(height int64) (*slashingTypes.QueryParamsResponse, error)
+	GetConsumerParams(height int64) (*consumerTypes.QueryParamsResponse, error)
+	GetConsumerChain(height int64) (*providerTypes.QueryConsumerChainResponse, error)
+	GetConsumerChains(height int64) (*providerTypes.QueryConsumerChainsResponse, error)
+	GetConsumerOptedInValidators(
+		height int64,
+	) (*providerTypes.QueryConsumerChainOptedInValidatorsResponse, error)
 }
 
 func GetFetcher(
 	config *configPkg.ChainConfig,
 	logger zerolog.Logger,
-	metricsManager *metrics.Manager,
+	pools *http.Pools,
+	grpcPools *grpc.Pools,
 ) Fetcher {
 	if config.FetcherType == constants.FetcherTypeCosmosLCD {
-		return fetchers.NewCosmosLCDFetcher(config, logger, metricsManager)
+		return fetchers.NewCosmosLCDFetcher(config, logger, pools)
+	}
+
+	if config.FetcherType == constants.FetcherTypeCosmosGRPC {
+		return fetchers.NewCosmosGRPCFetcher(config, logger, grpcPools)
 	}
 
-	return fetchers.NewCosmosRPCFetcher(config, logger, metricsManager)
+	return fetchers.NewCosmosRPCFetcher(config, logger, pools)
 }
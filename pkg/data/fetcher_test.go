@@ -4,6 +4,8 @@ import (
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/data/fetchers"
+	"main/pkg/grpc"
+	"main/pkg/http"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
 	"testing"
@@ -18,9 +20,30 @@ func TestFetcherManager(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
 
-	fetcher1 := GetFetcher(&configPkg.ChainConfig{FetcherType: constants.FetcherTypeCosmosLCD}, *logger, metricsManager)
+	lcdConfig := &configPkg.ChainConfig{FetcherType: constants.FetcherTypeCosmosLCD}
+	fetcher1 := GetFetcher(
+		lcdConfig,
+		*logger,
+		http.NewPools(*logger, metricsManager, lcdConfig),
+		grpc.NewPools(*logger, metricsManager, lcdConfig),
+	)
 	require.IsType(t, &fetchers.CosmosLCDFetcher{}, fetcher1)
 
-	fetcher2 := GetFetcher(&configPkg.ChainConfig{}, *logger, metricsManager)
+	rpcConfig := &configPkg.ChainConfig{}
+	fetcher2 := GetFetcher(
+		rpcConfig,
+		*logger,
+		http.NewPools(*logger, metricsManager, rpcConfig),
+		grpc.NewPools(*logger, metricsManager, rpcConfig),
+	)
 	require.IsType(t, &fetchers.CosmosRPCFetcher{}, fetcher2)
+
+	grpcConfig := &configPkg.ChainConfig{FetcherType: constants.FetcherTypeCosmosGRPC}
+	fetcher3 := GetFetcher(
+		grpcConfig,
+		*logger,
+		http.NewPools(*logger, metricsManager, grpcConfig),
+		grpc.NewPools(*logger, metricsManager, grpcConfig),
+	)
+	require.IsType(t, &fetchers.CosmosGRPCFetcher{}, fetcher3)
 }
@@ -0,0 +1,281 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	grpcPkg "main/pkg/grpc"
+
+	queryTypes "github.com/cosmos/cosmos-sdk/types/query"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	consumerTypes "github.com/cosmos/interchain-security/v6/x/ccv/consumer/types"
+	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+type CosmosGRPCFetcher struct {
+	config *configPkg.ChainConfig
+	logger zerolog.Logger
+
+	pool         *grpcPkg.Pool
+	providerPool *grpcPkg.Pool
+}
+
+func NewCosmosGRPCFetcher(
+	config *configPkg.ChainConfig,
+	logger zerolog.Logger,
+	pools *grpcPkg.Pools,
+) *CosmosGRPCFetcher {
+	return &CosmosGRPCFetcher{
+		config:       config,
+		logger:       logger.With().Str("component", "cosmos_grpc_fetcher").Logger(),
+		pool:         pools.GRPC,
+		providerPool: pools.ProviderGRPC,
+	}
+}
+
+func (f *CosmosGRPCFetcher) GetConsumerOrProviderPool() *grpcPkg.Pool {
+	if f.config.IsConsumer.Bool {
+		return f.providerPool
+	}
+
+	return f.pool
+}
+
+func (f *CosmosGRPCFetcher) GetValidators(height int64) (*stakingTypes.QueryValidatorsResponse, error) {
+	var response *stakingTypes.QueryValidatorsResponse
+
+	if err := f.Get(
+		constants.QueryTypeValidators,
+		f.GetConsumerOrProviderPool(),
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := stakingTypes.NewQueryClient(conn).Validators(ctx, &stakingTypes.QueryValidatorsRequest{
+				Pagination: &queryTypes.PageRequest{
+					Limit: f.config.Pagination.ValidatorsList,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(result.Validators) == 0 {
+				return errors.New("malformed response: got 0 validators")
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetSigningInfos(height int64) (*slashingTypes.QuerySigningInfosResponse, error) {
+	var response *slashingTypes.QuerySigningInfosResponse
+
+	if err := f.Get(
+		constants.QueryTypeSigningInfos,
+		f.pool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := slashingTypes.NewQueryClient(conn).SigningInfos(ctx, &slashingTypes.QuerySigningInfosRequest{
+				Pagination: &queryTypes.PageRequest{
+					Limit: f.config.Pagination.SigningInfos,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(result.Info) == 0 {
+				return errors.New("malformed response: got 0 signing infos")
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetValidatorsAssignedConsumerKeys(
+	height int64,
+) (*providerTypes.QueryAllPairsValConsAddrByConsumerResponse, error) {
+	var response *providerTypes.QueryAllPairsValConsAddrByConsumerResponse
+
+	if err := f.Get(
+		constants.QueryTypeConsumerAddrs,
+		f.providerPool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := providerTypes.NewQueryClient(conn).QueryAllPairsValConsAddrByConsumer(
+				ctx,
+				&providerTypes.QueryAllPairsValConsAddrByConsumerRequest{ConsumerId: f.config.ConsumerID},
+			)
+			if err != nil {
+				return err
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetSlashingParams(height int64) (*slashingTypes.QueryParamsResponse, error) {
+	var response *slashingTypes.QueryParamsResponse
+
+	if err := f.Get(
+		constants.QueryTypeSlashingParams,
+		f.pool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := slashingTypes.NewQueryClient(conn).Params(ctx, &slashingTypes.QueryParamsRequest{})
+			if err != nil {
+				return err
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetConsumerParams(height int64) (*consumerTypes.QueryParamsResponse, error) {
+	var response *consumerTypes.QueryParamsResponse
+
+	if err := f.Get(
+		constants.QueryTypeConsumerParams,
+		f.pool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := consumerTypes.NewQueryClient(conn).QueryParams(ctx, &consumerTypes.QueryParamsRequest{})
+			if err != nil {
+				return err
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetConsumerChain(height int64) (*providerTypes.QueryConsumerChainResponse, error) {
+	var response *providerTypes.QueryConsumerChainResponse
+
+	if err := f.Get(
+		constants.QueryTypeConsumerChain,
+		f.providerPool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := providerTypes.NewQueryClient(conn).QueryConsumerChain(
+				ctx,
+				&providerTypes.QueryConsumerChainRequest{ConsumerId: f.config.ConsumerID},
+			)
+			if err != nil {
+				return err
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetConsumerChains(height int64) (*providerTypes.QueryConsumerChainsResponse, error) {
+	var response *providerTypes.QueryConsumerChainsResponse
+
+	if err := f.Get(
+		constants.QueryTypeConsumerChains,
+		f.pool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := providerTypes.NewQueryClient(conn).QueryConsumerChains(
+				ctx,
+				&providerTypes.QueryConsumerChainsRequest{
+					Phase:      providerTypes.CONSUMER_PHASE_LAUNCHED,
+					Pagination: &queryTypes.PageRequest{Limit: constants.ConsumerChainsLimit},
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) GetConsumerOptedInValidators(
+	height int64,
+) (*providerTypes.QueryConsumerChainOptedInValidatorsResponse, error) {
+	var response *providerTypes.QueryConsumerChainOptedInValidatorsResponse
+
+	if err := f.Get(
+		constants.QueryTypeOptedIn,
+		f.providerPool,
+		height,
+		func(ctx context.Context, conn *grpc.ClientConn) error {
+			result, err := providerTypes.NewQueryClient(conn).QueryConsumerChainOptedInValidators(
+				ctx,
+				&providerTypes.QueryConsumerChainOptedInValidatorsRequest{ConsumerId: f.config.ConsumerID},
+			)
+			if err != nil {
+				return err
+			}
+
+			response = result
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *CosmosGRPCFetcher) Get(
+	queryType constants.QueryType,
+	pool *grpcPkg.Pool,
+	height int64,
+	query func(ctx context.Context, conn *grpc.ClientConn) error,
+) error {
+	return pool.Query(string(queryType), func(node *grpcPkg.Node) error {
+		if err := node.Client.Query(queryType, height, query); err != nil {
+			return fmt.Errorf("gRPC query failed: %w", err)
+		}
+
+		return nil
+	})
+}
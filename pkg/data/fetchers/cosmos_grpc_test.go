@@ -0,0 +1,647 @@
+package fetchers
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	grpcPkg "main/pkg/grpc"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"net"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	grpcTypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	consumerTypes "github.com/cosmos/interchain-security/v6/x/ccv/consumer/types"
+	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/guregu/null.v4"
+)
+
+type stakingQueryServer struct {
+	stakingTypes.UnimplementedQueryServer
+
+	response *stakingTypes.QueryValidatorsResponse
+	err      error
+	height   string
+}
+
+func (s *stakingQueryServer) Validators(
+	ctx context.Context,
+	_ *stakingTypes.QueryValidatorsRequest,
+) (*stakingTypes.QueryValidatorsResponse, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if heights := md.Get(grpcTypes.GRPCBlockHeightHeader); len(heights) > 0 {
+			s.height = heights[0]
+		}
+	}
+
+	return s.response, s.err
+}
+
+type slashingQueryServer struct {
+	slashingTypes.UnimplementedQueryServer
+
+	signingInfosResponse   *slashingTypes.QuerySigningInfosResponse
+	slashingParamsResponse *slashingTypes.QueryParamsResponse
+	err                    error
+}
+
+func (s *slashingQueryServer) SigningInfos(
+	context.Context,
+	*slashingTypes.QuerySigningInfosRequest,
+) (*slashingTypes.QuerySigningInfosResponse, error) {
+	return s.signingInfosResponse, s.err
+}
+
+func (s *slashingQueryServer) Params(
+	context.Context,
+	*slashingTypes.QueryParamsRequest,
+) (*slashingTypes.QueryParamsResponse, error) {
+	return s.slashingParamsResponse, s.err
+}
+
+type providerQueryServer struct {
+	providerTypes.UnimplementedQueryServer
+
+	response               *providerTypes.QueryAllPairsValConsAddrByConsumerResponse
+	consumerChainResponse  *providerTypes.QueryConsumerChainResponse
+	consumerChainsResponse *providerTypes.QueryConsumerChainsResponse
+	optedInResponse        *providerTypes.QueryConsumerChainOptedInValidatorsResponse
+	err                    error
+	consumerID             string
+	phase                  providerTypes.ConsumerPhase
+}
+
+func (s *providerQueryServer) QueryAllPairsValConsAddrByConsumer(
+	_ context.Context,
+	request *providerTypes.QueryAllPairsValConsAddrByConsumerRequest,
+) (*providerTypes.QueryAllPairsValConsAddrByConsumerResponse, error) {
+	s.consumerID = request.ConsumerId
+	return s.response, s.err
+}
+
+func (s *providerQueryServer) QueryConsumerChain(
+	_ context.Context,
+	request *providerTypes.QueryConsumerChainRequest,
+) (*providerTypes.QueryConsumerChainResponse, error) {
+	s.consumerID = request.ConsumerId
+	return s.consumerChainResponse, s.err
+}
+
+func (s *providerQueryServer) QueryConsumerChains(
+	_ context.Context,
+	request *providerTypes.QueryConsumerChainsRequest,
+) (*providerTypes.QueryConsumerChainsResponse, error) {
+	s.phase = request.Phase
+	return s.consumerChainsResponse, s.err
+}
+
+func (s *providerQueryServer) QueryConsumerChainOptedInValidators(
+	_ context.Context,
+	request *providerTypes.QueryConsumerChainOptedInValidatorsRequest,
+) (*providerTypes.QueryConsumerChainOptedInValidatorsResponse, error) {
+	s.consumerID = request.ConsumerId
+	return s.optedInResponse, s.err
+}
+
+type consumerQueryServer struct {
+	consumerTypes.UnimplementedQueryServer
+
+	response *consumerTypes.QueryParamsResponse
+	err      error
+}
+
+func (s *consumerQueryServer) QueryParams(
+	context.Context,
+	*consumerTypes.QueryParamsRequest,
+) (*consumerTypes.QueryParamsResponse, error) {
+	return s.response, s.err
+}
+
+func getGRPCTestCodec() *codec.ProtoCodec {
+	interfaceRegistry := codecTypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	return codec.NewProtoCodec(interfaceRegistry)
+}
+
+func parseGRPCTestFixture(t *testing.T, path string, target proto.Message) {
+	t.Helper()
+
+	err := getGRPCTestCodec().UnmarshalJSON(assets.GetBytesOrPanic(path), target)
+	require.NoError(t, err)
+}
+
+func startGRPCTestServer(t *testing.T, register func(server *grpc.Server)) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.ForceServerCodec(getGRPCTestCodec().GRPCCodec()))
+	register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func getGRPCTestFetcher(config *configPkg.ChainConfig) *CosmosGRPCFetcher {
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	return NewCosmosGRPCFetcher(config, *logger, grpcPkg.NewPools(*logger, metricsManager, config))
+}
+
+func TestGrpcGetValidatorsFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		stakingTypes.RegisterQueryServer(server, &stakingQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetValidatorsNodeDown(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "All grpc requests failed")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetValidatorsInvalidCAFile(t *testing.T) {
+	t.Parallel()
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{"127.0.0.1:9090"},
+		GRPCTLSConfig: configPkg.GRPCTLSConfig{
+			Enabled: true,
+			CAFile:  "not-existing.pem",
+		},
+	})
+
+	response, err := fetcher.GetValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "no such file or directory")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetValidatorsEmptyValidators(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		stakingTypes.RegisterQueryServer(server, &stakingQueryServer{
+			response: &stakingTypes.QueryValidatorsResponse{},
+		})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "malformed response: got 0 validators")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetValidatorsOk(t *testing.T) {
+	t.Parallel()
+
+	var validators stakingTypes.QueryValidatorsResponse
+	parseGRPCTestFixture(t, "lcd-validators.json", &validators)
+
+	server := &stakingQueryServer{response: &validators}
+	host := startGRPCTestServer(t, func(grpcServer *grpc.Server) {
+		stakingTypes.RegisterQueryServer(grpcServer, server)
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidators(123)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.NotEmpty(t, response.Validators)
+	require.Equal(t, "123", server.height)
+}
+
+func TestGrpcGetValidatorsFailover(t *testing.T) {
+	t.Parallel()
+
+	var validators stakingTypes.QueryValidatorsResponse
+	parseGRPCTestFixture(t, "lcd-validators.json", &validators)
+
+	failingHost := startGRPCTestServer(t, func(server *grpc.Server) {
+		stakingTypes.RegisterQueryServer(server, &stakingQueryServer{err: errors.New("custom error")})
+	})
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		stakingTypes.RegisterQueryServer(server, &stakingQueryServer{response: &validators})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{failingHost, host},
+	})
+
+	for range 5 {
+		response, err := fetcher.GetValidators(0)
+
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		require.NotEmpty(t, response.Validators)
+	}
+}
+
+func TestGrpcGetValidatorsOkConsumer(t *testing.T) {
+	t.Parallel()
+
+	var validators stakingTypes.QueryValidatorsResponse
+	parseGRPCTestFixture(t, "lcd-validators.json", &validators)
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		stakingTypes.RegisterQueryServer(server, &stakingQueryServer{response: &validators})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		IsConsumer:            null.BoolFrom(true),
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidators(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.NotEmpty(t, response.Validators)
+}
+
+func TestGrpcGetSigningInfosFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		slashingTypes.RegisterQueryServer(server, &slashingQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetSigningInfos(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetSigningInfosEmpty(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		slashingTypes.RegisterQueryServer(server, &slashingQueryServer{
+			signingInfosResponse: &slashingTypes.QuerySigningInfosResponse{},
+		})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetSigningInfos(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "malformed response: got 0 signing infos")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetSigningInfosOk(t *testing.T) {
+	t.Parallel()
+
+	var signingInfos slashingTypes.QuerySigningInfosResponse
+	parseGRPCTestFixture(t, "lcd-signing-infos.json", &signingInfos)
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		slashingTypes.RegisterQueryServer(server, &slashingQueryServer{signingInfosResponse: &signingInfos})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetSigningInfos(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.NotEmpty(t, response.Info)
+}
+
+func TestGrpcGetSlashingParamsFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		slashingTypes.RegisterQueryServer(server, &slashingQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetSlashingParams(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetSlashingParamsOk(t *testing.T) {
+	t.Parallel()
+
+	var params slashingTypes.QueryParamsResponse
+	parseGRPCTestFixture(t, "lcd-slashing-params.json", &params)
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		slashingTypes.RegisterQueryServer(server, &slashingQueryServer{slashingParamsResponse: &params})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetSlashingParams(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, params.Params.SignedBlocksWindow, response.Params.SignedBlocksWindow)
+}
+
+func TestGrpcGetAssignedKeysFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		providerTypes.RegisterQueryServer(server, &providerQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		ConsumerID:            "consumer",
+		GRPCEndpoints:         []string{"127.0.0.1:1"},
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidatorsAssignedConsumerKeys(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetAssignedKeysOk(t *testing.T) {
+	t.Parallel()
+
+	var keys providerTypes.QueryAllPairsValConsAddrByConsumerResponse
+	parseGRPCTestFixture(t, "lcd-assigned-keys.json", &keys)
+
+	server := &providerQueryServer{response: &keys}
+	host := startGRPCTestServer(t, func(grpcServer *grpc.Server) {
+		providerTypes.RegisterQueryServer(grpcServer, server)
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		ConsumerID:            "consumer",
+		GRPCEndpoints:         []string{"127.0.0.1:1"},
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetValidatorsAssignedConsumerKeys(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.NotEmpty(t, response.PairValConAddr)
+	require.Equal(t, "consumer", server.consumerID)
+}
+
+func TestGrpcGetConsumerParamsFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		consumerTypes.RegisterQueryServer(server, &consumerQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerParams(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetConsumerParamsOk(t *testing.T) {
+	t.Parallel()
+
+	var params consumerTypes.QueryParamsResponse
+	parseGRPCTestFixture(t, "lcd-consumer-params.json", &params)
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		consumerTypes.RegisterQueryServer(server, &consumerQueryServer{response: &params})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerParams(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, "0.05", response.Params.SoftOptOutThreshold) //nolint:staticcheck
+}
+
+func TestGrpcGetConsumerChainFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		providerTypes.RegisterQueryServer(server, &providerQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		ConsumerID:            "consumer",
+		GRPCEndpoints:         []string{"127.0.0.1:1"},
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerChain(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetConsumerChainOk(t *testing.T) {
+	t.Parallel()
+
+	var consumerChain providerTypes.QueryConsumerChainResponse
+	parseGRPCTestFixture(t, "lcd-consumer-chain.json", &consumerChain)
+
+	server := &providerQueryServer{consumerChainResponse: &consumerChain}
+	host := startGRPCTestServer(t, func(grpcServer *grpc.Server) {
+		providerTypes.RegisterQueryServer(grpcServer, server)
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		ConsumerID:            "consumer",
+		GRPCEndpoints:         []string{"127.0.0.1:1"},
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerChain(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, "consumer", server.consumerID)
+	require.Equal(t, uint32(95), response.PowerShapingParams.Top_N)
+}
+
+func TestGrpcGetConsumerChainsFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		providerTypes.RegisterQueryServer(server, &providerQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerChains(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetConsumerChainsOk(t *testing.T) {
+	t.Parallel()
+
+	var consumerChains providerTypes.QueryConsumerChainsResponse
+	parseGRPCTestFixture(t, "lcd-consumer-chains.json", &consumerChains)
+
+	server := &providerQueryServer{consumerChainsResponse: &consumerChains}
+	host := startGRPCTestServer(t, func(grpcServer *grpc.Server) {
+		providerTypes.RegisterQueryServer(grpcServer, server)
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:          "chain",
+		GRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerChains(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, providerTypes.CONSUMER_PHASE_LAUNCHED, server.phase)
+	require.Len(t, response.Chains, 3)
+	require.Equal(t, "neutron-1", response.Chains[0].ChainId)
+}
+
+func TestGrpcGetConsumerOptedInValidatorsFail(t *testing.T) {
+	t.Parallel()
+
+	host := startGRPCTestServer(t, func(server *grpc.Server) {
+		providerTypes.RegisterQueryServer(server, &providerQueryServer{err: errors.New("custom error")})
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		ConsumerID:            "consumer",
+		GRPCEndpoints:         []string{"127.0.0.1:1"},
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerOptedInValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+func TestGrpcGetConsumerOptedInValidatorsOk(t *testing.T) {
+	t.Parallel()
+
+	var optedIn providerTypes.QueryConsumerChainOptedInValidatorsResponse
+	parseGRPCTestFixture(t, "lcd-opted-in-validators.json", &optedIn)
+
+	server := &providerQueryServer{optedInResponse: &optedIn}
+	host := startGRPCTestServer(t, func(grpcServer *grpc.Server) {
+		providerTypes.RegisterQueryServer(grpcServer, server)
+	})
+
+	fetcher := getGRPCTestFetcher(&configPkg.ChainConfig{
+		Name:                  "chain",
+		ConsumerID:            "consumer",
+		GRPCEndpoints:         []string{"127.0.0.1:1"},
+		ProviderGRPCEndpoints: []string{host},
+	})
+
+	response, err := fetcher.GetConsumerOptedInValidators(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, "consumer", server.consumerID)
+	require.Len(t, response.ValidatorsProviderAddresses, 2)
+}
@@ -7,11 +7,8 @@ import (
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/http"
-	"main/pkg/metrics"
 	"main/pkg/types/responses"
-	"main/pkg/utils"
 	"strconv"
-	"strings"
 
 	"github.com/cosmos/cosmos-sdk/std"
 	"github.com/gogo/protobuf/proto"
@@ -21,17 +18,17 @@ import (
 
 	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	consumerTypes "github.com/cosmos/interchain-security/v6/x/ccv/consumer/types"
 	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
 	"github.com/rs/zerolog"
 )
 
 type CosmosLCDFetcher struct {
-	config         *configPkg.ChainConfig
-	metricsManager *metrics.Manager
-	logger         zerolog.Logger
+	config *configPkg.ChainConfig
+	logger zerolog.Logger
 
-	clients         []*http.Client
-	providerClients []*http.Client
+	pool         *http.Pool
+	providerPool *http.Pool
 
 	parseCodec *codec.ProtoCodec
 }
@@ -39,38 +36,27 @@ type CosmosLCDFetcher struct {
 func NewCosmosLCDFetcher(
 	config *configPkg.ChainConfig,
 	logger zerolog.Logger,
-	metricsManager *metrics.Manager,
+	pools *http.Pools,
 ) *CosmosLCDFetcher {
-	clients := make([]*http.Client, len(config.LCDEndpoints))
-	for index, host := range config.LCDEndpoints {
-		clients[index] = http.NewClient(logger, metricsManager, host, config.Name)
-	}
-
-	providerClients := make([]*http.Client, len(config.ProviderLCDEndpoints))
-	for index, host := range config.ProviderLCDEndpoints {
-		providerClients[index] = http.NewClient(logger, metricsManager, host, config.Name)
-	}
-
 	interfaceRegistry := codecTypes.NewInterfaceRegistry()
 	std.RegisterInterfaces(interfaceRegistry)
 	parseCodec := codec.NewProtoCodec(interfaceRegistry)
 
 	return &CosmosLCDFetcher{
-		config:          config,
-		metricsManager:  metricsManager,
-		logger:          logger.With().Str("component", "cosmos_lcd_fetcher").Logger(),
-		clients:         clients,
-		providerClients: providerClients,
-		parseCodec:      parseCodec,
+		config:       config,
+		logger:       logger.With().Str("component", "cosmos_lcd_fetcher").Logger(),
+		pool:         pools.LCD,
+		providerPool: pools.ProviderLCD,
+		parseCodec:   parseCodec,
 	}
 }
 
-func (f *CosmosLCDFetcher) GetConsumerOrProviderClients() []*http.Client {
+func (f *CosmosLCDFetcher) GetConsumerOrProviderPool() *http.Pool {
 	if f.config.IsConsumer.Bool {
-		return f.providerClients
+		return f.providerPool
 	}
 
-	return f.clients
+	return f.pool
 }
 
 func (f *CosmosLCDFetcher) GetValidators(height int64) (*stakingTypes.QueryValidatorsResponse, error) {
@@ -80,7 +66,7 @@ func (f *CosmosLCDFetcher) GetValidators(height int64) (*stakingTypes.QueryValid
 		"/cosmos/staking/v1beta1/validators?pagination.limit=1000",
 		constants.QueryTypeValidators,
 		&validatorsResponse,
-		f.GetConsumerOrProviderClients(),
+		f.GetConsumerOrProviderPool(),
 		height,
 		func(v proto.Message) error {
 			response, _ := v.(*stakingTypes.QueryValidatorsResponse)
@@ -104,7 +90,7 @@ func (f *CosmosLCDFetcher) GetSigningInfos(height int64) (*slashingTypes.QuerySi
 		"/cosmos/slashing/v1beta1/signing_infos?pagination.limit=1000",
 		constants.QueryTypeSigningInfos,
 		&response,
-		f.clients,
+		f.pool,
 		height,
 		func(v proto.Message) error {
 			responseInternal, _ := v.(*slashingTypes.QuerySigningInfosResponse)
@@ -130,7 +116,7 @@ func (f *CosmosLCDFetcher) GetValidatorsAssignedConsumerKeys(
 		"/interchain_security/ccv/provider/address_pairs/"+f.config.ConsumerID,
 		constants.QueryTypeConsumerAddrs,
 		&response,
-		f.providerClients,
+		f.providerPool,
 		height,
 		func(v proto.Message) error {
 			return nil
@@ -149,7 +135,7 @@ func (f *CosmosLCDFetcher) GetSlashingParams(height int64) (*slashingTypes.Query
 		"/cosmos/slashing/v1beta1/params",
 		constants.QueryTypeSlashingParams,
 		&slashingParamsResponse,
-		f.clients,
+		f.pool,
 		height,
 		func(v proto.Message) error {
 			return nil
@@ -161,43 +147,108 @@ func (f *CosmosLCDFetcher) GetSlashingParams(height int64) (*slashingTypes.Query
 	return &slashingParamsResponse, nil
 }
 
+func (f *CosmosLCDFetcher) GetConsumerParams(height int64) (*consumerTypes.QueryParamsResponse, error) {
+	var response consumerTypes.QueryParamsResponse
+
+	if err := f.Get(
+		"/interchain_security/ccv/consumer/params",
+		constants.QueryTypeConsumerParams,
+		&response,
+		f.pool,
+		height,
+		func(v proto.Message) error {
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (f *CosmosLCDFetcher) GetConsumerChain(height int64) (*providerTypes.QueryConsumerChainResponse, error) {
+	var response providerTypes.QueryConsumerChainResponse
+
+	if err := f.Get(
+		"/interchain_security/ccv/provider/consumer_chain/"+f.config.ConsumerID,
+		constants.QueryTypeConsumerChain,
+		&response,
+		f.providerPool,
+		height,
+		func(v proto.Message) error {
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (f *CosmosLCDFetcher) GetConsumerChains(height int64) (*providerTypes.QueryConsumerChainsResponse, error) {
+	var response providerTypes.QueryConsumerChainsResponse
+
+	if err := f.Get(
+		fmt.Sprintf(
+			"/interchain_security/ccv/provider/consumer_chains/%d?pagination.limit=%d",
+			providerTypes.CONSUMER_PHASE_LAUNCHED,
+			constants.ConsumerChainsLimit,
+		),
+		constants.QueryTypeConsumerChains,
+		&response,
+		f.pool,
+		height,
+		func(v proto.Message) error {
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (f *CosmosLCDFetcher) GetConsumerOptedInValidators(
+	height int64,
+) (*providerTypes.QueryConsumerChainOptedInValidatorsResponse, error) {
+	var response providerTypes.QueryConsumerChainOptedInValidatorsResponse
+
+	if err := f.Get(
+		"/interchain_security/ccv/provider/opted_in_validators/"+f.config.ConsumerID,
+		constants.QueryTypeOptedIn,
+		&response,
+		f.providerPool,
+		height,
+		func(v proto.Message) error {
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 func (f *CosmosLCDFetcher) Get(
 	url string,
 	queryType constants.QueryType,
 	target proto.Message,
-	clients []*http.Client,
+	pool *http.Pool,
 	height int64,
 	predicate func(proto.Message) error,
 ) error {
-	errorsArray := make([]error, len(clients))
-
-	indexesShuffled := utils.MakeShuffledArray(len(clients))
-	clientsShuffled := make([]*http.Client, len(clients))
-
-	for index, indexShuffled := range indexesShuffled {
-		clientsShuffled[index] = clients[indexShuffled]
-	}
-
 	headers := map[string]string{
 		"x-cosmos-block-height": strconv.FormatInt(height, 10),
 	}
 
-	for index := range indexesShuffled {
-		client := clientsShuffled[index]
-
-		fullURL := client.Host + url
-		f.logger.Trace().Str("url", fullURL).Msg("Trying making request to LCD")
-
-		bytes, err := client.GetPlain(
+	return pool.Query(url, func(node *http.Node) error {
+		bytes, err := node.Client.GetPlain(
 			url,
 			queryType,
 			headers,
 		)
-
 		if err != nil {
-			f.logger.Warn().Str("url", fullURL).Err(err).Msg("LCD request failed")
-			errorsArray[index] = err
-			continue
+			return err
 		}
 
 		// check whether the response is error first
@@ -206,39 +257,18 @@ func (f *CosmosLCDFetcher) Get(
 			// if we successfully unmarshalled it into LCDError, so err == nil,
 			// that means the response is indeed an error.
 			if errorResponse.Code != 0 {
-				f.logger.Warn().Str("url", fullURL).
-					Err(err).
-					Int("code", errorResponse.Code).
-					Str("message", errorResponse.Message).
-					Msg("LCD request returned an error")
-				errorsArray[index] = errors.New(errorResponse.Message)
-				continue
+				return errors.New(errorResponse.Message)
 			}
 		}
 
 		if err := f.parseCodec.UnmarshalJSON(bytes, target); err != nil {
-			f.logger.Warn().Str("url", fullURL).Err(err).Msg("JSON unmarshalling failed")
-			errorsArray[index] = err
-			continue
+			return err
 		}
 
 		if predicateErr := predicate(target); predicateErr != nil {
-			f.logger.Warn().Str("url", fullURL).Err(predicateErr).Msg("LCD precondition failed")
-			errorsArray[index] = fmt.Errorf("precondition failed: %s", predicateErr)
-			continue
+			return fmt.Errorf("precondition failed: %s", predicateErr)
 		}
 
 		return nil
-	}
-
-	f.logger.Warn().Str("url", url).Msg("All LCD requests failed")
-
-	var sb strings.Builder
-
-	sb.WriteString("All LCD requests failed:\n")
-	for index, client := range clientsShuffled {
-		sb.WriteString(fmt.Sprintf("#%d: %s -> %s\n", index+1, client.Host, errorsArray[index]))
-	}
-
-	return errors.New(sb.String())
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"main/assets"
 	configPkg "main/pkg/config"
+	"main/pkg/http"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
 	"testing"
@@ -26,7 +27,7 @@ func TestLcdFailToUnmarshal(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -53,7 +54,7 @@ func TestLcdError(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -80,7 +81,7 @@ func TestLcdGetValidatorsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -107,7 +108,7 @@ func TestLcdGetValidatorsEmptyValidators(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -134,7 +135,7 @@ func TestLcdGetValidatorsOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -162,7 +163,7 @@ func TestLcdGetValidatorsOkConsumer(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -189,7 +190,7 @@ func TestLcdGetSigningInfosFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -216,7 +217,7 @@ func TestLcdGetSigningInfosEmpty(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -243,7 +244,7 @@ func TestLcdGetSigningInfosOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -270,7 +271,7 @@ func TestLcdGetSlashingParamsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -297,7 +298,7 @@ func TestLcdGetSlashingParamsOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -325,7 +326,7 @@ func TestLcdGetAssignedKeysFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -354,7 +355,7 @@ func TestLcdGetAssignedKeysOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosLCDFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -367,3 +368,230 @@ func TestLcdGetAssignedKeysOk(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, response)
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerParamsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/interchain_security/ccv/consumer/params",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := lcdFetcher.GetConsumerParams(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerParamsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/interchain_security/ccv/consumer/params",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-consumer-params.json")),
+	)
+
+	response, err := lcdFetcher.GetConsumerParams(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, "0.05", response.Params.SoftOptOutThreshold) //nolint:staticcheck
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerChainFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		LCDEndpoints:         []string{"https://consumer-example.com"},
+		ProviderLCDEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/interchain_security/ccv/provider/consumer_chain/consumer",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := lcdFetcher.GetConsumerChain(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerChainOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		LCDEndpoints:         []string{"https://consumer-example.com"},
+		ProviderLCDEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/interchain_security/ccv/provider/consumer_chain/consumer",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-consumer-chain.json")),
+	)
+
+	response, err := lcdFetcher.GetConsumerChain(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.NotNil(t, response.PowerShapingParams)
+	require.Equal(t, uint32(95), response.PowerShapingParams.Top_N)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerChainsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/interchain_security/ccv/provider/consumer_chains/3?pagination.limit=1000",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := lcdFetcher.GetConsumerChains(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerChainsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/interchain_security/ccv/provider/consumer_chains/3?pagination.limit=1000",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-consumer-chains.json")),
+	)
+
+	response, err := lcdFetcher.GetConsumerChains(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Len(t, response.Chains, 3)
+	require.Equal(t, "neutron-1", response.Chains[0].ChainId)
+	require.Equal(t, "0", response.Chains[0].ConsumerId)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerOptedInValidatorsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		LCDEndpoints:         []string{"https://consumer-example.com"},
+		ProviderLCDEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/interchain_security/ccv/provider/opted_in_validators/consumer",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := lcdFetcher.GetConsumerOptedInValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestLcdGetConsumerOptedInValidatorsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		LCDEndpoints:         []string{"https://consumer-example.com"},
+		ProviderLCDEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	lcdFetcher := NewCosmosLCDFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/interchain_security/ccv/provider/opted_in_validators/consumer",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-opted-in-validators.json")),
+	)
+
+	response, err := lcdFetcher.GetConsumerOptedInValidators(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Len(t, response.ValidatorsProviderAddresses, 2)
+}
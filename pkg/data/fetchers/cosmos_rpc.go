@@ -1,16 +1,12 @@
 package fetchers
 
 import (
-	"errors"
 	"fmt"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/http"
-	"main/pkg/metrics"
 	"main/pkg/types/responses"
-	"main/pkg/utils"
 	"net/url"
-	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 
@@ -18,49 +14,38 @@ import (
 
 	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	consumerTypes "github.com/cosmos/interchain-security/v6/x/ccv/consumer/types"
 	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
 	"github.com/rs/zerolog"
 )
 
 type CosmosRPCFetcher struct {
-	config         *configPkg.ChainConfig
-	metricsManager *metrics.Manager
-	logger         zerolog.Logger
+	config *configPkg.ChainConfig
+	logger zerolog.Logger
 
-	clients         []*http.Client
-	providerClients []*http.Client
+	pool         *http.Pool
+	providerPool *http.Pool
 }
 
 func NewCosmosRPCFetcher(
 	config *configPkg.ChainConfig,
 	logger zerolog.Logger,
-	metricsManager *metrics.Manager,
+	pools *http.Pools,
 ) *CosmosRPCFetcher {
-	clients := make([]*http.Client, len(config.RPCEndpoints))
-	for index, host := range config.RPCEndpoints {
-		clients[index] = http.NewClient(logger, metricsManager, host, config.Name)
-	}
-
-	providerClients := make([]*http.Client, len(config.ProviderRPCEndpoints))
-	for index, host := range config.ProviderRPCEndpoints {
-		providerClients[index] = http.NewClient(logger, metricsManager, host, config.Name)
-	}
-
 	return &CosmosRPCFetcher{
-		config:          config,
-		metricsManager:  metricsManager,
-		logger:          logger.With().Str("component", "cosmos_rpc_fetcher").Logger(),
-		clients:         clients,
-		providerClients: providerClients,
+		config:       config,
+		logger:       logger.With().Str("component", "cosmos_rpc_fetcher").Logger(),
+		pool:         pools.RPC,
+		providerPool: pools.ProviderRPC,
 	}
 }
 
-func (f *CosmosRPCFetcher) GetConsumerOrProviderClients() []*http.Client {
+func (f *CosmosRPCFetcher) GetConsumerOrProviderPool() *http.Pool {
 	if f.config.IsConsumer.Bool {
-		return f.providerClients
+		return f.providerPool
 	}
 
-	return f.clients
+	return f.pool
 }
 
 func (f *CosmosRPCFetcher) AbciQuery(
@@ -69,7 +54,7 @@ func (f *CosmosRPCFetcher) AbciQuery(
 	height int64,
 	queryType constants.QueryType,
 	output codec.ProtoMarshaler, //nolint:staticcheck
-	clients []*http.Client,
+	pool *http.Pool,
 ) error {
 	dataBytes, _ := message.Marshal()
 	methodName := fmt.Sprintf("\"%s\"", method)
@@ -84,7 +69,7 @@ func (f *CosmosRPCFetcher) AbciQuery(
 	}
 
 	var response responses.AbciQueryResponse
-	if err := f.Get(queryURL, constants.QueryType("abci_"+string(queryType)), &response, clients, func(v *responses.AbciQueryResponse) error {
+	if err := f.Get(queryURL, constants.QueryType("abci_"+string(queryType)), &response, pool, func(v *responses.AbciQueryResponse) error {
 		if v.Result.Response.Code != 0 {
 			return fmt.Errorf(
 				"error in Tendermint response: expected code 0, but got %d, error: %s",
@@ -115,7 +100,7 @@ func (f *CosmosRPCFetcher) GetValidators(height int64) (*stakingTypes.QueryValid
 		height,
 		constants.QueryTypeValidators,
 		&validatorsResponse,
-		f.GetConsumerOrProviderClients(),
+		f.GetConsumerOrProviderPool(),
 	); err != nil {
 		return nil, err
 	}
@@ -137,7 +122,7 @@ func (f *CosmosRPCFetcher) GetSigningInfos(height int64) (*slashingTypes.QuerySi
 		height,
 		constants.QueryTypeSigningInfos,
 		&response,
-		f.clients,
+		f.pool,
 	); err != nil {
 		return nil, err
 	}
@@ -159,7 +144,7 @@ func (f *CosmosRPCFetcher) GetValidatorsAssignedConsumerKeys(
 		height,
 		constants.QueryTypeConsumerAddrs,
 		&response,
-		f.providerClients,
+		f.providerPool,
 	); err != nil {
 		return nil, err
 	}
@@ -175,7 +160,7 @@ func (f *CosmosRPCFetcher) GetSlashingParams(height int64) (*slashingTypes.Query
 		height,
 		constants.QueryTypeSlashingParams,
 		&response,
-		f.clients,
+		f.pool,
 	); err != nil {
 		return nil, err
 	}
@@ -183,57 +168,103 @@ func (f *CosmosRPCFetcher) GetSlashingParams(height int64) (*slashingTypes.Query
 	return &response, nil
 }
 
-func (f *CosmosRPCFetcher) Get(
-	url string,
-	queryType constants.QueryType,
-	target *responses.AbciQueryResponse,
-	clients []*http.Client,
-	predicate func(response *responses.AbciQueryResponse) error,
-) error {
-	errorsArray := make([]error, len(clients))
+func (f *CosmosRPCFetcher) GetConsumerParams(height int64) (*consumerTypes.QueryParamsResponse, error) {
+	var response consumerTypes.QueryParamsResponse
+	if err := f.AbciQuery(
+		"/interchain_security.ccv.consumer.v1.Query/QueryParams",
+		&consumerTypes.QueryParamsRequest{},
+		height,
+		constants.QueryTypeConsumerParams,
+		&response,
+		f.pool,
+	); err != nil {
+		return nil, err
+	}
 
-	indexesShuffled := utils.MakeShuffledArray(len(clients))
-	clientsShuffled := make([]*http.Client, len(clients))
+	return &response, nil
+}
 
-	for index, indexShuffled := range indexesShuffled {
-		clientsShuffled[index] = clients[indexShuffled]
+func (f *CosmosRPCFetcher) GetConsumerChain(height int64) (*providerTypes.QueryConsumerChainResponse, error) {
+	query := providerTypes.QueryConsumerChainRequest{
+		ConsumerId: f.config.ConsumerID,
 	}
 
-	for index := range indexesShuffled {
-		client := clientsShuffled[index]
+	var response providerTypes.QueryConsumerChainResponse
+	if err := f.AbciQuery(
+		"/interchain_security.ccv.provider.v1.Query/QueryConsumerChain",
+		&query,
+		height,
+		constants.QueryTypeConsumerChain,
+		&response,
+		f.providerPool,
+	); err != nil {
+		return nil, err
+	}
 
-		fullURL := client.Host + url
-		f.logger.Trace().Str("url", fullURL).Msg("Trying making request to LCD")
+	return &response, nil
+}
 
-		err := client.Get(
-			url,
-			queryType,
-			target,
-		)
+func (f *CosmosRPCFetcher) GetConsumerChains(height int64) (*providerTypes.QueryConsumerChainsResponse, error) {
+	query := providerTypes.QueryConsumerChainsRequest{
+		Phase: providerTypes.CONSUMER_PHASE_LAUNCHED,
+		Pagination: &queryTypes.PageRequest{
+			Limit: constants.ConsumerChainsLimit,
+		},
+	}
 
-		if err != nil {
-			f.logger.Warn().Str("url", fullURL).Err(err).Msg("LCD request failed")
-			errorsArray[index] = err
-			continue
-		}
+	var response providerTypes.QueryConsumerChainsResponse
+	if err := f.AbciQuery(
+		"/interchain_security.ccv.provider.v1.Query/QueryConsumerChains",
+		&query,
+		height,
+		constants.QueryTypeConsumerChains,
+		&response,
+		f.pool,
+	); err != nil {
+		return nil, err
+	}
 
-		if predicateErr := predicate(target); predicateErr != nil {
-			f.logger.Warn().Str("url", fullURL).Err(predicateErr).Msg("LCD precondition failed")
-			errorsArray[index] = fmt.Errorf("precondition failed: %s", predicateErr)
-			continue
-		}
+	return &response, nil
+}
 
-		return nil
+func (f *CosmosRPCFetcher) GetConsumerOptedInValidators(
+	height int64,
+) (*providerTypes.QueryConsumerChainOptedInValidatorsResponse, error) {
+	query := providerTypes.QueryConsumerChainOptedInValidatorsRequest{
+		ConsumerId: f.config.ConsumerID,
 	}
 
-	f.logger.Warn().Str("url", url).Msg("All LCD requests failed")
+	var response providerTypes.QueryConsumerChainOptedInValidatorsResponse
+	if err := f.AbciQuery(
+		"/interchain_security.ccv.provider.v1.Query/QueryConsumerChainOptedInValidators",
+		&query,
+		height,
+		constants.QueryTypeOptedIn,
+		&response,
+		f.providerPool,
+	); err != nil {
+		return nil, err
+	}
 
-	var sb strings.Builder
+	return &response, nil
+}
 
-	sb.WriteString("All LCD requests failed:\n")
-	for index, client := range clientsShuffled {
-		sb.WriteString(fmt.Sprintf("#%d: %s -> %s\n", index+1, client.Host, errorsArray[index]))
-	}
+func (f *CosmosRPCFetcher) Get(
+	url string,
+	queryType constants.QueryType,
+	target *responses.AbciQueryResponse,
+	pool *http.Pool,
+	predicate func(response *responses.AbciQueryResponse) error,
+) error {
+	return pool.Query(url, func(node *http.Node) error {
+		if err := node.Client.Get(url, queryType, target); err != nil {
+			return err
+		}
 
-	return errors.New(sb.String())
+		if predicateErr := predicate(target); predicateErr != nil {
+			return fmt.Errorf("precondition failed: %s", predicateErr)
+		}
+
+		return nil
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"main/assets"
 	configPkg "main/pkg/config"
+	"main/pkg/http"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
 	"testing"
@@ -25,7 +26,7 @@ func TestNewCosmosRPCFetcherRpcError(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -52,7 +53,7 @@ func TestNewCosmosRPCFetcherGetValidatorsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -79,7 +80,7 @@ func TestNewCosmosRPCFetcherGetValidatorsOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -106,7 +107,7 @@ func TestNewCosmosRPCFetcherGetValidatorsOkConsumer(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -132,7 +133,7 @@ func TestNewCosmosRPCFetcherGetSigningInfosFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -159,7 +160,7 @@ func TestNewCosmosRPCFetcherGetSigningInfosOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -185,7 +186,7 @@ func TestNewCosmosRPCFetcherGetSlashingParamsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -212,7 +213,7 @@ func TestNewCosmosRPCFetcherGetSlashingParamsOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -240,7 +241,7 @@ func TestNewCosmosRPCFetcherGetAssignedKeysFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -269,7 +270,7 @@ func TestNewCosmosRPCFetcherGetAssignedKeysOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewCosmosRPCFetcher(config, *logger, metricsManager)
+	lcdFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -282,3 +283,230 @@ func TestNewCosmosRPCFetcherGetAssignedKeysOk(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, response)
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerParamsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/abci_query?path=%22%2Finterchain_security.ccv.consumer.v1.Query%2FQueryParams%22&data=0x",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := rpcFetcher.GetConsumerParams(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerParamsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/abci_query?path=%22%2Finterchain_security.ccv.consumer.v1.Query%2FQueryParams%22&data=0x",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-consumer-params.json")),
+	)
+
+	response, err := rpcFetcher.GetConsumerParams(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Equal(t, "0.05", response.Params.SoftOptOutThreshold) //nolint:staticcheck
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerChainFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChain%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := rpcFetcher.GetConsumerChain(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerChainOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChain%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-consumer-chain.json")),
+	)
+
+	response, err := rpcFetcher.GetConsumerChain(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.NotNil(t, response.PowerShapingParams)
+	require.Equal(t, uint32(95), response.PowerShapingParams.Top_N)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerChainsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChains%22&data=0x0803120318e807",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := rpcFetcher.GetConsumerChains(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerChainsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChains%22&data=0x0803120318e807",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-consumer-chains.json")),
+	)
+
+	response, err := rpcFetcher.GetConsumerChains(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Len(t, response.Chains, 3)
+	require.Equal(t, "neutron-1", response.Chains[0].ChainId)
+	require.Equal(t, "0", response.Chains[0].ConsumerId)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerOptedInValidatorsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChainOptedInValidators%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	response, err := rpcFetcher.GetConsumerOptedInValidators(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, response)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestNewCosmosRPCFetcherGetConsumerOptedInValidatorsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpcFetcher := NewCosmosRPCFetcher(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChainOptedInValidators%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-opted-in-validators.json")),
+	)
+
+	response, err := rpcFetcher.GetConsumerOptedInValidators(0)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	require.Len(t, response.ValidatorsProviderAddresses, 2)
+}
@@ -1,13 +1,15 @@
 package data
 
 import (
+	"errors"
 	configPkg "main/pkg/config"
 	converterPkg "main/pkg/converter"
-	"main/pkg/metrics"
+	"main/pkg/grpc"
+	"main/pkg/http"
 	"main/pkg/tendermint"
 	"main/pkg/types"
-	"main/pkg/types/responses"
 	"main/pkg/utils"
+	"strconv"
 	"sync"
 
 	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
@@ -15,6 +17,7 @@ import (
 	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/rs/zerolog"
+	"gopkg.in/guregu/null.v4"
 )
 
 type Manager struct {
@@ -22,26 +25,38 @@ type Manager struct {
 	config    *configPkg.ChainConfig
 	fetcher   Fetcher
 	rpc       *tendermint.RPC
+	pools     *http.Pools
+	grpcPools *grpc.Pools
 	converter *converterPkg.Converter
 }
 
 func NewManager(
 	logger zerolog.Logger,
 	config *configPkg.ChainConfig,
-	metricsManager *metrics.Manager,
+	pools *http.Pools,
+	grpcPools *grpc.Pools,
 ) *Manager {
-	rpc := tendermint.NewRPC(config, logger, metricsManager)
-	fetcher := GetFetcher(config, logger, metricsManager)
+	rpc := tendermint.NewRPC(config, logger, pools)
+	fetcher := GetFetcher(config, logger, pools, grpcPools)
 
 	return &Manager{
 		logger:    logger.With().Str("component", "data_manager").Logger(),
 		config:    config,
 		fetcher:   fetcher,
 		rpc:       rpc,
+		pools:     pools,
+		grpcPools: grpcPools,
 		converter: converterPkg.NewConverter(),
 	}
 }
 
+// Stop closes the connections the manager keeps open.
+func (manager *Manager) Stop() {
+	if err := manager.grpcPools.Stop(); err != nil {
+		manager.logger.Warn().Err(err).Msg("Error closing gRPC connections")
+	}
+}
+
 func (manager *Manager) GetValidators(height int64) (types.Validators, error) {
 	if manager.config.IsConsumer.Bool {
 		return manager.GetValidatorsAndSigningInfoForConsumerChain(height)
@@ -108,10 +123,12 @@ func (manager *Manager) GetValidatorsAndSigningInfoForConsumerChain(height int64
 		signingInfoErr       error
 		assignedKeysResponse *providerTypes.QueryAllPairsValConsAddrByConsumerResponse
 		assignedKeysError    error
+		optedInResponse      *providerTypes.QueryConsumerChainOptedInValidatorsResponse
+		optedInError         error
 		mutex                sync.Mutex
 	)
 
-	wg.Add(3)
+	wg.Add(4)
 	go func() {
 		validatorsResponse, validatorsError = manager.fetcher.GetValidators(0)
 		wg.Done()
@@ -127,6 +144,11 @@ func (manager *Manager) GetValidatorsAndSigningInfoForConsumerChain(height int64
 		wg.Done()
 	}()
 
+	go func() {
+		optedInResponse, optedInError = manager.fetcher.GetConsumerOptedInValidators(0)
+		wg.Done()
+	}()
+
 	wg.Wait()
 
 	if validatorsError != nil {
@@ -146,6 +168,18 @@ func (manager *Manager) GetValidatorsAndSigningInfoForConsumerChain(height int64
 		signingInfosByAddr[utils.MustDecodeBech32(info.Address)] = &info //nolint:exportloopref
 	}
 
+	// opted-in validators are not required to track consumer chains, so if they cannot be fetched
+	// (for example, on older ICS versions), the opt-in status is left unknown
+	var optedInAddrs map[string]bool
+	if optedInError != nil {
+		manager.logger.Warn().Err(optedInError).Msg("Could not get opted-in validators")
+	} else {
+		optedInAddrs = make(map[string]bool, len(optedInResponse.ValidatorsProviderAddresses))
+		for _, address := range optedInResponse.ValidatorsProviderAddresses {
+			optedInAddrs[utils.MustDecodeBech32(address)] = true
+		}
+	}
+
 	assignedKeysByAddr := make(map[string]*providerTypes.PairValConAddrProviderAndConsumer)
 	for _, assignedKey := range assignedKeysResponse.PairValConAddr {
 		assignedKeysByAddr[utils.MustDecodeBech32(assignedKey.ProviderAddress)] = assignedKey
@@ -165,8 +199,8 @@ func (manager *Manager) GetValidatorsAndSigningInfoForConsumerChain(height int64
 		consensusAddrProvider := manager.converter.GetConsensusAddress(validatorRaw)
 		consensusAddr := consensusAddrProvider
 
-		assignedConsensusAddr, ok := assignedKeysByAddr[utils.MustDecodeBech32(consensusAddr)]
-		if ok {
+		assignedConsensusAddr, assigned := assignedKeysByAddr[utils.MustDecodeBech32(consensusAddr)]
+		if assigned {
 			consensusAddr = assignedConsensusAddr.ConsumerAddress
 		}
 
@@ -179,6 +213,12 @@ func (manager *Manager) GetValidatorsAndSigningInfoForConsumerChain(height int64
 
 		validator := manager.converter.ValidatorFromCosmosValidator(validatorRaw, signingInfo)
 		manager.converter.MustSetValidatorConsumerConsensusAddr(validator, consensusAddr)
+		validator.ProviderConsensusAddressValcons = consensusAddrProvider
+		validator.AssignedConsumerKey = assigned
+
+		if optedInAddrs != nil {
+			validator.OptedIn = null.BoolFrom(optedInAddrs[utils.MustDecodeBech32(consensusAddrProvider)])
+		}
 
 		mutex.Lock()
 		validators[index] = validator
@@ -188,8 +228,14 @@ func (manager *Manager) GetValidatorsAndSigningInfoForConsumerChain(height int64
 	return validators, nil
 }
 
-func (manager *Manager) GetBlock(height int64) (*responses.SingleBlockResponse, error) {
-	return manager.rpc.GetBlock(height)
+// GetConsumerChains returns launched consumer chains, if this chain is a provider chain.
+func (manager *Manager) GetConsumerChains() ([]*providerTypes.Chain, error) {
+	response, err := manager.fetcher.GetConsumerChains(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Chains, nil
 }
 
 func (manager *Manager) GetSigningInfos(height int64) (*slashingTypes.QuerySigningInfosResponse, error) {
@@ -200,16 +246,41 @@ func (manager *Manager) GetSlashingParams(height int64) (*slashingTypes.QueryPar
 	return manager.fetcher.GetSlashingParams(height)
 }
 
+// GetConsumerSoftOptOutThreshold returns the share of the voting power of the bottom validators
+// that are not required to sign blocks on a consumer chain. On ICS v6, where consumer chains
+// have power-shaping params, the consumer validator set only consists of validators that
+// have to sign, either forced to opt in by Top N or opted in voluntarily, so there's no soft opt-out.
+// On older ICS versions, it's the soft opt-out threshold from the consumer chain params.
+func (manager *Manager) GetConsumerSoftOptOutThreshold(height int64) (float64, error) {
+	// queried at the latest provider height, as the height is of the consumer chain
+	consumerChain, err := manager.fetcher.GetConsumerChain(0)
+	if err == nil && consumerChain.PowerShapingParams != nil {
+		return 0, nil
+	}
+
+	if err != nil {
+		manager.logger.Debug().
+			Err(err).
+			Msg("Could not get consumer chain power-shaping params, falling back to consumer params")
+	}
+
+	params, err := manager.fetcher.GetConsumerParams(height)
+	if err != nil {
+		return 0, err
+	}
+
+	if params.Params.SoftOptOutThreshold == "" { //nolint:staticcheck
+		return 0, errors.New("soft opt-out threshold is not set in consumer params")
+	}
+
+	return strconv.ParseFloat(params.Params.SoftOptOutThreshold, 64) //nolint:staticcheck
+}
+
 func (manager *Manager) GetActiveSetAtBlock(height int64) (map[string]bool, error) {
 	return manager.rpc.GetActiveSetAtBlock(height)
 }
 
-func (manager *Manager) GetBlocksAndValidatorsAtHeights(heights []int64) (
-	map[int64]*responses.SingleBlockResponse,
-	map[int64]map[string]bool,
-	[]error,
-) {
-	blocksMap := make(map[int64]*responses.SingleBlockResponse)
+func (manager *Manager) GetActiveSetsAtHeights(heights []int64) (map[int64]map[string]bool, []error) {
 	activeSetsMap := make(map[int64]map[string]bool)
 	errors := make([]error, 0)
 
@@ -217,21 +288,6 @@ func (manager *Manager) GetBlocksAndValidatorsAtHeights(heights []int64) (
 	var mutex sync.Mutex
 
 	for _, height := range heights {
-		wg.Add(1)
-		go func(height int64) {
-			block, err := manager.rpc.GetBlock(height)
-			mutex.Lock()
-			defer mutex.Unlock()
-
-			if err != nil {
-				errors = append(errors, err)
-			} else {
-				blocksMap[height] = block
-			}
-
-			wg.Done()
-		}(height)
-
 		wg.Add(1)
 		go func(height int64) {
 			activeSet, err := manager.rpc.GetActiveSetAtBlock(height)
@@ -249,5 +305,17 @@ func (manager *Manager) GetBlocksAndValidatorsAtHeights(heights []int64) (
 	}
 
 	wg.Wait()
-	return blocksMap, activeSetsMap, errors
+	return activeSetsMap, errors
+}
+
+func (manager *Manager) GetNodesStats() []types.NodeStats {
+	return append(manager.pools.GetStats(), manager.grpcPools.GetStats()...)
+}
+
+func (manager *Manager) CheckNodesLag() error {
+	return manager.rpc.CheckNodesLag()
+}
+
+func (manager *Manager) GetRPCNodesStats() []types.NodeStats {
+	return manager.pools.RPC.GetStats()
 }
@@ -5,6 +5,8 @@ import (
 	"main/assets"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
+	"main/pkg/grpc"
+	"main/pkg/http"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
 	"main/pkg/types"
@@ -16,32 +18,6 @@ import (
 	"gopkg.in/guregu/null.v4"
 )
 
-//nolint:paralleltest // disabled due to httpmock usage
-func TestGetBlock(t *testing.T) {
-	httpmock.Activate()
-	defer httpmock.DeactivateAndReset()
-
-	config := &configPkg.ChainConfig{
-		Name:         "chain",
-		RPCEndpoints: []string{"https://example.com"},
-	}
-	logger := loggerPkg.GetNopLogger()
-
-	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
-
-	httpmock.RegisterResponder(
-		"GET",
-		"https://example.com/block?height=123",
-		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-block.json")),
-	)
-
-	response, err := dataManager.GetBlock(123)
-
-	require.NoError(t, err)
-	require.NotNil(t, response)
-}
-
 //nolint:paralleltest // disabled due to httpmock usage
 func TestGetSlashingParams(t *testing.T) {
 	httpmock.Activate()
@@ -54,7 +30,12 @@ func TestGetSlashingParams(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -80,7 +61,12 @@ func TestGetValidators(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -113,7 +99,12 @@ func TestGetSigningInfos(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -128,7 +119,7 @@ func TestGetSigningInfos(t *testing.T) {
 }
 
 //nolint:paralleltest // disabled due to httpmock usage
-func TestGetBlocksAndValidatorsFail(t *testing.T) {
+func TestGetActiveSetsAtHeightsFail(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
@@ -139,29 +130,27 @@ func TestGetBlocksAndValidatorsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
-
-	httpmock.RegisterResponder(
-		"GET",
-		"https://example.com/validators?height=123&per_page=100&page=1",
-		httpmock.NewErrorResponder(errors.New("custom error")),
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
 	)
 
 	httpmock.RegisterResponder(
 		"GET",
-		"https://example.com/block?height=123",
+		"https://example.com/validators?height=123&per_page=100&page=1",
 		httpmock.NewErrorResponder(errors.New("custom error")),
 	)
 
-	_, _, errs := dataManager.GetBlocksAndValidatorsAtHeights([]int64{123})
+	_, errs := dataManager.GetActiveSetsAtHeights([]int64{123})
 
-	require.Len(t, errs, 2)
+	require.Len(t, errs, 1)
 	require.ErrorContains(t, errs[0], "custom error")
-	require.ErrorContains(t, errs[1], "custom error")
 }
 
 //nolint:paralleltest // disabled due to httpmock usage
-func TestGetBlocksAndValidatorsOk(t *testing.T) {
+func TestGetActiveSetsAtHeightsOk(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
@@ -172,12 +161,11 @@ func TestGetBlocksAndValidatorsOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
-
-	httpmock.RegisterResponder(
-		"GET",
-		"https://example.com/block?height=123",
-		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-block.json")),
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
 	)
 
 	httpmock.RegisterResponder(
@@ -192,11 +180,11 @@ func TestGetBlocksAndValidatorsOk(t *testing.T) {
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-tendermint-validators-2.json")),
 	)
 
-	blocks, validators, errs := dataManager.GetBlocksAndValidatorsAtHeights([]int64{123})
+	validators, errs := dataManager.GetActiveSetsAtHeights([]int64{123})
 
 	require.Empty(t, errs)
-	require.NotEmpty(t, blocks)
-	require.NotEmpty(t, validators)
+	require.Len(t, validators, 1)
+	require.NotEmpty(t, validators[123])
 }
 
 //nolint:paralleltest // disabled due to httpmock usage
@@ -212,7 +200,12 @@ func TestGetValidatorsValidatorsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -240,7 +233,12 @@ func TestGetValidatorsSigningInfosFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -274,7 +272,12 @@ func TestGetValidatorsSigningInfosOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -316,7 +319,12 @@ func TestGetValidatorsConsumerValidatorsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -347,7 +355,12 @@ func TestGetValidatorsConsumerSigningInfosFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -384,7 +397,12 @@ func TestGetValidatorsConsumerAssignedKeysFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -427,7 +445,12 @@ func TestGetValidatorsConsumerAssignedKeysOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -476,7 +499,12 @@ func TestGetValidatorsConsumerAssignedKeysOkAnotherPrefix(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	dataManager := NewManager(*logger, config, metricsManager)
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -507,3 +535,314 @@ func TestGetValidatorsConsumerAssignedKeysOkAnotherPrefix(t *testing.T) {
 	})
 	require.Len(t, withoutSigningInfo, 326)
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetConsumerSoftOptOutThresholdFromPowerShaping(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		IsConsumer:           null.BoolFrom(true),
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChain%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-consumer-chain.json")),
+	)
+
+	threshold, err := dataManager.GetConsumerSoftOptOutThreshold(0)
+
+	// validators that opted in voluntarily below Top N have to sign as well
+	require.NoError(t, err)
+	require.Zero(t, threshold)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetConsumerSoftOptOutThresholdFromConsumerParams(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		IsConsumer:           null.BoolFrom(true),
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChain%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-error.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://consumer-example.com/abci_query?path=%22%2Finterchain_security.ccv.consumer.v1.Query%2FQueryParams%22&data=0x&height=100",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-consumer-params.json")),
+	)
+
+	threshold, err := dataManager.GetConsumerSoftOptOutThreshold(100)
+
+	require.NoError(t, err)
+	require.InDelta(t, 0.05, threshold, 0.0001)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetConsumerSoftOptOutThresholdFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		IsConsumer:           null.BoolFrom(true),
+		ConsumerID:           "consumer",
+		RPCEndpoints:         []string{"https://consumer-example.com"},
+		ProviderRPCEndpoints: []string{"https://provider-example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider-example.com/abci_query?path=%22%2Finterchain_security.ccv.provider.v1.Query%2FQueryConsumerChain%22&data=0x0a08636f6e73756d6572",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://consumer-example.com/abci_query?path=%22%2Finterchain_security.ccv.consumer.v1.Query%2FQueryParams%22&data=0x",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	_, err := dataManager.GetConsumerSoftOptOutThreshold(0)
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetValidatorsConsumerOptedInOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		FetcherType:          constants.FetcherTypeCosmosLCD,
+		IsConsumer:           null.BoolFrom(true),
+		ConsumerID:           "consumer",
+		LCDEndpoints:         []string{"https://consumer.com"},
+		ProviderLCDEndpoints: []string{"https://provider.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/cosmos/staking/v1beta1/validators?pagination.limit=1000",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-validators-cosmos.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://consumer.com/cosmos/slashing/v1beta1/signing_infos?pagination.limit=1000",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-signing-infos-neutron.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/interchain_security/ccv/provider/address_pairs/consumer",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-assigned-keys-neutron.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/interchain_security/ccv/provider/opted_in_validators/consumer",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-opted-in-validators.json")),
+	)
+
+	validators, err := dataManager.GetValidators(123)
+
+	require.NoError(t, err)
+	require.Len(t, validators, 547)
+
+	withoutOptedIn := utils.Filter(validators, func(v *types.Validator) bool {
+		return !v.OptedIn.Valid
+	})
+	require.Empty(t, withoutOptedIn)
+
+	optedIn := utils.Filter(validators, func(v *types.Validator) bool {
+		return v.OptedIn.Bool
+	})
+	require.Len(t, optedIn, 2)
+
+	withoutProviderAddress := utils.Filter(validators, func(v *types.Validator) bool {
+		return v.ProviderConsensusAddressValcons == ""
+	})
+	require.Empty(t, withoutProviderAddress)
+
+	withConsumerKey := utils.Filter(validators, func(v *types.Validator) bool {
+		return v.AssignedConsumerKey
+	})
+	require.Len(t, withConsumerKey, 141)
+	require.NotEqual(t, withConsumerKey[0].ProviderConsensusAddressValcons, withConsumerKey[0].ConsensusAddressValcons)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetValidatorsConsumerOptedInFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:                 "chain",
+		FetcherType:          constants.FetcherTypeCosmosLCD,
+		IsConsumer:           null.BoolFrom(true),
+		ConsumerID:           "consumer",
+		LCDEndpoints:         []string{"https://consumer.com"},
+		ProviderLCDEndpoints: []string{"https://provider.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/cosmos/staking/v1beta1/validators?pagination.limit=1000",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-validators-cosmos.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://consumer.com/cosmos/slashing/v1beta1/signing_infos?pagination.limit=1000",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-signing-infos-neutron.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/interchain_security/ccv/provider/address_pairs/consumer",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-assigned-keys-neutron.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/interchain_security/ccv/provider/opted_in_validators/consumer",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	validators, err := dataManager.GetValidators(123)
+
+	require.NoError(t, err)
+	require.Len(t, validators, 547)
+
+	withOptedIn := utils.Filter(validators, func(v *types.Validator) bool {
+		return v.OptedIn.Valid
+	})
+	require.Empty(t, withOptedIn)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetConsumerChainsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		FetcherType:  constants.FetcherTypeCosmosLCD,
+		LCDEndpoints: []string{"https://provider.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/interchain_security/ccv/provider/consumer_chains/3?pagination.limit=1000",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	chains, err := dataManager.GetConsumerChains()
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, chains)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetConsumerChainsOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		FetcherType:  constants.FetcherTypeCosmosLCD,
+		LCDEndpoints: []string{"https://provider.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	dataManager := NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://provider.com/interchain_security/ccv/provider/consumer_chains/3?pagination.limit=1000",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-consumer-chains.json")),
+	)
+
+	chains, err := dataManager.GetConsumerChains()
+
+	require.NoError(t, err)
+	require.Len(t, chains, 3)
+	require.Equal(t, "stride-1", chains[1].ChainId)
+}
@@ -29,5 +29,11 @@ type DatabaseClient interface {
 	Exec(query string, args ...any) (sql.Result, error)
 	Query(query string, args ...any) (*sql.Rows, error)
 	QueryRow(query string, args ...any) *sql.Row
+	Begin() (*sql.Tx, error)
 	Migrate() error
 }
+
+// Executor is what both DatabaseClient and a transaction can execute queries with.
+type Executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
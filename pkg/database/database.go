@@ -2,12 +2,14 @@ package database
 
 import (
 	"encoding/json"
+	"fmt"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	eventsPkg "main/pkg/events"
 	snapshotPkg "main/pkg/snapshot"
 	"main/pkg/types"
 	"main/pkg/utils"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +24,11 @@ type Database struct {
 	config configPkg.DatabaseConfig
 	client DatabaseClient
 	mutex  sync.Mutex
+
+	// dictionaries are shared by all chains, so they are guarded by their own mutex,
+	// which is held regardless of the database type
+	dictionaries      map[string]*ValidatorsDictionary
+	dictionariesMutex sync.Mutex
 }
 
 func NewDatabase(
@@ -29,8 +36,9 @@ func NewDatabase(
 	config configPkg.DatabaseConfig,
 ) *Database {
 	return &Database{
-		logger: logger.With().Str("component", "state_manager").Logger(),
-		config: config,
+		logger:       logger.With().Str("component", "state_manager").Logger(),
+		config:       config,
+		dictionaries: map[string]*ValidatorsDictionary{},
 	}
 }
 
@@ -51,6 +59,10 @@ func (d *Database) Init() {
 	}
 
 	d.SetClient(db)
+
+	if err := d.MigrateLegacyBlocks(); err != nil {
+		d.logger.Panic().Err(err).Msg("Failed to migrate blocks to bitmaps")
+	}
 }
 
 func (d *Database) SetClient(client DatabaseClient) {
@@ -69,21 +81,99 @@ func (d *Database) MaybeMutexUnlock() {
 	}
 }
 
+// GetValidatorsDictionary returns the dictionary of validators addresses used for encoding
+// and decoding blocks, loading it from the database the first time it's requested for a chain.
+// Should be called with the dictionaries mutex held.
+func (d *Database) GetValidatorsDictionary(chain string) (*ValidatorsDictionary, error) {
+	if dictionary, ok := d.dictionaries[chain]; ok {
+		return dictionary, nil
+	}
+
+	dictionary := NewValidatorsDictionary()
+
+	rows, err := d.client.Query(
+		"SELECT id, address FROM validators_dictionary WHERE chain = $1",
+		chain,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting validators dictionary")
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			id      int
+			address string
+		)
+
+		if err := rows.Scan(&id, &address); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching validators dictionary entry")
+			return nil, err
+		}
+
+		dictionary.Set(id, address)
+	}
+
+	d.dictionaries[chain] = dictionary
+	return dictionary, nil
+}
+
+// EncodeBlock encodes a block into a bitmap, adding validators that are not
+// in the dictionary yet into it.
+func (d *Database) EncodeBlock(chain string, block *types.Block) ([]byte, error) {
+	d.dictionariesMutex.Lock()
+	defer d.dictionariesMutex.Unlock()
+
+	dictionary, err := d.GetValidatorsDictionary(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	for address, id := range dictionary.GetNewAddresses(block) {
+		if _, err := d.client.Exec(
+			"INSERT INTO validators_dictionary (chain, id, address) VALUES ($1, $2, $3)",
+			chain,
+			id,
+			address,
+		); err != nil {
+			d.logger.Error().Err(err).Msg("Error saving validators dictionary entry")
+
+			// the dictionary in memory might be inconsistent with the database now,
+			// so dropping it, it will be reloaded on the next call
+			delete(d.dictionaries, chain)
+			return nil, err
+		}
+
+		dictionary.Set(id, address)
+	}
+
+	return dictionary.Encode(block)
+}
+
+// InsertBlock stores a block, replacing the stored one at the same height if any,
+// so a refetched block is stored the same way it's counted in the signatures window.
 func (d *Database) InsertBlock(chain string, block *types.Block) error {
 	d.MaybeMutexLock()
 	defer d.MaybeMutexUnlock()
 
-	signaturesBytes := utils.MustJSONMarshall(block.Signatures)
-	validatorsBytes := utils.MustJSONMarshall(block.Validators)
+	bitmap, err := d.EncodeBlock(chain, block)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error encoding block")
+		return err
+	}
 
-	_, err := d.client.Exec(
-		"INSERT INTO blocks (chain, height, time, proposer, signatures, validators) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING",
+	_, err = d.client.Exec(
+		"INSERT INTO blocks (chain, height, time, proposer, signatures, validators, bitmap) VALUES ($1, $2, $3, $4, '', '', $5) "+
+			"ON CONFLICT (chain, height) DO UPDATE SET time = excluded.time, proposer = excluded.proposer, bitmap = excluded.bitmap",
 		chain,
 		block.Height,
 		block.Time.Unix(),
 		block.Proposer,
-		signaturesBytes,
-		validatorsBytes,
+		bitmap,
 	)
 	if err != nil {
 		d.logger.Error().Err(err).Msg("Error saving block")
@@ -93,19 +183,131 @@ func (d *Database) InsertBlock(chain string, block *types.Block) error {
 	return nil
 }
 
+// InsertMissedBlocks stores heights validators have missed, so it's possible
+// to find out exactly when a validator was missing blocks. The ones stored before
+// at the same height are removed, so a refetched block replaces them.
+func (d *Database) InsertMissedBlocks(chain string, block *types.Block) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	if _, err := d.client.Exec(
+		"DELETE FROM missed_blocks WHERE chain = $1 AND height = $2",
+		chain,
+		block.Height,
+	); err != nil {
+		d.logger.Error().Err(err).Msg("Error deleting missed blocks")
+		return err
+	}
+
+	missedValidators := block.GetMissedValidators()
+	if len(missedValidators) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(missedValidators))
+	args := make([]any, 0, len(missedValidators)*4)
+
+	// placeholders are not reused, as sqlite binds them by the order they appear in the query
+	for index, validator := range missedValidators {
+		values[index] = fmt.Sprintf("($%d, $%d, $%d, $%d)", index*4+1, index*4+2, index*4+3, index*4+4)
+		args = append(args, chain, validator, block.Height, block.Time.Unix())
+	}
+
+	_, err := d.client.Exec(
+		"INSERT INTO missed_blocks (chain, validator, height, time) VALUES "+
+			strings.Join(values, ", ")+
+			" ON CONFLICT DO NOTHING",
+		args...,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error saving missed blocks")
+		return err
+	}
+
+	return nil
+}
+
+func (d *Database) FindLastMissedBlocks(
+	chain string,
+	validator string,
+	count int64,
+) ([]types.MissedBlock, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	missedBlocks := []types.MissedBlock{}
+
+	rows, err := d.client.Query(
+		"SELECT height, time FROM missed_blocks WHERE chain = $1 AND validator = $2 ORDER BY height DESC LIMIT $3",
+		chain,
+		validator,
+		count,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting missed blocks")
+		return missedBlocks, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			blockHeight int64
+			blockTime   int64
+		)
+
+		if err := rows.Scan(&blockHeight, &blockTime); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching missed block")
+			return missedBlocks, err
+		}
+
+		missedBlocks = append(missedBlocks, types.MissedBlock{
+			Height: blockHeight,
+			Time:   time.Unix(blockTime, 0),
+		})
+	}
+
+	return missedBlocks, nil
+}
+
 func (d *Database) GetAllBlocks(chain string) (map[int64]*types.Block, error) {
+	return d.QueryBlocks(
+		chain,
+		"SELECT height, time, proposer, signatures, validators, bitmap FROM blocks WHERE chain = $1",
+		chain,
+	)
+}
+
+func (d *Database) GetBlocksInRange(chain string, fromHeight, toHeight int64) (map[int64]*types.Block, error) {
+	return d.QueryBlocks(
+		chain,
+		"SELECT height, time, proposer, signatures, validators, bitmap FROM blocks WHERE chain = $1 AND height >= $2 AND height <= $3",
+		chain,
+		fromHeight,
+		toHeight,
+	)
+}
+
+func (d *Database) QueryBlocks(chain string, query string, args ...any) (map[int64]*types.Block, error) {
 	d.MaybeMutexLock()
 	defer d.MaybeMutexUnlock()
 
 	blocks := map[int64]*types.Block{}
 
+	d.dictionariesMutex.Lock()
+	dictionary, err := d.GetValidatorsDictionary(chain)
+	d.dictionariesMutex.Unlock()
+
+	if err != nil {
+		return blocks, err
+	}
+
 	// Getting blocks
-	blocksRows, err := d.client.Query(
-		"SELECT height, time, proposer, signatures, validators FROM blocks WHERE chain = $1",
-		chain,
-	)
+	blocksRows, err := d.client.Query(query, args...)
 	if err != nil {
-		d.logger.Error().Err(err).Msg("Error getting all blocks")
+		d.logger.Error().Err(err).Msg("Error getting blocks")
 		return blocks, err
 	}
 	defer func() {
@@ -120,22 +322,30 @@ func (d *Database) GetAllBlocks(chain string) (map[int64]*types.Block, error) {
 			blockProposer string
 			signaturesRaw []byte
 			validatorsRaw []byte
+			bitmap        []byte
 			signatures    = map[string]int32{}
 			validators    = map[string]bool{}
 		)
 
-		err = blocksRows.Scan(&blockHeight, &blockTime, &blockProposer, &signaturesRaw, &validatorsRaw)
+		err = blocksRows.Scan(&blockHeight, &blockTime, &blockProposer, &signaturesRaw, &validatorsRaw, &bitmap)
 		if err != nil {
 			d.logger.Error().Err(err).Msg("Error fetching block data")
 			return blocks, err
 		}
 
-		if unmarshalErr := json.Unmarshal(signaturesRaw, &signatures); unmarshalErr != nil {
-			d.logger.Error().Err(unmarshalErr).Msg("Error unmarshalling signatures")
-		}
-
-		if unmarshalErr := json.Unmarshal(validatorsRaw, &validators); unmarshalErr != nil {
-			d.logger.Error().Err(unmarshalErr).Msg("Error unmarshalling validators")
+		if bitmap != nil {
+			// the dictionary might be appended to by the blocks being inserted concurrently
+			d.dictionariesMutex.Lock()
+			decodedSignatures, decodedValidators, decodeErr := dictionary.Decode(bitmap)
+			d.dictionariesMutex.Unlock()
+
+			if decodeErr != nil {
+				d.logger.Error().Err(decodeErr).Int64("height", blockHeight).Msg("Error decoding block")
+			} else {
+				signatures, validators = decodedSignatures, decodedValidators
+			}
+		} else {
+			signatures, validators = d.DecodeLegacyBlock(signaturesRaw, validatorsRaw)
 		}
 
 		block := &types.Block{
@@ -151,6 +361,133 @@ func (d *Database) GetAllBlocks(chain string) (map[int64]*types.Block, error) {
 	return blocks, nil
 }
 
+// GetBlocksTimes returns heights of all stored blocks along with their time,
+// without decoding the blocks.
+func (d *Database) GetBlocksTimes(chain string) (map[int64]time.Time, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	times := map[int64]time.Time{}
+
+	rows, err := d.client.Query(
+		"SELECT height, time FROM blocks WHERE chain = $1",
+		chain,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting blocks times")
+		return times, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			blockHeight int64
+			blockTime   int64
+		)
+
+		if err := rows.Scan(&blockHeight, &blockTime); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching block time")
+			return times, err
+		}
+
+		times[blockHeight] = time.Unix(blockTime, 0)
+	}
+
+	return times, nil
+}
+
+// DecodeLegacyBlock decodes signatures and validators of blocks that were stored
+// as JSON maps, before blocks were stored as bitmaps.
+func (d *Database) DecodeLegacyBlock(signaturesRaw, validatorsRaw []byte) (map[string]int32, map[string]bool) {
+	signatures := map[string]int32{}
+	validators := map[string]bool{}
+
+	if unmarshalErr := json.Unmarshal(signaturesRaw, &signatures); unmarshalErr != nil {
+		d.logger.Error().Err(unmarshalErr).Msg("Error unmarshalling signatures")
+	}
+
+	if unmarshalErr := json.Unmarshal(validatorsRaw, &validators); unmarshalErr != nil {
+		d.logger.Error().Err(unmarshalErr).Msg("Error unmarshalling validators")
+	}
+
+	return signatures, validators
+}
+
+// MigrateLegacyBlocks converts blocks stored as JSON maps into bitmaps, in batches.
+func (d *Database) MigrateLegacyBlocks() error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	type legacyBlock struct {
+		chain         string
+		height        int64
+		signaturesRaw []byte
+		validatorsRaw []byte
+	}
+
+	migrated := 0
+
+	for {
+		rows, err := d.client.Query(
+			"SELECT chain, height, signatures, validators FROM blocks WHERE bitmap IS NULL LIMIT $1",
+			constants.LegacyBlocksMigrationBatchSize,
+		)
+		if err != nil {
+			d.logger.Error().Err(err).Msg("Error getting legacy blocks")
+			return err
+		}
+
+		legacyBlocks := make([]legacyBlock, 0)
+
+		for rows.Next() {
+			var block legacyBlock
+			if err := rows.Scan(&block.chain, &block.height, &block.signaturesRaw, &block.validatorsRaw); err != nil {
+				_ = rows.Close()
+				d.logger.Error().Err(err).Msg("Error fetching legacy block data")
+				return err
+			}
+
+			legacyBlocks = append(legacyBlocks, block)
+		}
+
+		_ = rows.Close()
+
+		if len(legacyBlocks) == 0 {
+			break
+		}
+
+		for _, legacy := range legacyBlocks {
+			signatures, validators := d.DecodeLegacyBlock(legacy.signaturesRaw, legacy.validatorsRaw)
+
+			bitmap, err := d.EncodeBlock(legacy.chain, &types.Block{
+				Signatures: signatures,
+				Validators: validators,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err := d.client.Exec(
+				"UPDATE blocks SET signatures = '', validators = '', bitmap = $1 WHERE chain = $2 AND height = $3",
+				bitmap,
+				legacy.chain,
+				legacy.height,
+			); err != nil {
+				d.logger.Error().Err(err).Msg("Error saving migrated block")
+				return err
+			}
+		}
+
+		migrated += len(legacyBlocks)
+		d.logger.Info().Int("count", migrated).Msg("Migrated blocks to bitmaps")
+	}
+
+	return nil
+}
+
 func (d *Database) TrimBlocksBefore(chain string, height int64) error {
 	d.MaybeMutexLock()
 	defer d.MaybeMutexUnlock()
@@ -165,6 +502,47 @@ func (d *Database) TrimBlocksBefore(chain string, height int64) error {
 		return err
 	}
 
+	_, err = d.client.Exec(
+		"DELETE FROM missed_blocks WHERE height <= $1 AND chain = $2",
+		height,
+		chain,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error trimming missed blocks")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBlocks removes blocks and missed blocks at the heights, so they can be fetched again.
+func (d *Database) DeleteBlocks(chain string, heights []int64) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	for _, chunk := range utils.SplitIntoChunks(heights, constants.StateBlocksLoadBatchSize) {
+		placeholders := make([]string, len(chunk))
+		args := make([]any, 0, len(chunk)+1)
+		args = append(args, chain)
+
+		for index, height := range chunk {
+			placeholders[index] = fmt.Sprintf("$%d", index+2)
+			args = append(args, height)
+		}
+
+		inClause := "(" + strings.Join(placeholders, ", ") + ")"
+
+		if _, err := d.client.Exec("DELETE FROM blocks WHERE chain = $1 AND height IN "+inClause, args...); err != nil {
+			d.logger.Error().Err(err).Msg("Error deleting blocks")
+			return err
+		}
+
+		if _, err := d.client.Exec("DELETE FROM missed_blocks WHERE chain = $1 AND height IN "+inClause, args...); err != nil {
+			d.logger.Error().Err(err).Msg("Error deleting missed blocks")
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -224,7 +602,18 @@ func (d *Database) InsertNotifier(
 	d.MaybeMutexLock()
 	defer d.MaybeMutexUnlock()
 
-	_, err := d.client.Exec(
+	return d.insertNotifier(d.client, chain, operatorAddress, reporter, userID, userName)
+}
+
+func (d *Database) insertNotifier(
+	executor Executor,
+	chain string,
+	operatorAddress string,
+	reporter constants.ReporterName,
+	userID string,
+	userName string,
+) error {
+	_, err := executor.Exec(
 		"INSERT INTO notifiers (chain, operator_address, reporter, user_id, user_name) VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING",
 		chain,
 		operatorAddress,
@@ -288,7 +677,11 @@ func (d *Database) SetValueByKey(chain string, key string, data []byte) error {
 	d.MaybeMutexLock()
 	defer d.MaybeMutexUnlock()
 
-	_, err := d.client.Exec(
+	return d.setValueByKey(d.client, chain, key, data)
+}
+
+func (d *Database) setValueByKey(executor Executor, chain string, key string, data []byte) error {
+	_, err := executor.Exec(
 		"INSERT INTO data (chain, key, value) VALUES ($1, $2, $3) ON CONFLICT (chain, key) DO UPDATE SET value = $3",
 		chain,
 		key,
@@ -335,12 +728,18 @@ func (d *Database) InsertEvent(chain string, height int64, entry types.ReportEve
 
 	payloadBytes := utils.MustJSONMarshall(entry)
 
+	// chain-level events are stored with an empty validator
+	validatorAddress := ""
+	if validator := entry.GetValidator(); validator != nil {
+		validatorAddress = validator.OperatorAddress
+	}
+
 	_, err := d.client.Exec(
 		"INSERT INTO events (chain, event, height, validator, payload, time) VALUES ($1, $2, $3, $4, $5, NOW())",
 		chain,
 		entry.Type(),
 		height,
-		entry.GetValidator().OperatorAddress,
+		validatorAddress,
 		payloadBytes,
 	)
 	if err != nil {
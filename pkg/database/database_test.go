@@ -3,6 +3,7 @@ package database
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/events"
@@ -10,6 +11,7 @@ import (
 	snapshotPkg "main/pkg/snapshot"
 	"main/pkg/types"
 	"main/pkg/utils"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,12 +20,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestDatabaseInsertBlockFail(t *testing.T) {
+func TestDatabaseInsertBlockDictionaryFail(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
 	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
-	database.SetClient(&StubDatabaseClient{ExecError: errors.New("custom error")})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnError(errors.New("custom error"))
 
 	err := database.InsertBlock("chain", &types.Block{
 		Height:     123,
@@ -36,12 +43,43 @@ func TestDatabaseInsertBlockFail(t *testing.T) {
 	require.ErrorContains(t, err, "custom error")
 }
 
-func TestDatabaseInsertBlockOk(t *testing.T) {
+func TestDatabaseInsertBlockDictionaryInsertFail(t *testing.T) {
 	t.Parallel()
 
 	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
 	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
-	database.SetClient(&StubDatabaseClient{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}))
+
+	err := database.InsertBlock("chain", &types.Block{
+		Height:     123,
+		Time:       time.Now(),
+		Proposer:   "123",
+		Signatures: map[string]int32{"validator": 2},
+		Validators: map[string]bool{"validator": true},
+	})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.NotContains(t, database.dictionaries, "chain")
+}
+
+func TestDatabaseInsertBlockFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}))
 
 	err := database.InsertBlock("chain", &types.Block{
 		Height:     123,
@@ -50,7 +88,31 @@ func TestDatabaseInsertBlockOk(t *testing.T) {
 		Signatures: map[string]int32{},
 		Validators: map[string]bool{},
 	})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseInsertBlockOk(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}).AddRow(0, "validator"))
+
+	err := database.InsertBlock("chain", &types.Block{
+		Height:     123,
+		Time:       time.Now(),
+		Proposer:   "123",
+		Signatures: map[string]int32{"validator": 2, "another": 1},
+		Validators: map[string]bool{"validator": true},
+	})
 	require.NoError(t, err)
+	require.Equal(t, []string{"validator", "another"}, database.dictionaries["chain"].Addresses)
 }
 
 func TestDatabaseTrimBlocksFail(t *testing.T) {
@@ -76,6 +138,85 @@ func TestDatabaseTrimBlocksOk(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDatabaseInsertBlockReplaces(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	require.NoError(t, database.InsertBlock("chain", &types.Block{
+		Height:     1,
+		Time:       time.Unix(1, 0),
+		Proposer:   "proposer",
+		Signatures: map[string]int32{},
+		Validators: map[string]bool{"address": true},
+	}))
+	require.NoError(t, database.InsertBlock("chain", &types.Block{
+		Height:     1,
+		Time:       time.Unix(2, 0),
+		Proposer:   "other",
+		Signatures: map[string]int32{"address": 2},
+		Validators: map[string]bool{"address": true},
+	}))
+
+	blocks, err := database.GetAllBlocks("chain")
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, "other", blocks[1].Proposer)
+	require.Equal(t, int64(2), blocks[1].Time.Unix())
+	require.Equal(t, int32(2), blocks[1].Signatures["address"])
+}
+
+func TestDatabaseDeleteBlocksFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(&StubDatabaseClient{ExecError: errors.New("custom error")})
+
+	err := database.DeleteBlocks("chain", []int64{1, 2})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseDeleteBlocksOk(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	for height := int64(1); height <= 3; height++ {
+		block := &types.Block{
+			Height:     height,
+			Time:       time.Unix(height, 0),
+			Signatures: map[string]int32{},
+			Validators: map[string]bool{"address": true},
+		}
+		require.NoError(t, database.InsertBlock("chain", block))
+		require.NoError(t, database.InsertMissedBlocks("chain", block))
+	}
+
+	require.NoError(t, database.DeleteBlocks("chain", []int64{1, 3}))
+
+	blocks, err := database.GetAllBlocks("chain")
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Contains(t, blocks, int64(2))
+
+	missedBlocks, err := database.FindLastMissedBlocks("chain", "address", 10)
+	require.NoError(t, err)
+	require.Len(t, missedBlocks, 1)
+	require.Equal(t, int64(2), missedBlocks[0].Height)
+}
+
 func TestDatabaseInsertNotifierFail(t *testing.T) {
 	t.Parallel()
 
@@ -201,6 +342,17 @@ func TestDatabaseInsertEventOk(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDatabaseInsertChainEventOk(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(&StubDatabaseClient{})
+
+	err := database.InsertEvent("chain", 123, &events.SlashingParamsChanged{})
+	require.NoError(t, err)
+}
+
 func TestDatabaseGetValueByKeyFail(t *testing.T) {
 	t.Parallel()
 
@@ -335,6 +487,23 @@ func TestDatabaseGetNotifiersOk(t *testing.T) {
 	}, result)
 }
 
+func TestDatabaseGetAllBlocksDictionaryFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.GetAllBlocks("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
 func TestDatabaseGetAllBlocksFail(t *testing.T) {
 	t.Parallel()
 
@@ -344,7 +513,11 @@ func TestDatabaseGetAllBlocksFail(t *testing.T) {
 	database.SetClient(client)
 
 	client.Mock.
-		ExpectQuery("SELECT height, time, proposer, signatures, validators FROM blocks").
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}))
+
+	client.Mock.
+		ExpectQuery("SELECT height, time, proposer, signatures, validators, bitmap FROM blocks").
 		WillReturnError(errors.New("custom error"))
 
 	_, err := database.GetAllBlocks("chain")
@@ -352,6 +525,87 @@ func TestDatabaseGetAllBlocksFail(t *testing.T) {
 	require.ErrorContains(t, err, "custom error")
 }
 
+func TestDatabaseGetBlocksInRangeOk(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	rows := sqlmock.NewRows([]string{"height", "time", "proposer", "signatures", "validators", "bitmap"}).
+		AddRow("123", time.Now().Unix(), "proposer", "", "", []byte{0b0000_1110})
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}).AddRow(0, "validator"))
+
+	client.Mock.
+		ExpectQuery("SELECT height, time, proposer, signatures, validators, bitmap FROM blocks WHERE chain = \\$1 AND height >= \\$2 AND height <= \\$3").
+		WithArgs("chain", int64(120), int64(125)).
+		WillReturnRows(rows)
+
+	result, err := database.GetBlocksInRange("chain", 120, 125)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, map[string]int32{"validator": 2}, result[123].Signatures)
+	require.Equal(t, map[string]bool{"validator": true}, result[123].Validators)
+}
+
+func TestDatabaseGetBlocksTimesFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT height, time FROM blocks").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.GetBlocksTimes("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseGetBlocksTimesFailToScan(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT height, time FROM blocks").
+		WillReturnRows(sqlmock.NewRows([]string{"height", "time"}).AddRow("invalid", "invalid"))
+
+	_, err := database.GetBlocksTimes("chain")
+	require.Error(t, err)
+}
+
+func TestDatabaseGetBlocksTimesOk(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	blockTime := time.Now().Round(time.Second)
+
+	client.Mock.
+		ExpectQuery("SELECT height, time FROM blocks").
+		WillReturnRows(sqlmock.NewRows([]string{"height", "time"}).
+			AddRow(123, blockTime.Unix()).
+			AddRow(124, blockTime.Unix()))
+
+	result, err := database.GetBlocksTimes("chain")
+	require.NoError(t, err)
+	require.Equal(t, map[int64]time.Time{123: blockTime, 124: blockTime}, result)
+}
+
 func TestDatabaseGetBlocksFailToUnmarshal(t *testing.T) {
 	t.Parallel()
 
@@ -360,11 +614,44 @@ func TestDatabaseGetBlocksFailToUnmarshal(t *testing.T) {
 	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
 	database.SetClient(client)
 
-	rows := sqlmock.NewRows([]string{"height", "time", "proposer", "signatures", "validators"}).
-		AddRow("123", time.Now().Unix(), "proposer", "invalid", "invalid")
+	rows := sqlmock.NewRows([]string{"height", "time", "proposer", "signatures", "validators", "bitmap"}).
+		AddRow("123", time.Now().Unix(), "proposer", "invalid", "invalid", nil)
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}))
 
 	client.Mock.
-		ExpectQuery("SELECT height, time, proposer, signatures, validators FROM blocks").
+		ExpectQuery("SELECT height, time, proposer, signatures, validators, bitmap FROM blocks").
+		WillReturnRows(rows)
+
+	result, err := database.GetAllBlocks("chain")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	block, ok := result[123]
+	require.True(t, ok)
+	require.Empty(t, block.Validators)
+	require.Empty(t, block.Signatures)
+}
+
+func TestDatabaseGetBlocksFailToDecode(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	rows := sqlmock.NewRows([]string{"height", "time", "proposer", "signatures", "validators", "bitmap"}).
+		AddRow("123", time.Now().Unix(), "proposer", "", "", []byte{0b11101110})
+
+	client.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}))
+
+	client.Mock.
+		ExpectQuery("SELECT height, time, proposer, signatures, validators, bitmap FROM blocks").
 		WillReturnRows(rows)
 
 	result, err := database.GetAllBlocks("chain")
@@ -387,21 +674,33 @@ func TestDatabaseGetBlocksOk(t *testing.T) {
 
 	blockTime := time.Now().Round(time.Second)
 
-	rows := sqlmock.NewRows([]string{"height", "time", "proposer", "signatures", "validators"}).
+	rows := sqlmock.NewRows([]string{"height", "time", "proposer", "signatures", "validators", "bitmap"}).
 		AddRow(
 			"123", blockTime.Unix(),
 			"proposer",
 			utils.MustJSONMarshall(map[string]int32{"validator": 2}),
 			utils.MustJSONMarshall(map[string]bool{"validator": true}),
+			nil,
+		).
+		AddRow(
+			"124", blockTime.Unix(),
+			"proposer",
+			"",
+			"",
+			[]byte{0b0101_1110},
 		)
 
 	client.Mock.
-		ExpectQuery("SELECT height, time, proposer, signatures, validators FROM blocks").
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}).AddRow(0, "validator").AddRow(1, "another"))
+
+	client.Mock.
+		ExpectQuery("SELECT height, time, proposer, signatures, validators, bitmap FROM blocks").
 		WillReturnRows(rows)
 
 	result, err := database.GetAllBlocks("chain")
 	require.NoError(t, err)
-	require.Len(t, result, 1)
+	require.Len(t, result, 2)
 
 	block, ok := result[123]
 	require.True(t, ok)
@@ -412,6 +711,160 @@ func TestDatabaseGetBlocksOk(t *testing.T) {
 		Signatures: map[string]int32{"validator": 2},
 		Validators: map[string]bool{"validator": true},
 	}, block)
+
+	anotherBlock, ok := result[124]
+	require.True(t, ok)
+	require.Equal(t, &types.Block{
+		Height:     124,
+		Time:       blockTime,
+		Proposer:   "proposer",
+		Signatures: map[string]int32{"validator": 2, "another": 1},
+		Validators: map[string]bool{"validator": true},
+	}, anotherBlock)
+}
+
+func TestDatabaseConcurrentChainsBlocks(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite?_busy_timeout=10000&_journal_mode=WAL",
+	})
+	database.Init()
+
+	// simulating a database that is not guarded by the database mutex, like Postgres
+	database.config.Type = constants.DatabaseTypePostgres
+
+	chains := []string{"chain-1", "chain-2", "chain-3"}
+	writersPerChain := 2
+	blocksPerWriter := int64(20)
+
+	getBlock := func(chain string, height int64) *types.Block {
+		// each block introduces a new validator, so the dictionaries are written to concurrently
+		return &types.Block{
+			Height: height,
+			Time:   time.Unix(height, 0),
+			Signatures: map[string]int32{
+				fmt.Sprintf("%s-validator-%d", chain, height): constants.ValidatorSigned,
+				chain + "-validator":                          constants.ValidatorSigned,
+			},
+			Validators: map[string]bool{
+				fmt.Sprintf("%s-validator-%d", chain, height): true,
+				chain + "-validator":                          true,
+			},
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(chains)*(writersPerChain+1)*int(blocksPerWriter))
+
+	for _, chain := range chains {
+		for writer := 0; writer < writersPerChain; writer++ {
+			wg.Add(1)
+			go func(chain string, writer int) {
+				defer wg.Done()
+
+				for index := int64(1); index <= blocksPerWriter; index++ {
+					height := int64(writer)*blocksPerWriter + index
+					if err := database.InsertBlock(chain, getBlock(chain, height)); err != nil {
+						errs <- err
+					}
+				}
+			}(chain, writer)
+		}
+
+		wg.Add(1)
+		go func(chain string) {
+			defer wg.Done()
+
+			for index := int64(0); index < blocksPerWriter; index++ {
+				if _, err := database.GetAllBlocks(chain); err != nil {
+					errs <- err
+				}
+			}
+		}(chain)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	for _, chain := range chains {
+		blocks, err := database.GetAllBlocks(chain)
+		require.NoError(t, err)
+		require.Len(t, blocks, writersPerChain*int(blocksPerWriter))
+
+		for height, block := range blocks {
+			expected := getBlock(chain, height)
+			require.Equal(t, expected.Signatures, block.Signatures)
+			require.Equal(t, expected.Validators, block.Validators)
+		}
+	}
+}
+
+func TestDatabaseMigrateLegacyBlocksSqlite(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	blockTime := time.Now().Round(time.Second)
+
+	_, err := database.client.Exec(
+		"INSERT INTO blocks (chain, height, time, proposer, signatures, validators) VALUES ($1, $2, $3, $4, $5, $6)",
+		"chain",
+		123,
+		blockTime.Unix(),
+		"proposer",
+		utils.MustJSONMarshall(map[string]int32{"validator": 2, "another": 3}),
+		utils.MustJSONMarshall(map[string]bool{"validator": true, "another": true}),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, database.MigrateLegacyBlocks())
+
+	err = database.InsertBlock("chain", &types.Block{
+		Height:     124,
+		Time:       blockTime,
+		Proposer:   "proposer",
+		Signatures: map[string]int32{"validator": 1, "third": 2},
+		Validators: map[string]bool{"validator": true, "third": true},
+	})
+	require.NoError(t, err)
+
+	// loading the dictionary from the database again
+	database.dictionaries = map[string]*ValidatorsDictionary{}
+
+	var legacyCount int
+	require.NoError(t, database.client.QueryRow("SELECT COUNT(*) FROM blocks WHERE bitmap IS NULL").Scan(&legacyCount))
+	require.Zero(t, legacyCount)
+
+	blocks, err := database.GetAllBlocks("chain")
+	require.NoError(t, err)
+	require.Equal(t, map[int64]*types.Block{
+		123: {
+			Height:     123,
+			Time:       blockTime,
+			Proposer:   "proposer",
+			Signatures: map[string]int32{"validator": 2, "another": 3},
+			Validators: map[string]bool{"validator": true, "another": true},
+		},
+		124: {
+			Height:     124,
+			Time:       blockTime,
+			Proposer:   "proposer",
+			Signatures: map[string]int32{"validator": 1, "third": 2},
+			Validators: map[string]bool{"validator": true, "third": true},
+		},
+	}, blocks)
 }
 
 func TestDatabaseInitSqlite(t *testing.T) {
@@ -584,3 +1037,128 @@ func TestDatabaseGetEventsByValidatorOk(t *testing.T) {
 	_, err := database.FindLastEventsByValidator("chain", "validator")
 	require.NoError(t, err)
 }
+
+func TestDatabaseInsertMissedBlocksReplaces(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	require.NoError(t, database.InsertMissedBlocks("chain", &types.Block{
+		Height:     123,
+		Time:       time.Unix(123, 0),
+		Signatures: map[string]int32{},
+		Validators: map[string]bool{"validator": true},
+	}))
+
+	missedBlocks, err := database.FindLastMissedBlocks("chain", "validator", 10)
+	require.NoError(t, err)
+	require.Len(t, missedBlocks, 1)
+
+	// the block was refetched and the validator has actually signed it
+	require.NoError(t, database.InsertMissedBlocks("chain", &types.Block{
+		Height:     123,
+		Time:       time.Unix(123, 0),
+		Signatures: map[string]int32{"validator": 2},
+		Validators: map[string]bool{"validator": true},
+	}))
+
+	missedBlocks, err = database.FindLastMissedBlocks("chain", "validator", 10)
+	require.NoError(t, err)
+	require.Empty(t, missedBlocks)
+}
+
+func TestDatabaseInsertMissedBlocksFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(&StubDatabaseClient{ExecError: errors.New("custom error")})
+
+	err := database.InsertMissedBlocks("chain", &types.Block{
+		Height:     123,
+		Signatures: map[string]int32{"validator": 1},
+		Validators: map[string]bool{"validator": true},
+	})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseFindLastMissedBlocksFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT height, time FROM missed_blocks").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.FindLastMissedBlocks("chain", "validator", 10)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseFindLastMissedBlocksFailToScan(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT height, time FROM missed_blocks").
+		WillReturnRows(sqlmock.NewRows([]string{"height", "time"}).AddRow("invalid", "invalid"))
+
+	_, err := database.FindLastMissedBlocks("chain", "validator", 10)
+	require.Error(t, err)
+}
+
+func TestDatabaseMissedBlocksSqlite(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	blockTime := time.Now().Round(time.Second)
+
+	for height := int64(1); height <= 5; height++ {
+		err := database.InsertMissedBlocks("chain", &types.Block{
+			Height:     height,
+			Time:       blockTime.Add(time.Duration(height) * time.Second),
+			Signatures: map[string]int32{"validator": 1, "another": 2},
+			Validators: map[string]bool{"validator": true, "another": true, "third": true},
+		})
+		require.NoError(t, err)
+	}
+
+	missedBlocks, err := database.FindLastMissedBlocks("chain", "validator", 3)
+	require.NoError(t, err)
+	require.Equal(t, []types.MissedBlock{
+		{Height: 5, Time: blockTime.Add(5 * time.Second)},
+		{Height: 4, Time: blockTime.Add(4 * time.Second)},
+		{Height: 3, Time: blockTime.Add(3 * time.Second)},
+	}, missedBlocks)
+
+	missedBlocks, err = database.FindLastMissedBlocks("chain", "another", 3)
+	require.NoError(t, err)
+	require.Empty(t, missedBlocks)
+
+	require.NoError(t, database.TrimBlocksBefore("chain", 4))
+
+	missedBlocks, err = database.FindLastMissedBlocks("chain", "third", 10)
+	require.NoError(t, err)
+	require.Len(t, missedBlocks, 1)
+	require.Equal(t, int64(5), missedBlocks[0].Height)
+}
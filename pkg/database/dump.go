@@ -0,0 +1,248 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+	"time"
+)
+
+// ChainDump holds everything stored for a chain that cannot be fetched from the chain again,
+// so it can be moved to another database, even of another type.
+type ChainDump struct {
+	Chain     string            `json:"chain"`
+	Notifiers types.Notifiers   `json:"notifiers"`
+	Events    []DumpedEvent     `json:"events"`
+	Data      map[string]string `json:"data"`
+}
+
+type DumpedEvent struct {
+	Event     constants.EventName `json:"event"`
+	Height    int64               `json:"height"`
+	Validator string              `json:"validator"`
+	Payload   json.RawMessage     `json:"payload"`
+	Time      time.Time           `json:"time"`
+}
+
+func (d *Database) ExportChain(chain string) (*ChainDump, error) {
+	notifiers, err := d.GetAllNotifiers(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := d.GetAllEvents(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := d.GetAllValues(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChainDump{
+		Chain:     chain,
+		Notifiers: *notifiers,
+		Events:    events,
+		Data:      data,
+	}, nil
+}
+
+// ImportChain writes the dump to the chain. Notifiers are added to the existing ones,
+// data values are overwritten and events are replaced, so importing the same dump
+// twice does not duplicate them. It's done in a transaction, so nothing is changed
+// if the import fails.
+func (d *Database) ImportChain(chain string, dump *ChainDump) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	tx, err := d.client.Begin()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not start import transaction")
+		return err
+	}
+
+	if err := d.importChain(tx, chain, dump); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			d.logger.Error().Err(rollbackErr).Msg("Could not roll back import transaction")
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *Database) importChain(executor Executor, chain string, dump *ChainDump) error {
+	for _, notifier := range dump.Notifiers {
+		if err := d.insertNotifier(
+			executor,
+			chain,
+			notifier.OperatorAddress,
+			notifier.Reporter,
+			notifier.UserID,
+			notifier.UserName,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := d.deleteAllEvents(executor, chain); err != nil {
+		return err
+	}
+
+	for _, event := range dump.Events {
+		if err := d.insertDumpedEvent(executor, chain, event); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range dump.Data {
+		if err := d.setValueByKey(executor, chain, key, []byte(value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Database) GetAllEvents(chain string) ([]DumpedEvent, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	events := []DumpedEvent{}
+
+	rows, err := d.client.Query(
+		"SELECT event, height, validator, payload, time FROM events WHERE chain = $1 ORDER BY id",
+		chain,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting all events")
+		return events, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			event     DumpedEvent
+			payload   []byte
+			eventTime any
+		)
+
+		if err := rows.Scan(&event.Event, &event.Height, &event.Validator, &payload, &eventTime); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching event data")
+			return events, err
+		}
+
+		event.Payload = payload
+		if event.Time, err = parseEventTime(eventTime); err != nil {
+			d.logger.Error().Err(err).Msg("Error parsing event time")
+			return events, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// parseEventTime handles event times stored as timestamps by postgres
+// and as text by sqlite.
+func parseEventTime(value any) (time.Time, error) {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed, nil
+	case string:
+		return parseEventTimeString(typed)
+	case []byte:
+		return parseEventTimeString(string(typed))
+	default:
+		return time.Time{}, fmt.Errorf("unsupported event time type: %T", value)
+	}
+}
+
+func parseEventTimeString(value string) (time.Time, error) {
+	for _, layout := range []string{
+		"2006-01-02 15:04:05.999999999-07:00",
+		time.RFC3339Nano,
+		time.DateTime,
+	} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("could not parse event time: %s", value)
+}
+
+func (d *Database) deleteAllEvents(executor Executor, chain string) error {
+	_, err := executor.Exec("DELETE FROM events WHERE chain = $1", chain)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error deleting events")
+		return err
+	}
+
+	return nil
+}
+
+func (d *Database) InsertDumpedEvent(chain string, event DumpedEvent) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	return d.insertDumpedEvent(d.client, chain, event)
+}
+
+func (d *Database) insertDumpedEvent(executor Executor, chain string, event DumpedEvent) error {
+	_, err := executor.Exec(
+		"INSERT INTO events (chain, event, height, validator, payload, time) VALUES ($1, $2, $3, $4, $5, $6)",
+		chain,
+		event.Event,
+		event.Height,
+		event.Validator,
+		[]byte(event.Payload),
+		event.Time,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error saving event")
+		return err
+	}
+
+	return nil
+}
+
+func (d *Database) GetAllValues(chain string) (map[string]string, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	values := map[string]string{}
+
+	rows, err := d.client.Query("SELECT key, value FROM data WHERE chain = $1", chain)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting all values")
+		return values, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			key   string
+			value []byte
+		)
+
+		if err := rows.Scan(&key, &value); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching value")
+			return values, err
+		}
+
+		values[key] = string(value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseExportChainNotifiersFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT operator_address, reporter, user_id, user_name FROM notifiers").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.ExportChain("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseExportChainEventsFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT operator_address, reporter, user_id, user_name FROM notifiers").
+		WillReturnRows(sqlmock.NewRows([]string{"operator_address", "reporter", "user_id", "user_name"}))
+	client.Mock.
+		ExpectQuery("SELECT event, height, validator, payload, time FROM events").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.ExportChain("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseExportChainEventsInvalidTime(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT operator_address, reporter, user_id, user_name FROM notifiers").
+		WillReturnRows(sqlmock.NewRows([]string{"operator_address", "reporter", "user_id", "user_name"}))
+	client.Mock.
+		ExpectQuery("SELECT event, height, validator, payload, time FROM events").
+		WillReturnRows(sqlmock.NewRows([]string{"event", "height", "validator", "payload", "time"}).
+			AddRow(constants.EventValidatorJailed, 123, "validator", "{}", "invalid"))
+
+	_, err := database.ExportChain("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "could not parse event time")
+}
+
+func TestDatabaseExportChainDataFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT operator_address, reporter, user_id, user_name FROM notifiers").
+		WillReturnRows(sqlmock.NewRows([]string{"operator_address", "reporter", "user_id", "user_name"}))
+	client.Mock.
+		ExpectQuery("SELECT event, height, validator, payload, time FROM events").
+		WillReturnRows(sqlmock.NewRows([]string{"event", "height", "validator", "payload", "time"}))
+	client.Mock.
+		ExpectQuery("SELECT key, value FROM data").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.ExportChain("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseImportChainFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(&StubDatabaseClient{ExecError: errors.New("custom error")})
+
+	err := database.ImportChain("chain", &ChainDump{})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseExportImportSqlite(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	source := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/source.sqlite",
+	})
+	source.Init()
+
+	eventTime := time.Date(2025, 1, 19, 11, 3, 0, 0, time.UTC)
+
+	require.NoError(t, source.InsertNotifier("chain", "validator", constants.TelegramReporterName, "id", "name"))
+	require.NoError(t, source.InsertNotifier("another", "validator", constants.TelegramReporterName, "id", "name"))
+	require.NoError(t, source.SetValueByKey("chain", "key", []byte(`{"value":1}`)))
+	require.NoError(t, source.InsertDumpedEvent("chain", DumpedEvent{
+		Event:     constants.EventValidatorJailed,
+		Height:    123,
+		Validator: "validator",
+		Payload:   []byte(`{"validator":{"OperatorAddress":"validator"}}`),
+		Time:      eventTime,
+	}))
+
+	dump, err := source.ExportChain("chain")
+	require.NoError(t, err)
+	require.Equal(t, types.Notifiers{{
+		OperatorAddress: "validator",
+		Reporter:        constants.TelegramReporterName,
+		UserID:          "id",
+		UserName:        "name",
+	}}, dump.Notifiers)
+	require.Len(t, dump.Events, 1)
+	require.True(t, eventTime.Equal(dump.Events[0].Time))
+	require.Equal(t, map[string]string{"key": `{"value":1}`}, dump.Data)
+
+	target := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/target.sqlite",
+	})
+	target.Init()
+
+	// importing twice should not duplicate anything
+	require.NoError(t, target.ImportChain("chain", dump))
+	require.NoError(t, target.ImportChain("chain", dump))
+
+	imported, err := target.ExportChain("chain")
+	require.NoError(t, err)
+	require.Equal(t, dump.Notifiers, imported.Notifiers)
+	require.Equal(t, dump.Data, imported.Data)
+	require.Len(t, imported.Events, 1)
+	require.Equal(t, dump.Events[0].Payload, imported.Events[0].Payload)
+	require.True(t, eventTime.Equal(imported.Events[0].Time))
+}
+
+func TestDatabaseImportChainRollback(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	event := DumpedEvent{
+		Event:     constants.EventValidatorJailed,
+		Height:    123,
+		Validator: "validator",
+		Payload:   []byte(`{"validator":{"OperatorAddress":"validator"}}`),
+		Time:      time.Date(2025, 1, 19, 11, 3, 0, 0, time.UTC),
+	}
+	require.NoError(t, database.InsertDumpedEvent("chain", event))
+
+	// the event has no payload, so inserting it fails after the stored events are deleted
+	err := database.ImportChain("chain", &ChainDump{
+		Events: []DumpedEvent{{Event: constants.EventValidatorJailed}},
+		Data:   map[string]string{"key": "value"},
+	})
+	require.Error(t, err)
+
+	dump, err := database.ExportChain("chain")
+	require.NoError(t, err)
+	require.Len(t, dump.Events, 1)
+	require.Empty(t, dump.Data)
+}
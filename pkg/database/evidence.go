@@ -0,0 +1,82 @@
+package database
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+	"time"
+)
+
+// InsertEvidence stores the double sign evidence and returns whether it was not stored before,
+// as the same evidence can be seen again if a block is processed twice.
+func (d *Database) InsertEvidence(chain string, evidence *types.Evidence) (bool, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	result, err := d.client.Exec(
+		"INSERT INTO evidence (chain, validator_address, operator_address, height, block_height, time) "+
+			"VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING",
+		chain,
+		evidence.ValidatorAddress,
+		evidence.OperatorAddress,
+		evidence.Height,
+		evidence.BlockHeight,
+		evidence.Time.Unix(),
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error saving evidence")
+		return false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting saved evidence count")
+		return false, err
+	}
+
+	return inserted > 0, nil
+}
+
+func (d *Database) FindLastEvidence(chain string) ([]*types.Evidence, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	evidence := []*types.Evidence{}
+
+	rows, err := d.client.Query(
+		"SELECT validator_address, operator_address, height, block_height, time FROM evidence "+
+			"WHERE chain = $1 ORDER BY height DESC LIMIT $2",
+		chain,
+		constants.LastEventsCount,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting evidence")
+		return evidence, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			evidenceItem types.Evidence
+			evidenceTime int64
+		)
+
+		if err := rows.Scan(
+			&evidenceItem.ValidatorAddress,
+			&evidenceItem.OperatorAddress,
+			&evidenceItem.Height,
+			&evidenceItem.BlockHeight,
+			&evidenceTime,
+		); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching evidence")
+			return evidence, err
+		}
+
+		evidenceItem.Time = time.Unix(evidenceTime, 0)
+		evidence = append(evidence, &evidenceItem)
+	}
+
+	return evidence, nil
+}
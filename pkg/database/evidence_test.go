@@ -0,0 +1,92 @@
+package database
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseInsertEvidenceFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	inserted, err := database.InsertEvidence("chain", &types.Evidence{Height: 100})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.False(t, inserted)
+}
+
+func TestDatabaseFindLastEvidenceFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT validator_address, operator_address, height, block_height, time FROM evidence").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.FindLastEvidence("chain")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseEvidenceSqlite(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	now := time.Now().Round(time.Second)
+	evidence := &types.Evidence{
+		Height:           100,
+		BlockHeight:      102,
+		ValidatorAddress: "ABCDEF",
+		OperatorAddress:  "cosmosvaloper1xxx",
+		Time:             now,
+	}
+
+	inserted, err := database.InsertEvidence("chain", evidence)
+	require.NoError(t, err)
+	require.True(t, inserted)
+
+	inserted, err = database.InsertEvidence("chain", evidence)
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	inserted, err = database.InsertEvidence("chain", &types.Evidence{
+		Height:           200,
+		BlockHeight:      201,
+		ValidatorAddress: "ABCDEF",
+		Time:             now,
+	})
+	require.NoError(t, err)
+	require.True(t, inserted)
+
+	inserted, err = database.InsertEvidence("other", evidence)
+	require.NoError(t, err)
+	require.True(t, inserted)
+
+	found, err := database.FindLastEvidence("chain")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, int64(200), found[0].Height)
+	require.Equal(t, evidence, found[1])
+}
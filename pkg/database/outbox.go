@@ -0,0 +1,137 @@
+package database
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+	"time"
+)
+
+func (d *Database) InsertOutboxReport(
+	chain string,
+	reporter constants.ReporterName,
+	height int64,
+	message string,
+	createdAt time.Time,
+) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	_, err := d.client.Exec(
+		"INSERT INTO reports_outbox "+
+			"(chain, reporter, height, message, status, attempts, next_attempt_at, last_error, created_at) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		chain,
+		reporter,
+		height,
+		message,
+		constants.OutboxStatusPending,
+		0,
+		createdAt.Unix(),
+		"",
+		createdAt.Unix(),
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error saving report to outbox")
+		return err
+	}
+
+	return nil
+}
+
+// GetPendingOutboxReports returns reports which are not delivered yet, oldest first.
+func (d *Database) GetPendingOutboxReports(chain string, limit int) ([]*types.OutboxReport, error) {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	reports := []*types.OutboxReport{}
+
+	rows, err := d.client.Query(
+		"SELECT id, reporter, height, message, status, attempts, sent_chunks, next_attempt_at, last_error, created_at "+
+			"FROM reports_outbox WHERE chain = $1 AND status = $2 ORDER BY id LIMIT $3",
+		chain,
+		constants.OutboxStatusPending,
+		limit,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error getting outbox reports")
+		return reports, err
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+
+	for rows.Next() {
+		var (
+			report        types.OutboxReport
+			nextAttemptAt int64
+			createdAt     int64
+		)
+
+		if err := rows.Scan(
+			&report.ID,
+			&report.Reporter,
+			&report.Height,
+			&report.Message,
+			&report.Status,
+			&report.Attempts,
+			&report.SentChunks,
+			&nextAttemptAt,
+			&report.LastError,
+			&createdAt,
+		); err != nil {
+			d.logger.Error().Err(err).Msg("Error fetching outbox report")
+			return reports, err
+		}
+
+		report.Chain = chain
+		report.NextAttemptAt = time.Unix(nextAttemptAt, 0)
+		report.CreatedAt = time.Unix(createdAt, 0)
+		reports = append(reports, &report)
+	}
+
+	return reports, nil
+}
+
+// UpdateOutboxReport stores the delivery status of a report.
+func (d *Database) UpdateOutboxReport(report *types.OutboxReport) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	_, err := d.client.Exec(
+		"UPDATE reports_outbox SET status = $1, attempts = $2, sent_chunks = $3, next_attempt_at = $4, last_error = $5 "+
+			"WHERE id = $6",
+		report.Status,
+		report.Attempts,
+		report.SentChunks,
+		report.NextAttemptAt.Unix(),
+		report.LastError,
+		report.ID,
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error updating outbox report")
+		return err
+	}
+
+	return nil
+}
+
+// TrimOutboxReportsBefore removes delivered and failed reports created before the time,
+// pending ones are kept until they are delivered.
+func (d *Database) TrimOutboxReportsBefore(chain string, before time.Time) error {
+	d.MaybeMutexLock()
+	defer d.MaybeMutexUnlock()
+
+	_, err := d.client.Exec(
+		"DELETE FROM reports_outbox WHERE chain = $1 AND status != $2 AND created_at < $3",
+		chain,
+		constants.OutboxStatusPending,
+		before.Unix(),
+	)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Error trimming outbox reports")
+		return err
+	}
+
+	return nil
+}
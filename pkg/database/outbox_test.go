@@ -0,0 +1,123 @@
+package database
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseInsertOutboxReportFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	err := database.InsertOutboxReport("chain", constants.TelegramReporterName, 123, "message", time.Now())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseGetPendingOutboxReportsFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	client.Mock.
+		ExpectQuery("SELECT id, reporter, height, message, status, attempts, sent_chunks, next_attempt_at, last_error, created_at FROM reports_outbox").
+		WillReturnError(errors.New("custom error"))
+
+	_, err := database.GetPendingOutboxReports("chain", 10)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseUpdateOutboxReportFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	report := &types.OutboxReport{ID: 1, Status: constants.OutboxStatusSent}
+	err := database.UpdateOutboxReport(report)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseTrimOutboxReportsFail(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	client := NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	err := database.TrimOutboxReportsBefore("chain", time.Now())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestDatabaseOutboxReportsSqlite(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	now := time.Now().Round(time.Second)
+
+	require.NoError(t, database.InsertOutboxReport("chain", constants.TelegramReporterName, 1, "first", now.Add(-time.Hour)))
+	require.NoError(t, database.InsertOutboxReport("chain", constants.DiscordReporterName, 2, "second", now))
+	require.NoError(t, database.InsertOutboxReport("other", constants.TelegramReporterName, 3, "other", now))
+
+	reports, err := database.GetPendingOutboxReports("chain", 10)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, "first", reports[0].Message)
+	require.Equal(t, constants.TelegramReporterName, reports[0].Reporter)
+	require.Equal(t, int64(1), reports[0].Height)
+	require.Equal(t, constants.OutboxStatusPending, reports[0].Status)
+	require.Equal(t, "second", reports[1].Message)
+
+	reports[0].Status = constants.OutboxStatusSent
+	reports[0].Attempts = 1
+	require.NoError(t, database.UpdateOutboxReport(reports[0]))
+
+	reports[1].Attempts = 1
+	reports[1].SentChunks = 2
+	reports[1].LastError = "custom error"
+	reports[1].NextAttemptAt = now.Add(time.Minute)
+	require.NoError(t, database.UpdateOutboxReport(reports[1]))
+
+	reports, err = database.GetPendingOutboxReports("chain", 10)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, now.Add(time.Minute), reports[0].NextAttemptAt)
+	require.Equal(t, "custom error", reports[0].LastError)
+	require.Equal(t, int64(1), reports[0].Attempts)
+	require.Equal(t, int64(2), reports[0].SentChunks)
+
+	require.NoError(t, database.TrimOutboxReportsBefore("chain", now))
+
+	var count int
+	require.NoError(t, database.client.QueryRow("SELECT COUNT(*) FROM reports_outbox").Scan(&count))
+	require.Equal(t, 2, count)
+}
@@ -47,6 +47,10 @@ func (d *PostgresDatabaseClient) QueryRow(query string, args ...any) *sql.Row {
 	return d.db.QueryRow(query, args...)
 }
 
+func (d *PostgresDatabaseClient) Begin() (*sql.Tx, error) {
+	return d.db.Begin()
+}
+
 func (d *PostgresDatabaseClient) Migrate() error {
 	goose.SetBaseFS(postgresMigrations.EmbedFS)
 	goose.SetLogger(d.logger)
@@ -46,6 +46,10 @@ func (d *SqliteDatabaseClient) QueryRow(query string, args ...any) *sql.Row {
 	return d.db.QueryRow(query, args...)
 }
 
+func (d *SqliteDatabaseClient) Begin() (*sql.Tx, error) {
+	return d.db.Begin()
+}
+
 func (d *SqliteDatabaseClient) Migrate() error {
 	goose.SetBaseFS(sqliteMigrations.EmbedFS)
 	goose.SetLogger(d.logger)
@@ -40,6 +40,23 @@ func (d *StubDatabaseClient) QueryRow(query string, args ...any) *sql.Row {
 	return d.Client.QueryRow(query, args...)
 }
 
+// Begin fails with ExecError if it's set, as the transaction would fail to write anyway.
+func (d *StubDatabaseClient) Begin() (*sql.Tx, error) {
+	if d.ExecError != nil {
+		return nil, d.ExecError
+	}
+
+	return d.Client.Begin()
+}
+
 func (d *StubDatabaseClient) Migrate() error {
 	return d.MigrateError
 }
+
+// ExpectEmptyValidatorsDictionary mocks loading an empty validators dictionary,
+// which is done on the first block insertion.
+func (d *StubDatabaseClient) ExpectEmptyValidatorsDictionary() {
+	d.Mock.
+		ExpectQuery("SELECT id, address FROM validators_dictionary").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "address"}))
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"fmt"
+	"main/pkg/types"
+)
+
+// Blocks are stored as bitmaps with a 4 bits entry per validator, validators are referenced
+// by their index in a per-chain dictionary of consensus addresses.
+// Lower 2 bits of an entry are the signature's block ID flag, the other bits tell
+// whether a validator has a signature in this block and whether it is in the active set.
+const (
+	BitmapFlagMask      byte = 0b0011
+	BitmapHasSignature  byte = 0b0100
+	BitmapInActiveSet   byte = 0b1000
+	BitmapEntriesInByte      = 2
+)
+
+type ValidatorsDictionary struct {
+	IDs       map[string]int
+	Addresses []string
+}
+
+func NewValidatorsDictionary() *ValidatorsDictionary {
+	return &ValidatorsDictionary{
+		IDs:       map[string]int{},
+		Addresses: []string{},
+	}
+}
+
+func (d *ValidatorsDictionary) Set(id int, address string) {
+	for len(d.Addresses) <= id {
+		d.Addresses = append(d.Addresses, "")
+	}
+
+	d.Addresses[id] = address
+	d.IDs[address] = id
+}
+
+// GetNewAddresses returns the addresses from this block that are not in the dictionary yet,
+// along with the IDs they should get.
+func (d *ValidatorsDictionary) GetNewAddresses(block *types.Block) map[string]int {
+	newAddresses := map[string]int{}
+
+	addNew := func(address string) {
+		if _, ok := d.IDs[address]; ok {
+			return
+		}
+
+		if _, ok := newAddresses[address]; ok {
+			return
+		}
+
+		newAddresses[address] = len(d.Addresses) + len(newAddresses)
+	}
+
+	for address := range block.Signatures {
+		addNew(address)
+	}
+
+	for address := range block.Validators {
+		addNew(address)
+	}
+
+	return newAddresses
+}
+
+func (d *ValidatorsDictionary) Encode(block *types.Block) ([]byte, error) {
+	entries := map[int]byte{}
+	maxID := -1
+
+	setEntry := func(address string, value byte) error {
+		id, ok := d.IDs[address]
+		if !ok {
+			return fmt.Errorf("validator %s is not in the dictionary", address)
+		}
+
+		entries[id] |= value
+		maxID = max(maxID, id)
+		return nil
+	}
+
+	for address, flag := range block.Signatures {
+		if flag < 0 || byte(flag) > BitmapFlagMask {
+			return nil, fmt.Errorf("unsupported block ID flag %d for validator %s", flag, address)
+		}
+
+		if err := setEntry(address, BitmapHasSignature|byte(flag)); err != nil {
+			return nil, err
+		}
+	}
+
+	for address := range block.Validators {
+		if err := setEntry(address, BitmapInActiveSet); err != nil {
+			return nil, err
+		}
+	}
+
+	// always having at least 1 byte, so a block without signatures is not stored as NULL
+	bitmap := make([]byte, max(1, (maxID+BitmapEntriesInByte)/BitmapEntriesInByte))
+	for id, entry := range entries {
+		bitmap[id/BitmapEntriesInByte] |= entry << (4 * (id % BitmapEntriesInByte))
+	}
+
+	return bitmap, nil
+}
+
+func (d *ValidatorsDictionary) Decode(bitmap []byte) (map[string]int32, map[string]bool, error) {
+	signatures := map[string]int32{}
+	validators := map[string]bool{}
+
+	for index, value := range bitmap {
+		for shift := range BitmapEntriesInByte {
+			entry := (value >> (4 * shift)) & 0b1111
+			if entry == 0 {
+				continue
+			}
+
+			id := index*BitmapEntriesInByte + shift
+			if id >= len(d.Addresses) {
+				return nil, nil, fmt.Errorf("validator with id %d is not in the dictionary", id)
+			}
+
+			address := d.Addresses[id]
+
+			if entry&BitmapHasSignature != 0 {
+				signatures[address] = int32(entry & BitmapFlagMask)
+			}
+
+			if entry&BitmapInActiveSet != 0 {
+				validators[address] = true
+			}
+		}
+	}
+
+	return signatures, validators, nil
+}
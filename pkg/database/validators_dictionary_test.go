@@ -0,0 +1,84 @@
+package database
+
+import (
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorsDictionaryEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	dictionary := NewValidatorsDictionary()
+	dictionary.Set(0, "existing")
+
+	block := &types.Block{
+		Signatures: map[string]int32{"existing": 2, "absent": 1, "nil": 3, "not-in-set": 2},
+		Validators: map[string]bool{"existing": true, "absent": true, "nil": true, "no-signature": true},
+	}
+
+	newAddresses := dictionary.GetNewAddresses(block)
+	require.Len(t, newAddresses, 4)
+	require.NotContains(t, newAddresses, "existing")
+
+	for address, id := range newAddresses {
+		require.Positive(t, id)
+		dictionary.Set(id, address)
+	}
+
+	bitmap, err := dictionary.Encode(block)
+	require.NoError(t, err)
+	require.Len(t, bitmap, 3)
+
+	signatures, validators, err := dictionary.Decode(bitmap)
+	require.NoError(t, err)
+	require.Equal(t, block.Signatures, signatures)
+	require.Equal(t, block.Validators, validators)
+}
+
+func TestValidatorsDictionaryEncodeEmpty(t *testing.T) {
+	t.Parallel()
+
+	dictionary := NewValidatorsDictionary()
+
+	bitmap, err := dictionary.Encode(&types.Block{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0}, bitmap)
+
+	signatures, validators, err := dictionary.Decode(bitmap)
+	require.NoError(t, err)
+	require.Empty(t, signatures)
+	require.Empty(t, validators)
+}
+
+func TestValidatorsDictionaryEncodeUnknownValidator(t *testing.T) {
+	t.Parallel()
+
+	dictionary := NewValidatorsDictionary()
+
+	_, err := dictionary.Encode(&types.Block{Validators: map[string]bool{"validator": true}})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "validator validator is not in the dictionary")
+}
+
+func TestValidatorsDictionaryEncodeUnsupportedFlag(t *testing.T) {
+	t.Parallel()
+
+	dictionary := NewValidatorsDictionary()
+	dictionary.Set(0, "validator")
+
+	_, err := dictionary.Encode(&types.Block{Signatures: map[string]int32{"validator": 4}})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "unsupported block ID flag 4")
+}
+
+func TestValidatorsDictionaryDecodeUnknownValidator(t *testing.T) {
+	t.Parallel()
+
+	dictionary := NewValidatorsDictionary()
+
+	_, _, err := dictionary.Decode([]byte{0b1110})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "validator with id 0 is not in the dictionary")
+}
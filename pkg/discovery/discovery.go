@@ -0,0 +1,112 @@
+package discovery
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/fs"
+
+	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
+	"github.com/rs/zerolog"
+	"gopkg.in/guregu/null.v4"
+)
+
+type DataManager interface {
+	GetConsumerChains() ([]*providerTypes.Chain, error)
+}
+
+// Discovery finds consumer chains launched on a provider chain and builds their configs
+// from the consumers mapping, so they can be tracked without setting them up in the config.
+type Discovery struct {
+	logger      zerolog.Logger
+	config      *configPkg.ChainConfig
+	filesystem  fs.FS
+	dataManager DataManager
+}
+
+func NewDiscovery(
+	logger zerolog.Logger,
+	config *configPkg.ChainConfig,
+	filesystem fs.FS,
+	dataManager DataManager,
+) *Discovery {
+	return &Discovery{
+		logger:      logger.With().Str("component", "consumers_discovery").Logger(),
+		config:      config,
+		filesystem:  filesystem,
+		dataManager: dataManager,
+	}
+}
+
+// Discover returns configs of launched consumer chains matching consumer-ids which are
+// present in the consumers mapping. The mapping is read each time, so changes to it
+// are picked up without a restart.
+func (d *Discovery) Discover() ([]*configPkg.ChainConfig, error) {
+	mapping, err := configPkg.GetConsumersMapping(d.config.ConsumersMappingPath, d.filesystem)
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not load consumers mapping")
+		return nil, err
+	}
+
+	consumerChains, err := d.dataManager.GetConsumerChains()
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Could not get consumer chains")
+		return nil, err
+	}
+
+	consumerConfigs := make([]*configPkg.ChainConfig, 0)
+
+	for _, consumerChain := range consumerChains {
+		if !d.config.IsConsumerIncluded(consumerChain.ConsumerId, consumerChain.ChainId) {
+			continue
+		}
+
+		mapped, found := mapping.FindByChainID(consumerChain.ChainId)
+		if !found {
+			d.logger.Debug().
+				Str("consumer_id", consumerChain.ConsumerId).
+				Str("chain_id", consumerChain.ChainId).
+				Msg("Consumer chain is not in consumers mapping, skipping")
+			continue
+		}
+
+		consumerConfig := d.GetConsumerConfig(consumerChain, mapped)
+		if err := consumerConfig.Validate(); err != nil {
+			d.logger.Warn().
+				Err(err).
+				Str("consumer_id", consumerChain.ConsumerId).
+				Str("chain_id", consumerChain.ChainId).
+				Msg("Consumer chain config is invalid, skipping")
+			continue
+		}
+
+		consumerConfig.RecalculateMissedBlocksGroups()
+		consumerConfigs = append(consumerConfigs, consumerConfig)
+	}
+
+	return consumerConfigs, nil
+}
+
+// GetConsumerConfig builds a consumer chain config from its consumers mapping entry,
+// taking consumer ID from the provider chain and provider endpoints from the provider chain config.
+func (d *Discovery) GetConsumerConfig(
+	consumerChain *providerTypes.Chain,
+	mapped *configPkg.ChainConfig,
+) *configPkg.ChainConfig {
+	consumerConfig := *mapped
+
+	if consumerConfig.Name == "" {
+		consumerConfig.Name = consumerChain.ChainId
+	}
+
+	if consumerConfig.PrettyName == "" {
+		consumerConfig.PrettyName = consumerChain.Metadata.Name
+	}
+
+	consumerConfig.IsConsumer = null.BoolFrom(true)
+	consumerConfig.ConsumerID = consumerChain.ConsumerId
+	consumerConfig.ProviderRPCEndpoints = d.config.RPCEndpoints
+	consumerConfig.ProviderLCDEndpoints = d.config.LCDEndpoints
+	consumerConfig.ProviderGRPCEndpoints = d.config.GRPCEndpoints
+	consumerConfig.DiscoveredFrom = d.config.Name
+
+	return &consumerConfig
+}
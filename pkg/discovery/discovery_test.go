@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	"main/pkg/fs"
+	loggerPkg "main/pkg/logger"
+	"testing"
+
+	providerTypes "github.com/cosmos/interchain-security/v6/x/ccv/provider/types"
+	"github.com/stretchr/testify/require"
+)
+
+type TestFS struct{}
+
+func (filesystem *TestFS) ReadFile(name string) ([]byte, error) {
+	return assets.EmbedFS.ReadFile(name)
+}
+
+func (filesystem *TestFS) Create(path string) (fs.File, error) {
+	return nil, errors.New("not yet supported")
+}
+
+type TestDataManager struct {
+	chains []*providerTypes.Chain
+	err    error
+}
+
+func (m *TestDataManager) GetConsumerChains() ([]*providerTypes.Chain, error) {
+	return m.chains, m.err
+}
+
+func getTestProviderConfig() *configPkg.ChainConfig {
+	return &configPkg.ChainConfig{
+		Name:                 "cosmos",
+		RPCEndpoints:         []string{"https://rpc.cosmos.quokkastake.io"},
+		LCDEndpoints:         []string{"https://lcd.cosmos.quokkastake.io"},
+		ConsumerIDs:          []string{"*"},
+		ConsumersMappingPath: "consumers-mapping.toml",
+	}
+}
+
+func getTestConsumerChains() []*providerTypes.Chain {
+	return []*providerTypes.Chain{
+		{
+			ChainId:    "neutron-1",
+			ConsumerId: "0",
+			Metadata:   providerTypes.ConsumerMetadata{Name: "Neutron"},
+		},
+		{
+			ChainId:    "stride-1",
+			ConsumerId: "1",
+			Metadata:   providerTypes.ConsumerMetadata{Name: "Stride"},
+		},
+		{
+			ChainId:    "invalid-1",
+			ConsumerId: "2",
+			Metadata:   providerTypes.ConsumerMetadata{Name: "Invalid"},
+		},
+		{
+			ChainId:    "unknown-1",
+			ConsumerId: "3",
+			Metadata:   providerTypes.ConsumerMetadata{Name: "Unknown"},
+		},
+	}
+}
+
+func TestDiscoverMappingNotFound(t *testing.T) {
+	t.Parallel()
+
+	config := getTestProviderConfig()
+	config.ConsumersMappingPath = "nonexistent.toml"
+
+	discovery := NewDiscovery(
+		*loggerPkg.GetNopLogger(),
+		config,
+		&TestFS{},
+		&TestDataManager{chains: getTestConsumerChains()},
+	)
+
+	consumers, err := discovery.Discover()
+	require.Error(t, err)
+	require.Nil(t, consumers)
+}
+
+func TestDiscoverConsumerChainsFail(t *testing.T) {
+	t.Parallel()
+
+	discovery := NewDiscovery(
+		*loggerPkg.GetNopLogger(),
+		getTestProviderConfig(),
+		&TestFS{},
+		&TestDataManager{err: errors.New("custom error")},
+	)
+
+	consumers, err := discovery.Discover()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+	require.Nil(t, consumers)
+}
+
+func TestDiscoverAll(t *testing.T) {
+	t.Parallel()
+
+	discovery := NewDiscovery(
+		*loggerPkg.GetNopLogger(),
+		getTestProviderConfig(),
+		&TestFS{},
+		&TestDataManager{chains: getTestConsumerChains()},
+	)
+
+	consumers, err := discovery.Discover()
+	require.NoError(t, err)
+	require.Len(t, consumers, 2)
+
+	require.Equal(t, "neutron", consumers[0].Name)
+	require.Equal(t, "Neutron", consumers[0].GetName())
+	require.True(t, consumers[0].IsConsumer.Bool)
+	require.Equal(t, "0", consumers[0].ConsumerID)
+	require.Equal(t, []string{"https://rpc.cosmos.quokkastake.io"}, consumers[0].ProviderRPCEndpoints)
+	require.Equal(t, []string{"https://lcd.cosmos.quokkastake.io"}, consumers[0].ProviderLCDEndpoints)
+	require.Equal(t, "cosmos", consumers[0].DiscoveredFrom)
+	require.NotEmpty(t, consumers[0].MissedBlocksGroups)
+
+	require.Equal(t, "stride-1", consumers[1].Name)
+	require.Equal(t, "1", consumers[1].ConsumerID)
+}
+
+func TestDiscoverFiltered(t *testing.T) {
+	t.Parallel()
+
+	config := getTestProviderConfig()
+	config.ConsumerIDs = []string{"stride-1", "3"}
+
+	discovery := NewDiscovery(
+		*loggerPkg.GetNopLogger(),
+		config,
+		&TestFS{},
+		&TestDataManager{chains: getTestConsumerChains()},
+	)
+
+	consumers, err := discovery.Discover()
+	require.NoError(t, err)
+	require.Len(t, consumers, 1)
+	require.Equal(t, "stride-1", consumers[0].Name)
+}
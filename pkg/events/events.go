@@ -7,18 +7,26 @@ import (
 
 func MapEventTypesToEvent(eventName constants.EventName) types.ReportEvent {
 	eventsMap := map[constants.EventName]types.ReportEvent{
-		constants.EventValidatorTombstoned:        &ValidatorTombstoned{},
-		constants.EventValidatorJailed:            &ValidatorJailed{},
-		constants.EventValidatorUnjailed:          &ValidatorUnjailed{},
-		constants.EventValidatorInactive:          &ValidatorInactive{},
-		constants.EventValidatorActive:            &ValidatorActive{},
-		constants.EventValidatorLeftSignatory:     &ValidatorLeftSignatory{},
-		constants.EventValidatorJoinedSignatory:   &ValidatorJoinedSignatory{},
-		constants.EventValidatorChangedKey:        &ValidatorChangedKey{},
-		constants.EventValidatorChangedMoniker:    &ValidatorChangedMoniker{},
-		constants.EventValidatorChangedCommission: &ValidatorChangedCommission{},
-		constants.EventValidatorCreated:           &ValidatorCreated{},
-		constants.EventValidatorGroupChanged:      &ValidatorGroupChanged{},
+		constants.EventValidatorDoubleSigned:        &ValidatorDoubleSigned{},
+		constants.EventValidatorTombstoned:          &ValidatorTombstoned{},
+		constants.EventValidatorJailed:              &ValidatorJailed{},
+		constants.EventValidatorUnjailed:            &ValidatorUnjailed{},
+		constants.EventValidatorCanUnjail:           &ValidatorCanUnjail{},
+		constants.EventValidatorInactive:            &ValidatorInactive{},
+		constants.EventValidatorActive:              &ValidatorActive{},
+		constants.EventValidatorLeftSignatory:       &ValidatorLeftSignatory{},
+		constants.EventValidatorJoinedSignatory:     &ValidatorJoinedSignatory{},
+		constants.EventValidatorOptedIn:             &ValidatorOptedIn{},
+		constants.EventValidatorOptedOut:            &ValidatorOptedOut{},
+		constants.EventValidatorChangedKey:          &ValidatorChangedKey{},
+		constants.EventValidatorAssignedConsumerKey: &ValidatorAssignedConsumerKey{},
+		constants.EventValidatorChangedConsumerKey:  &ValidatorChangedConsumerKey{},
+		constants.EventValidatorRemovedConsumerKey:  &ValidatorRemovedConsumerKey{},
+		constants.EventValidatorChangedMoniker:      &ValidatorChangedMoniker{},
+		constants.EventValidatorChangedCommission:   &ValidatorChangedCommission{},
+		constants.EventSlashingParamsChanged:        &SlashingParamsChanged{},
+		constants.EventValidatorCreated:             &ValidatorCreated{},
+		constants.EventValidatorGroupChanged:        &ValidatorGroupChanged{},
 	}
 
 	return eventsMap[eventName]
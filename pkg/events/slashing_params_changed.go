@@ -0,0 +1,86 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"strings"
+)
+
+// SlashingParamsChanged is a chain-level event, it's not related to any validator.
+type SlashingParamsChanged struct {
+	OldParams types.SlashingParams
+	NewParams types.SlashingParams
+}
+
+func (e SlashingParamsChanged) Type() constants.EventName {
+	return constants.EventSlashingParamsChanged
+}
+
+func (e SlashingParamsChanged) GetValidator() *types.Validator {
+	return nil
+}
+
+func (e SlashingParamsChanged) GetChanges() []string {
+	changes := []string{}
+
+	if e.OldParams.SignedBlocksWindow != e.NewParams.SignedBlocksWindow {
+		changes = append(changes, fmt.Sprintf(
+			"signed blocks window: %d -> %d",
+			e.OldParams.SignedBlocksWindow,
+			e.NewParams.SignedBlocksWindow,
+		))
+	}
+
+	if e.OldParams.MinSignedPerWindow != e.NewParams.MinSignedPerWindow {
+		changes = append(changes, fmt.Sprintf(
+			"min signed per window: %.2f%% -> %.2f%%",
+			e.OldParams.MinSignedPerWindow*100,
+			e.NewParams.MinSignedPerWindow*100,
+		))
+	}
+
+	if e.OldParams.DowntimeJailDuration != e.NewParams.DowntimeJailDuration {
+		changes = append(changes, fmt.Sprintf(
+			"downtime jail duration: %s -> %s",
+			utils.FormatDuration(e.OldParams.DowntimeJailDuration),
+			utils.FormatDuration(e.NewParams.DowntimeJailDuration),
+		))
+	}
+
+	if e.OldParams.SlashFractionDowntime != e.NewParams.SlashFractionDowntime {
+		changes = append(changes, fmt.Sprintf(
+			"downtime slash fraction: %.2f%% -> %.2f%%",
+			e.OldParams.SlashFractionDowntime*100,
+			e.NewParams.SlashFractionDowntime*100,
+		))
+	}
+
+	if e.OldParams.SlashFractionDoubleSign != e.NewParams.SlashFractionDoubleSign {
+		changes = append(changes, fmt.Sprintf(
+			"double sign slash fraction: %.2f%% -> %.2f%%",
+			e.OldParams.SlashFractionDoubleSign*100,
+			e.NewParams.SlashFractionDoubleSign*100,
+		))
+	}
+
+	return changes
+}
+
+func (e SlashingParamsChanged) Render(formatType constants.FormatType, renderData types.ReportEventRenderData) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**⚙️ Slashing params have changed:** %s",
+			strings.Join(e.GetChanges(), ", "),
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>⚙️ Slashing params have changed:</strong> %s",
+			strings.Join(e.GetChanges(), ", "),
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
@@ -0,0 +1,94 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashingParamsChangedBase(t *testing.T) {
+	t.Parallel()
+
+	entry := events.SlashingParamsChanged{}
+
+	assert.Equal(t, constants.EventSlashingParamsChanged, entry.Type())
+	assert.Nil(t, entry.GetValidator())
+}
+
+func TestSlashingParamsChangedGetChanges(t *testing.T) {
+	t.Parallel()
+
+	entry := events.SlashingParamsChanged{
+		OldParams: types.SlashingParams{
+			SignedBlocksWindow:      10000,
+			MinSignedPerWindow:      0.05,
+			DowntimeJailDuration:    10 * time.Minute,
+			SlashFractionDowntime:   0.0001,
+			SlashFractionDoubleSign: 0.05,
+		},
+		NewParams: types.SlashingParams{
+			SignedBlocksWindow:      20000,
+			MinSignedPerWindow:      0.1,
+			DowntimeJailDuration:    time.Hour,
+			SlashFractionDowntime:   0.0002,
+			SlashFractionDoubleSign: 0.1,
+		},
+	}
+
+	assert.Equal(t, []string{
+		"signed blocks window: 10000 -> 20000",
+		"min signed per window: 5.00% -> 10.00%",
+		"downtime jail duration: 10 minutes -> 1 hour",
+		"downtime slash fraction: 0.01% -> 0.02%",
+		"double sign slash fraction: 5.00% -> 10.00%",
+	}, entry.GetChanges())
+}
+
+func TestSlashingParamsChangedFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := events.SlashingParamsChanged{
+		OldParams: types.SlashingParams{SignedBlocksWindow: 10000, MinSignedPerWindow: 0.05},
+		NewParams: types.SlashingParams{SignedBlocksWindow: 20000, MinSignedPerWindow: 0.05},
+	}
+	renderData := types.ReportEventRenderData{}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>⚙️ Slashing params have changed:</strong> signed blocks window: 10000 -> 20000",
+		rendered,
+	)
+}
+
+func TestSlashingParamsChangedFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := events.SlashingParamsChanged{
+		OldParams: types.SlashingParams{SignedBlocksWindow: 10000, MinSignedPerWindow: 0.05},
+		NewParams: types.SlashingParams{SignedBlocksWindow: 10000, MinSignedPerWindow: 0.1},
+	}
+	renderData := types.ReportEventRenderData{}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**⚙️ Slashing params have changed:** min signed per window: 5.00% -> 10.00%",
+		rendered,
+	)
+}
+
+func TestSlashingParamsChangedFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := events.SlashingParamsChanged{}
+	renderData := types.ReportEventRenderData{}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorAssignedConsumerKey struct {
+	Validator *types.Validator
+}
+
+func (e ValidatorAssignedConsumerKey) Type() constants.EventName {
+	return constants.EventValidatorAssignedConsumerKey
+}
+
+func (e ValidatorAssignedConsumerKey) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorAssignedConsumerKey) Render(
+	formatType constants.FormatType,
+	renderData types.ReportEventRenderData,
+) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**🔑 %s has assigned a consumer key** (provider `%s`, consumer `%s`), "+
+				"make sure the node is running with it %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>🔑 %s has assigned a consumer key</strong> (provider <code>%s</code>, consumer <code>%s</code>), "+
+				"make sure the node is running with it %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
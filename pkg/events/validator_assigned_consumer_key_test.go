@@ -0,0 +1,68 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestValidatorAssignedConsumerKeyEvent() events.ValidatorAssignedConsumerKey {
+	return events.ValidatorAssignedConsumerKey{
+		Validator: &types.Validator{
+			Moniker:                         "test",
+			ConsensusAddressValcons:         "consumer",
+			ProviderConsensusAddressValcons: "provider",
+		},
+	}
+}
+
+func TestValidatorAssignedConsumerKeyBase(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorAssignedConsumerKeyEvent()
+
+	assert.Equal(t, constants.EventValidatorAssignedConsumerKey, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorAssignedConsumerKeyFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorAssignedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>🔑 <link> has assigned a consumer key</strong> (provider <code>provider</code>, consumer <code>consumer</code>), make sure the node is running with it notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorAssignedConsumerKeyFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorAssignedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**🔑 <link> has assigned a consumer key** (provider `provider`, consumer `consumer`), make sure the node is running with it notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorAssignedConsumerKeyFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorAssignedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+	"time"
+)
+
+type ValidatorCanUnjail struct {
+	Validator   *types.Validator
+	JailedUntil time.Time
+}
+
+func (e ValidatorCanUnjail) Type() constants.EventName {
+	return constants.EventValidatorCanUnjail
+}
+
+func (e ValidatorCanUnjail) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorCanUnjail) Render(formatType constants.FormatType, renderData types.ReportEventRenderData) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**⏰ %s can unjail since %s UTC, but is still jailed** %s",
+			renderData.ValidatorLink,
+			e.JailedUntil.UTC().Format(time.DateTime),
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>⏰ %s can unjail since %s UTC, but is still jailed</strong> %s",
+			renderData.ValidatorLink,
+			e.JailedUntil.UTC().Format(time.DateTime),
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
@@ -0,0 +1,65 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorCanUnjailBase(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorCanUnjail{Validator: &types.Validator{Moniker: "test"}}
+
+	assert.Equal(t, constants.EventValidatorCanUnjail, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorCanUnjailFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorCanUnjail{
+		Validator:   &types.Validator{Moniker: "test"},
+		JailedUntil: time.Unix(1700000000, 0),
+	}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>⏰ <link> can unjail since 2023-11-14 22:13:20 UTC, but is still jailed</strong> notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorCanUnjailFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorCanUnjail{
+		Validator:   &types.Validator{Moniker: "test"},
+		JailedUntil: time.Unix(1700000000, 0),
+	}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**⏰ <link> can unjail since 2023-11-14 22:13:20 UTC, but is still jailed** notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorCanUnjailFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorCanUnjail{Validator: &types.Validator{Moniker: "test"}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorChangedConsumerKey struct {
+	Validator    *types.Validator
+	OldValidator *types.Validator
+}
+
+func (e ValidatorChangedConsumerKey) Type() constants.EventName {
+	return constants.EventValidatorChangedConsumerKey
+}
+
+func (e ValidatorChangedConsumerKey) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorChangedConsumerKey) Render(
+	formatType constants.FormatType,
+	renderData types.ReportEventRenderData,
+) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**🔑 %s has changed its consumer key** (provider `%s`, consumer `%s` → `%s`), "+
+				"make sure the node is running with the new one %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.OldValidator.ConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>🔑 %s has changed its consumer key</strong> "+
+				"(provider <code>%s</code>, consumer <code>%s</code> → <code>%s</code>), "+
+				"make sure the node is running with the new one %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.OldValidator.ConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
@@ -0,0 +1,71 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestValidatorChangedConsumerKeyEvent() events.ValidatorChangedConsumerKey {
+	return events.ValidatorChangedConsumerKey{
+		Validator: &types.Validator{
+			Moniker:                         "test",
+			ConsensusAddressValcons:         "new",
+			ProviderConsensusAddressValcons: "provider",
+		},
+		OldValidator: &types.Validator{
+			ConsensusAddressValcons: "old",
+		},
+	}
+}
+
+func TestValidatorChangedConsumerKeyBase(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorChangedConsumerKeyEvent()
+
+	assert.Equal(t, constants.EventValidatorChangedConsumerKey, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorChangedConsumerKeyFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorChangedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>🔑 <link> has changed its consumer key</strong> (provider <code>provider</code>, consumer <code>old</code> → <code>new</code>), make sure the node is running with the new one notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorChangedConsumerKeyFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorChangedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**🔑 <link> has changed its consumer key** (provider `provider`, consumer `old` → `new`), make sure the node is running with the new one notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorChangedConsumerKeyFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorChangedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorDoubleSigned struct {
+	Validator *types.Validator
+	Evidence  *types.Evidence
+}
+
+func (e ValidatorDoubleSigned) Type() constants.EventName {
+	return constants.EventValidatorDoubleSigned
+}
+
+func (e ValidatorDoubleSigned) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorDoubleSigned) Render(formatType constants.FormatType, renderData types.ReportEventRenderData) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**🚨 %s has double signed at height %d** (evidence included in block %d), "+
+				"make sure only one node is signing with its key %s",
+			renderData.ValidatorLink,
+			e.Evidence.Height,
+			e.Evidence.BlockHeight,
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>🚨 %s has double signed at height %d</strong> (evidence included in block %d), "+
+				"make sure only one node is signing with its key %s",
+			renderData.ValidatorLink,
+			e.Evidence.Height,
+			e.Evidence.BlockHeight,
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
@@ -0,0 +1,67 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestValidatorDoubleSignedEvent() events.ValidatorDoubleSigned {
+	return events.ValidatorDoubleSigned{
+		Validator: &types.Validator{Moniker: "test"},
+		Evidence:  &types.Evidence{Height: 100, BlockHeight: 102},
+	}
+}
+
+func TestValidatorDoubleSignedBase(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorDoubleSignedEvent()
+
+	assert.Equal(t, constants.EventValidatorDoubleSigned, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorDoubleSignedFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorDoubleSignedEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>🚨 <link> has double signed at height 100</strong> (evidence included in block 102), "+
+			"make sure only one node is signing with its key notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorDoubleSignedFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorDoubleSignedEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**🚨 <link> has double signed at height 100** (evidence included in block 102), "+
+			"make sure only one node is signing with its key notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorDoubleSignedFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorDoubleSignedEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
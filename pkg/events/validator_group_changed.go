@@ -14,6 +14,9 @@ type ValidatorGroupChanged struct {
 	MissedBlocksAfter       int64
 	MissedBlocksGroupBefore *configPkg.MissedBlocksGroup
 	MissedBlocksGroupAfter  *configPkg.MissedBlocksGroup
+	// Window is the name of the additional signing window the group has changed in,
+	// empty for the slashing window.
+	Window string
 }
 
 func (e ValidatorGroupChanged) Type() constants.EventName {
@@ -44,6 +47,19 @@ func (e ValidatorGroupChanged) IsIncreasing() bool {
 	return e.MissedBlocksGroupBefore.Start < e.MissedBlocksGroupAfter.Start
 }
 
+// GetWindow returns the name of the signing window the group has changed in.
+func (e ValidatorGroupChanged) GetWindow() string {
+	if e.Window == "" {
+		return constants.SlashingWindowName
+	}
+
+	return e.Window
+}
+
+func (e ValidatorGroupChanged) IsSlashingWindow() bool {
+	return e.Window == ""
+}
+
 func (e ValidatorGroupChanged) GetValidator() *types.Validator {
 	return e.Validator
 }
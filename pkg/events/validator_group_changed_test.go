@@ -19,6 +19,18 @@ func TestValidatorGroupChangedBase(t *testing.T) {
 	assert.Equal(t, "test", entry.GetValidator().Moniker)
 }
 
+func TestValidatorGroupChangedWindow(t *testing.T) {
+	t.Parallel()
+
+	slashingEntry := events.ValidatorGroupChanged{Validator: &types.Validator{Moniker: "test"}}
+	assert.True(t, slashingEntry.IsSlashingWindow())
+	assert.Equal(t, constants.SlashingWindowName, slashingEntry.GetWindow())
+
+	windowEntry := events.ValidatorGroupChanged{Validator: &types.Validator{Moniker: "test"}, Window: "short"}
+	assert.False(t, windowEntry.IsSlashingWindow())
+	assert.Equal(t, "short", windowEntry.GetWindow())
+}
+
 func TestValidatorGetDescriptionAndEmojiIncreasing(t *testing.T) {
 	t.Parallel()
 
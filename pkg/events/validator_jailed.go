@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"main/pkg/constants"
 	"main/pkg/types"
+	"strings"
+	"time"
 )
 
 type ValidatorJailed struct {
 	Validator *types.Validator
+	// JailedUntil is the time after which the validator can unjail, zero if it's unknown.
+	JailedUntil time.Time
+	// SlashFraction is the share of the stake slashed for downtime, zero if it's unknown.
+	SlashFraction float64
 }
 
 func (e ValidatorJailed) Type() constants.EventName {
@@ -18,18 +24,38 @@ func (e ValidatorJailed) GetValidator() *types.Validator {
 	return e.Validator
 }
 
+func (e ValidatorJailed) GetDetails() string {
+	details := []string{}
+
+	if e.SlashFraction > 0 {
+		details = append(details, fmt.Sprintf("slashed %.2f%% of stake", e.SlashFraction*100))
+	}
+
+	if !e.JailedUntil.IsZero() {
+		details = append(details, "can unjail after "+e.JailedUntil.UTC().Format(time.DateTime)+" UTC")
+	}
+
+	if len(details) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(details, ", ") + ")"
+}
+
 func (e ValidatorJailed) Render(formatType constants.FormatType, renderData types.ReportEventRenderData) string {
 	switch formatType {
 	case constants.FormatTypeMarkdown:
 		return fmt.Sprintf(
-			"**❌ %s has been jailed** %s",
+			"**❌ %s has been jailed**%s %s",
 			renderData.ValidatorLink,
+			e.GetDetails(),
 			renderData.Notifiers,
 		)
 	case constants.FormatTypeHTML:
 		return fmt.Sprintf(
-			"<strong>❌ %s has been jailed</strong> %s",
+			"<strong>❌ %s has been jailed</strong>%s %s",
 			renderData.ValidatorLink,
+			e.GetDetails(),
 			renderData.Notifiers,
 		)
 	default:
@@ -5,6 +5,7 @@ import (
 	"main/pkg/events"
 	"main/pkg/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -56,3 +57,36 @@ func TestValidatorJailedFormatUnsupported(t *testing.T) {
 		rendered,
 	)
 }
+
+func TestValidatorJailedFormatHTMLWithDetails(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorJailed{
+		Validator:     &types.Validator{Moniker: "test"},
+		JailedUntil:   time.Unix(1700000000, 0),
+		SlashFraction: 0.0001,
+	}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>❌ <link> has been jailed</strong> (slashed 0.01% of stake, can unjail after 2023-11-14 22:13:20 UTC) notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorJailedFormatMarkdownWithDetails(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorJailed{
+		Validator:   &types.Validator{Moniker: "test"},
+		JailedUntil: time.Unix(1700000000, 0),
+	}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**❌ <link> has been jailed** (can unjail after 2023-11-14 22:13:20 UTC) notifier1 notifier2",
+		rendered,
+	)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorOptedIn struct {
+	Validator *types.Validator
+}
+
+func (e ValidatorOptedIn) Type() constants.EventName {
+	return constants.EventValidatorOptedIn
+}
+
+func (e ValidatorOptedIn) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorOptedIn) Render(formatType constants.FormatType, renderData types.ReportEventRenderData) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**✅ %s has opted in to validate the chain** (provider `%s`, consumer `%s`) %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>✅ %s has opted in to validate the chain</strong> (provider <code>%s</code>, consumer <code>%s</code>) %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
@@ -0,0 +1,74 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorOptedInBase(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedIn{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+
+	assert.Equal(t, constants.EventValidatorOptedIn, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorOptedInFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedIn{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>✅ <link> has opted in to validate the chain</strong> (provider <code>provider</code>, consumer <code>consumer</code>) notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorOptedInFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedIn{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**✅ <link> has opted in to validate the chain** (provider `provider`, consumer `consumer`) notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorOptedInFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedIn{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorOptedOut struct {
+	Validator *types.Validator
+}
+
+func (e ValidatorOptedOut) Type() constants.EventName {
+	return constants.EventValidatorOptedOut
+}
+
+func (e ValidatorOptedOut) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorOptedOut) Render(formatType constants.FormatType, renderData types.ReportEventRenderData) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**👋 %s has opted out of validating the chain** (provider `%s`, consumer `%s`) %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>👋 %s has opted out of validating the chain</strong> (provider <code>%s</code>, consumer <code>%s</code>) %s",
+			renderData.ValidatorLink,
+			e.Validator.ProviderConsensusAddressValcons,
+			e.Validator.ConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
@@ -0,0 +1,74 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatorOptedOutBase(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedOut{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+
+	assert.Equal(t, constants.EventValidatorOptedOut, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorOptedOutFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedOut{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>👋 <link> has opted out of validating the chain</strong> (provider <code>provider</code>, consumer <code>consumer</code>) notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorOptedOutFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedOut{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**👋 <link> has opted out of validating the chain** (provider `provider`, consumer `consumer`) notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorOptedOutFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := events.ValidatorOptedOut{Validator: &types.Validator{
+		Moniker:                         "test",
+		ConsensusAddressValcons:         "consumer",
+		ProviderConsensusAddressValcons: "provider",
+	}}
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
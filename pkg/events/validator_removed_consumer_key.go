@@ -0,0 +1,48 @@
+package events
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorRemovedConsumerKey struct {
+	Validator    *types.Validator
+	OldValidator *types.Validator
+}
+
+func (e ValidatorRemovedConsumerKey) Type() constants.EventName {
+	return constants.EventValidatorRemovedConsumerKey
+}
+
+func (e ValidatorRemovedConsumerKey) GetValidator() *types.Validator {
+	return e.Validator
+}
+
+func (e ValidatorRemovedConsumerKey) Render(
+	formatType constants.FormatType,
+	renderData types.ReportEventRenderData,
+) string {
+	switch formatType {
+	case constants.FormatTypeMarkdown:
+		return fmt.Sprintf(
+			"**🔑 %s has removed its consumer key** (was `%s`), "+
+				"make sure the node is running with the provider key `%s` %s",
+			renderData.ValidatorLink,
+			e.OldValidator.ConsensusAddressValcons,
+			e.Validator.ProviderConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	case constants.FormatTypeHTML:
+		return fmt.Sprintf(
+			"<strong>🔑 %s has removed its consumer key</strong> (was <code>%s</code>), "+
+				"make sure the node is running with the provider key <code>%s</code> %s",
+			renderData.ValidatorLink,
+			e.OldValidator.ConsensusAddressValcons,
+			e.Validator.ProviderConsensusAddressValcons,
+			renderData.Notifiers,
+		)
+	default:
+		return fmt.Sprintf("Unsupported format type: %s", formatType)
+	}
+}
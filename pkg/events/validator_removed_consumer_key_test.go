@@ -0,0 +1,71 @@
+package events_test
+
+import (
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestValidatorRemovedConsumerKeyEvent() events.ValidatorRemovedConsumerKey {
+	return events.ValidatorRemovedConsumerKey{
+		Validator: &types.Validator{
+			Moniker:                         "test",
+			ConsensusAddressValcons:         "provider",
+			ProviderConsensusAddressValcons: "provider",
+		},
+		OldValidator: &types.Validator{
+			ConsensusAddressValcons: "old",
+		},
+	}
+}
+
+func TestValidatorRemovedConsumerKeyBase(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorRemovedConsumerKeyEvent()
+
+	assert.Equal(t, constants.EventValidatorRemovedConsumerKey, entry.Type())
+	assert.Equal(t, "test", entry.GetValidator().Moniker)
+}
+
+func TestValidatorRemovedConsumerKeyFormatHTML(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorRemovedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeHTML, renderData)
+	assert.Equal(
+		t,
+		"<strong>🔑 <link> has removed its consumer key</strong> (was <code>old</code>), make sure the node is running with the provider key <code>provider</code> notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorRemovedConsumerKeyFormatMarkdown(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorRemovedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeMarkdown, renderData)
+	assert.Equal(
+		t,
+		"**🔑 <link> has removed its consumer key** (was `old`), make sure the node is running with the provider key `provider` notifier1 notifier2",
+		rendered,
+	)
+}
+
+func TestValidatorRemovedConsumerKeyFormatUnsupported(t *testing.T) {
+	t.Parallel()
+
+	entry := getTestValidatorRemovedConsumerKeyEvent()
+	renderData := types.ReportEventRenderData{Notifiers: "notifier1 notifier2", ValidatorLink: "<link>"}
+	rendered := entry.Render(constants.FormatTypeTest, renderData)
+	assert.Equal(
+		t,
+		"Unsupported format type: test",
+		rendered,
+	)
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/metrics"
+	"main/pkg/types"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	grpcTypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const QueryTimeout = 10 * time.Second
+
+type Client struct {
+	logger         zerolog.Logger
+	metricsManager *metrics.Manager
+	chainName      string
+	conn           *grpc.ClientConn
+	connError      error
+
+	Host string
+}
+
+func NewClient(
+	logger zerolog.Logger,
+	metricsManager *metrics.Manager,
+	host string,
+	chainName string,
+	tlsConfig configPkg.GRPCTLSConfig,
+) *Client {
+	client := &Client{
+		logger: logger.With().
+			Str("component", "grpc_client").
+			Str("host", host).
+			Logger(),
+		metricsManager: metricsManager,
+		chainName:      chainName,
+		Host:           host,
+	}
+
+	transportCredentials, err := GetTransportCredentials(tlsConfig)
+	if err != nil {
+		client.logger.Error().Err(err).Msg("Could not load gRPC TLS config")
+		client.connError = err
+		return client
+	}
+
+	interfaceRegistry := codecTypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	parseCodec := codec.NewProtoCodec(interfaceRegistry)
+
+	conn, err := grpc.NewClient(
+		host,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(parseCodec.GRPCCodec())),
+	)
+	if err != nil {
+		client.logger.Error().Err(err).Msg("Could not create gRPC client")
+		client.connError = err
+		return client
+	}
+
+	client.conn = conn
+	return client
+}
+
+func GetTransportCredentials(config configPkg.GRPCTLSConfig) (credentials.TransportCredentials, error) {
+	if !config.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify, //nolint:gosec // configurable by user
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if config.CAFile != "" {
+		caBytes, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caBytes) {
+			return nil, errors.New("could not parse CA certificate")
+		}
+
+		tlsConfig.RootCAs = certPool
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Query runs a gRPC query at the specified height (0 means the latest one),
+// logging the query metrics same way as the HTTP client does.
+func (c *Client) Query(
+	queryType constants.QueryType,
+	height int64,
+	query func(ctx context.Context, conn *grpc.ClientConn) error,
+) error {
+	if c.connError != nil {
+		return c.connError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), QueryTimeout)
+	defer cancel()
+
+	if height != 0 {
+		ctx = metadata.AppendToOutgoingContext(
+			ctx,
+			grpcTypes.GRPCBlockHeightHeader,
+			strconv.FormatInt(height, 10),
+		)
+	}
+
+	start := time.Now()
+
+	c.logger.Trace().
+		Str("type", string(queryType)).
+		Msg("Doing a query...")
+
+	err := query(ctx, c.conn)
+
+	c.metricsManager.LogQuery(c.chainName, types.QueryInfo{
+		Success:   err == nil,
+		Node:      c.Host,
+		QueryType: queryType,
+	})
+
+	if err != nil {
+		c.logger.Warn().Str("type", string(queryType)).Err(err).Msg("Query failed")
+		return err
+	}
+
+	c.logger.Debug().
+		Str("type", string(queryType)).
+		Dur("duration", time.Since(start)).
+		Msg("Query is finished")
+
+	return nil
+}
+
+func (c *Client) Stop() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close()
+}
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/metrics"
+	"main/pkg/pool"
+	"main/pkg/types"
+
+	"github.com/rs/zerolog"
+)
+
+type Pool = pool.Pool[*Client]
+type Node = pool.Node[*Client]
+
+// Pools holds gRPC endpoints pools for a chain, same as http.Pools does for RPC and LCD.
+type Pools struct {
+	GRPC         *Pool
+	ProviderGRPC *Pool
+}
+
+func NewPool(
+	logger zerolog.Logger,
+	metricsManager *metrics.Manager,
+	config *configPkg.ChainConfig,
+	poolName string,
+	hosts []string,
+) *Pool {
+	return pool.NewPool(logger, metricsManager, config.Name, poolName, hosts, func(host string) *Client {
+		return NewClient(logger, metricsManager, host, config.Name, config.GRPCTLSConfig)
+	})
+}
+
+func NewPools(
+	logger zerolog.Logger,
+	metricsManager *metrics.Manager,
+	config *configPkg.ChainConfig,
+) *Pools {
+	return &Pools{
+		GRPC:         NewPool(logger, metricsManager, config, constants.PoolGRPC, config.GRPCEndpoints),
+		ProviderGRPC: NewPool(logger, metricsManager, config, constants.PoolProviderGRPC, config.ProviderGRPCEndpoints),
+	}
+}
+
+// Stop closes the connections to all nodes, so they are not kept open once a chain is stopped.
+func (p *Pools) Stop() error {
+	var errs []error
+
+	for _, pool := range []*Pool{p.GRPC, p.ProviderGRPC} {
+		for _, node := range pool.GetNodes() {
+			if err := node.Client.Stop(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *Pools) GetStats() []types.NodeStats {
+	return append(p.GRPC.GetStats(), p.ProviderGRPC.GetStats()...)
+}
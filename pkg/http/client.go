@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"main/pkg/constants"
@@ -41,6 +42,16 @@ func (c *Client) GetInternal(
 	url string,
 	queryType constants.QueryType,
 	headers map[string]string,
+) (io.ReadCloser, error) {
+	return c.DoRequest(http.MethodGet, url, queryType, headers, nil)
+}
+
+func (c *Client) DoRequest(
+	method string,
+	url string,
+	queryType constants.QueryType,
+	headers map[string]string,
+	body io.Reader,
 ) (io.ReadCloser, error) {
 	var transport http.RoundTripper
 
@@ -66,7 +77,7 @@ func (c *Client) GetInternal(
 		QueryType: queryType,
 	}
 
-	req, err := http.NewRequest(http.MethodGet, fullURL, nil)
+	req, err := http.NewRequest(method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
@@ -136,3 +147,35 @@ func (c *Client) Get(
 
 	return body.Close()
 }
+
+func (c *Client) Post(
+	url string,
+	queryType constants.QueryType,
+	payload interface{},
+	target interface{},
+) error {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.DoRequest(
+		http.MethodPost,
+		url,
+		queryType,
+		map[string]string{"Content-Type": "application/json"},
+		bytes.NewReader(payloadBytes),
+	)
+	if err != nil {
+		return err
+	}
+
+	fullURL := c.Host + url
+
+	if jsonErr := json.NewDecoder(body).Decode(target); jsonErr != nil {
+		c.logger.Warn().Str("url", fullURL).Err(jsonErr).Msg("Error decoding JSON from response")
+		return jsonErr
+	}
+
+	return body.Close()
+}
@@ -81,3 +81,64 @@ func TestHttpClientErrorReading(t *testing.T) {
 	_, err := client.GetPlain("/", constants.QueryTypeBlock, map[string]string{})
 	require.Error(t, err)
 }
+
+func TestHttpClientPostMalformedPayload(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	client := NewClient(*logger, metricsManager, "http://example.com", "chain")
+
+	result := make(map[string]interface{})
+	err := client.Post("/", constants.QueryTypeCommits, make(chan int), &result)
+	require.Error(t, err)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestHttpClientPostMalformedJson(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	client := NewClient(*logger, metricsManager, "http://example.com", "chain")
+
+	httpmock.RegisterResponder(
+		"POST",
+		"http://example.com/",
+		httpmock.NewBytesResponder(200, []byte("invalid\"")),
+	)
+
+	result := make(map[string]interface{})
+	err := client.Post("/", constants.QueryTypeCommits, []string{}, &result)
+	require.Error(t, err)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestHttpClientPostOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	client := NewClient(*logger, metricsManager, "http://example.com", "chain")
+
+	httpmock.RegisterResponder(
+		"POST",
+		"http://example.com/",
+		func(request *http.Request) (*http.Response, error) {
+			require.Equal(t, "application/json", request.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"method":"commit"}`, string(body))
+
+			return httpmock.NewStringResponse(200, `{"result":"ok"}`), nil
+		},
+	)
+
+	result := make(map[string]interface{})
+	err := client.Post("/", constants.QueryTypeCommits, map[string]string{"method": "commit"}, &result)
+	require.NoError(t, err)
+	require.Equal(t, "ok", result["result"])
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/metrics"
+	"main/pkg/pool"
+	"main/pkg/types"
+
+	"github.com/rs/zerolog"
+)
+
+type Pool = pool.Pool[*Client]
+type Node = pool.Node[*Client]
+
+// Pools holds all the HTTP endpoints pools for a chain, so the nodes health
+// is shared between all the components querying the same hosts.
+type Pools struct {
+	RPC         *Pool
+	ProviderRPC *Pool
+	LCD         *Pool
+	ProviderLCD *Pool
+}
+
+func NewPool(
+	logger zerolog.Logger,
+	metricsManager *metrics.Manager,
+	chainName string,
+	poolName string,
+	hosts []string,
+) *Pool {
+	return pool.NewPool(logger, metricsManager, chainName, poolName, hosts, func(host string) *Client {
+		return NewClient(logger, metricsManager, host, chainName)
+	})
+}
+
+func NewPools(
+	logger zerolog.Logger,
+	metricsManager *metrics.Manager,
+	config *configPkg.ChainConfig,
+) *Pools {
+	return &Pools{
+		RPC:         NewPool(logger, metricsManager, config.Name, constants.PoolRPC, config.RPCEndpoints),
+		ProviderRPC: NewPool(logger, metricsManager, config.Name, constants.PoolProviderRPC, config.ProviderRPCEndpoints),
+		LCD:         NewPool(logger, metricsManager, config.Name, constants.PoolLCD, config.LCDEndpoints),
+		ProviderLCD: NewPool(logger, metricsManager, config.Name, constants.PoolProviderLCD, config.ProviderLCDEndpoints),
+	}
+}
+
+func (p *Pools) GetStats() []types.NodeStats {
+	stats := make([]types.NodeStats, 0)
+
+	for _, nodesPool := range []*Pool{p.RPC, p.ProviderRPC, p.LCD, p.ProviderLCD} {
+		stats = append(stats, nodesPool.GetStats()...)
+	}
+
+	return stats
+}
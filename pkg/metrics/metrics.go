@@ -40,6 +40,7 @@ type Manager struct {
 	reporterQueriesCounter *prometheus.CounterVec
 
 	missingBlocksGauge         *prometheus.GaugeVec
+	missedDivergenceGauge      *prometheus.GaugeVec
 	activeBlocksGauge          *prometheus.GaugeVec
 	votingPowerGauge           *prometheus.GaugeVec
 	cumulativeVotingPowerGauge *prometheus.GaugeVec
@@ -57,6 +58,14 @@ type Manager struct {
 	minSignedPerWindowGauge *prometheus.GaugeVec
 
 	storeBlocksGauge *prometheus.GaugeVec
+
+	nodeScoreGauge        *prometheus.GaugeVec
+	nodeLatencyGauge      *prometheus.GaugeVec
+	nodeErrorRateGauge    *prometheus.GaugeVec
+	nodeLatestHeightGauge *prometheus.GaugeVec
+	nodeQuarantinedGauge  *prometheus.GaugeVec
+	nodeLagGauge          *prometheus.GaugeVec
+	nodeLaggingGauge      *prometheus.GaugeVec
 }
 
 func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager {
@@ -89,7 +98,7 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 	reportEntriesCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: constants.PrometheusMetricsPrefix + "node_report_entries_total",
 		Help: "Counter of report entries send",
-	}, []string{"chain", "type"})
+	}, []string{"chain", "type", "window"})
 	totalBlocksGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: constants.PrometheusMetricsPrefix + "node_blocks",
 		Help: "Total amount of blocks stored",
@@ -121,11 +130,15 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 	missingBlocksGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: constants.PrometheusMetricsPrefix + "missed_blocks",
 		Help: "Validators' missed blocks count",
+	}, []string{"chain", "moniker", "address", "window"})
+	missedDivergenceGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "missed_blocks_divergence",
+		Help: "Difference between validators' missed blocks counted locally and the on-chain missed blocks counter",
 	}, []string{"chain", "moniker", "address"})
 	activeBlocksGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: constants.PrometheusMetricsPrefix + "active_blocks",
 		Help: "Count of each validator's blocks during which they were active",
-	}, []string{"chain", "moniker", "address"})
+	}, []string{"chain", "moniker", "address", "window"})
 	votingPowerGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: constants.PrometheusMetricsPrefix + "voting_power",
 		Help: "Voting power % of the validator",
@@ -166,6 +179,34 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 		Name: constants.PrometheusMetricsPrefix + "chain_info",
 		Help: "Chain info, with constant 1 as value and pretty_name and chain as labels",
 	}, []string{"chain", "pretty_name"})
+	nodeScoreGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_score",
+		Help: "Node health score, from 0 (unhealthy) to 1 (healthy)",
+	}, []string{"chain", "pool", "node"})
+	nodeLatencyGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_latency",
+		Help: "Node queries average latency, in seconds",
+	}, []string{"chain", "pool", "node"})
+	nodeErrorRateGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_error_rate",
+		Help: "Node queries recent error rate, from 0 to 1",
+	}, []string{"chain", "pool", "node"})
+	nodeLatestHeightGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_latest_height",
+		Help: "Latest block height reported by node",
+	}, []string{"chain", "pool", "node"})
+	nodeQuarantinedGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_quarantined",
+		Help: "Whether the node is quarantined due to failing queries (1 if yes, 0 if no)",
+	}, []string{"chain", "pool", "node"})
+	nodeLagGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_lag",
+		Help: "How much blocks the node is behind the highest block across all nodes",
+	}, []string{"chain", "pool", "node"})
+	nodeLaggingGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: constants.PrometheusMetricsPrefix + "node_lagging",
+		Help: "Whether the node is excluded from queries as it's lagging behind (1 if yes, 0 if no)",
+	}, []string{"chain", "pool", "node"})
 
 	registry.MustRegister(lastBlockHeightCollector)
 	registry.MustRegister(lastBlockTimeCollector)
@@ -182,6 +223,7 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 	registry.MustRegister(eventsCounter)
 	registry.MustRegister(reconnectsCounter)
 	registry.MustRegister(missingBlocksGauge)
+	registry.MustRegister(missedDivergenceGauge)
 	registry.MustRegister(activeBlocksGauge)
 	registry.MustRegister(votingPowerGauge)
 	registry.MustRegister(cumulativeVotingPowerGauge)
@@ -193,6 +235,13 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 	registry.MustRegister(storeBlocksGauge)
 	registry.MustRegister(minSignedPerWindowGauge)
 	registry.MustRegister(chainInfoGauge)
+	registry.MustRegister(nodeScoreGauge)
+	registry.MustRegister(nodeLatencyGauge)
+	registry.MustRegister(nodeErrorRateGauge)
+	registry.MustRegister(nodeLatestHeightGauge)
+	registry.MustRegister(nodeQuarantinedGauge)
+	registry.MustRegister(nodeLagGauge)
+	registry.MustRegister(nodeLaggingGauge)
 
 	startTimeGauge.
 		With(prometheus.Labels{}).
@@ -219,6 +268,7 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 		eventsCounter:              eventsCounter,
 		reconnectsCounter:          reconnectsCounter,
 		missingBlocksGauge:         missingBlocksGauge,
+		missedDivergenceGauge:      missedDivergenceGauge,
 		activeBlocksGauge:          activeBlocksGauge,
 		cumulativeVotingPowerGauge: cumulativeVotingPowerGauge,
 		votingPowerGauge:           votingPowerGauge,
@@ -230,6 +280,13 @@ func NewManager(logger zerolog.Logger, config configPkg.MetricsConfig) *Manager
 		storeBlocksGauge:           storeBlocksGauge,
 		minSignedPerWindowGauge:    minSignedPerWindowGauge,
 		chainInfoGauge:             chainInfoGauge,
+		nodeScoreGauge:             nodeScoreGauge,
+		nodeLatencyGauge:           nodeLatencyGauge,
+		nodeErrorRateGauge:         nodeErrorRateGauge,
+		nodeLatestHeightGauge:      nodeLatestHeightGauge,
+		nodeQuarantinedGauge:       nodeQuarantinedGauge,
+		nodeLagGauge:               nodeLagGauge,
+		nodeLaggingGauge:           nodeLaggingGauge,
 		server:                     server,
 	}
 }
@@ -240,12 +297,24 @@ func (m *Manager) SetDefaultMetrics(chain *configPkg.ChainConfig) {
 		Add(0)
 
 	for _, eventName := range constants.GetEventNames() {
-		m.reportEntriesCounter.
-			With(prometheus.Labels{
-				"chain": chain.Name,
-				"type":  string(eventName),
-			}).
-			Add(0)
+		// missed blocks group changes happen in signing windows, other events are not related to them
+		windows := []string{""}
+		if eventName == constants.EventValidatorGroupChanged {
+			windows = []string{constants.SlashingWindowName}
+			for _, window := range chain.SigningWindows {
+				windows = append(windows, window.Name)
+			}
+		}
+
+		for _, window := range windows {
+			m.reportEntriesCounter.
+				With(prometheus.Labels{
+					"chain":  chain.Name,
+					"type":   string(eventName),
+					"window": window,
+				}).
+				Add(0)
+		}
 	}
 
 	for _, node := range chain.RPCEndpoints {
@@ -335,10 +404,17 @@ func (m *Manager) LogReport(chain string, report *types.Report) {
 		Inc()
 
 	for _, event := range report.Events {
+		// empty for events not related to any signing window
+		window := ""
+		if windowedEvent, ok := event.(types.WindowedReportEvent); ok {
+			window = windowedEvent.GetWindow()
+		}
+
 		m.reportEntriesCounter.
 			With(prometheus.Labels{
-				"chain": chain,
-				"type":  string(event.Type()),
+				"chain":  chain,
+				"type":   string(event.Type()),
+				"window": window,
 			}).
 			Inc()
 	}
@@ -378,21 +454,11 @@ func (m *Manager) LogValidatorStats(
 	chain string,
 	entry *types.Entry,
 ) {
-	m.missingBlocksGauge.
-		With(prometheus.Labels{
-			"chain":   chain,
-			"moniker": entry.Validator.Moniker,
-			"address": entry.Validator.OperatorAddress,
-		}).
-		Set(float64(entry.SignatureInfo.GetNotSigned()))
+	m.logWindowStats(chain, entry.Validator, constants.SlashingWindowName, entry.SignatureInfo)
 
-	m.activeBlocksGauge.
-		With(prometheus.Labels{
-			"chain":   chain,
-			"moniker": entry.Validator.Moniker,
-			"address": entry.Validator.OperatorAddress,
-		}).
-		Set(float64(entry.SignatureInfo.Active))
+	for window, signatureInfo := range entry.WindowsSignatureInfo {
+		m.logWindowStats(chain, entry.Validator, window, signatureInfo)
+	}
 
 	m.isActiveGauge.
 		With(prometheus.Labels{
@@ -410,14 +476,6 @@ func (m *Manager) LogValidatorStats(
 		}).
 		Set(utils.BoolToFloat64(entry.Validator.Jailed))
 
-	m.missingBlocksGauge.
-		With(prometheus.Labels{
-			"chain":   chain,
-			"moniker": entry.Validator.Moniker,
-			"address": entry.Validator.OperatorAddress,
-		}).
-		Set(float64(entry.SignatureInfo.GetNotSigned()))
-
 	if entry.Validator.SigningInfo != nil {
 		m.isTombstonedGauge.
 			With(prometheus.Labels{
@@ -455,6 +513,43 @@ func (m *Manager) LogValidatorStats(
 	}
 }
 
+func (m *Manager) logWindowStats(
+	chain string,
+	validator *types.Validator,
+	window string,
+	signatureInfo types.SignatureInto,
+) {
+	m.missingBlocksGauge.
+		With(prometheus.Labels{
+			"chain":   chain,
+			"moniker": validator.Moniker,
+			"address": validator.OperatorAddress,
+			"window":  window,
+		}).
+		Set(float64(signatureInfo.GetNotSigned()))
+
+	m.activeBlocksGauge.
+		With(prometheus.Labels{
+			"chain":   chain,
+			"moniker": validator.Moniker,
+			"address": validator.OperatorAddress,
+			"window":  window,
+		}).
+		Set(float64(signatureInfo.Active))
+}
+
+// LogMissedBlocksDivergence logs the difference between the validator's missed blocks
+// counted locally and the on-chain missed blocks counter.
+func (m *Manager) LogMissedBlocksDivergence(chain string, validator *types.Validator, divergence int64) {
+	m.missedDivergenceGauge.
+		With(prometheus.Labels{
+			"chain":   chain,
+			"moniker": validator.Moniker,
+			"address": validator.OperatorAddress,
+		}).
+		Set(float64(divergence))
+}
+
 func (m *Manager) LogSlashingParams(
 	chain string,
 	window int64,
@@ -489,3 +584,19 @@ func (m *Manager) LogReporterQuery(chain string, reporter constants.ReporterName
 		}).
 		Inc()
 }
+
+func (m *Manager) LogNodeStats(chain string, stats types.NodeStats) {
+	labels := prometheus.Labels{
+		"chain": chain,
+		"pool":  stats.Pool,
+		"node":  stats.Host,
+	}
+
+	m.nodeScoreGauge.With(labels).Set(stats.Score())
+	m.nodeLatencyGauge.With(labels).Set(stats.Latency.Seconds())
+	m.nodeErrorRateGauge.With(labels).Set(stats.ErrorRate)
+	m.nodeLatestHeightGauge.With(labels).Set(float64(stats.LatestHeight))
+	m.nodeQuarantinedGauge.With(labels).Set(utils.BoolToFloat64(stats.IsQuarantined(time.Now())))
+	m.nodeLagGauge.With(labels).Set(float64(stats.Lag))
+	m.nodeLaggingGauge.With(labels).Set(utils.BoolToFloat64(stats.IsLagging))
+}
@@ -162,6 +162,8 @@ func TestMetricsManagerLogReport(t *testing.T) {
 	manager.LogReport("chain", &types.Report{
 		Events: []types.ReportEvent{
 			events.ValidatorActive{},
+			events.ValidatorGroupChanged{},
+			events.ValidatorGroupChanged{Window: "short"},
 		},
 	})
 
@@ -170,10 +172,21 @@ func TestMetricsManagerLogReport(t *testing.T) {
 		"chain": "chain",
 	})), 0.01)
 
-	assert.Equal(t, 1, testutil.CollectAndCount(manager.reportEntriesCounter))
+	assert.Equal(t, 3, testutil.CollectAndCount(manager.reportEntriesCounter))
 	assert.InDelta(t, 1, testutil.ToFloat64(manager.reportEntriesCounter.With(prometheus.Labels{
-		"chain": "chain",
-		"type":  string(constants.EventValidatorActive),
+		"chain":  "chain",
+		"type":   string(constants.EventValidatorActive),
+		"window": "",
+	})), 0.01)
+	assert.InDelta(t, 1, testutil.ToFloat64(manager.reportEntriesCounter.With(prometheus.Labels{
+		"chain":  "chain",
+		"type":   string(constants.EventValidatorGroupChanged),
+		"window": constants.SlashingWindowName,
+	})), 0.01)
+	assert.InDelta(t, 1, testutil.ToFloat64(manager.reportEntriesCounter.With(prometheus.Labels{
+		"chain":  "chain",
+		"type":   string(constants.EventValidatorGroupChanged),
+		"window": "short",
 	})), 0.01)
 }
 
@@ -296,20 +309,37 @@ func TestMetricsManagerLogValidatorStats(t *testing.T) {
 			Active:      5,
 			Proposed:    0,
 		},
+		WindowsSignatureInfo: map[string]types.SignatureInto{
+			"short": {BlocksCount: 3, Signed: 1, NotSigned: 2, Active: 3},
+		},
 	})
 
-	assert.Equal(t, 1, testutil.CollectAndCount(manager.missingBlocksGauge))
+	assert.Equal(t, 2, testutil.CollectAndCount(manager.missingBlocksGauge))
 	assert.InDelta(t, 4, testutil.ToFloat64(manager.missingBlocksGauge.With(prometheus.Labels{
 		"chain":   "chain",
 		"moniker": "moniker",
 		"address": "valoper",
+		"window":  constants.SlashingWindowName,
+	})), 0.01)
+	assert.InDelta(t, 2, testutil.ToFloat64(manager.missingBlocksGauge.With(prometheus.Labels{
+		"chain":   "chain",
+		"moniker": "moniker",
+		"address": "valoper",
+		"window":  "short",
 	})), 0.01)
 
-	assert.Equal(t, 1, testutil.CollectAndCount(manager.activeBlocksGauge))
+	assert.Equal(t, 2, testutil.CollectAndCount(manager.activeBlocksGauge))
 	assert.InDelta(t, 5, testutil.ToFloat64(manager.activeBlocksGauge.With(prometheus.Labels{
 		"chain":   "chain",
 		"moniker": "moniker",
 		"address": "valoper",
+		"window":  constants.SlashingWindowName,
+	})), 0.01)
+	assert.InDelta(t, 3, testutil.ToFloat64(manager.activeBlocksGauge.With(prometheus.Labels{
+		"chain":   "chain",
+		"moniker": "moniker",
+		"address": "valoper",
+		"window":  "short",
 	})), 0.01)
 
 	assert.Equal(t, 1, testutil.CollectAndCount(manager.isActiveGauge))
@@ -380,6 +410,26 @@ func TestMetricsManagerLogSlashingParams(t *testing.T) {
 	})), 0.01)
 }
 
+func TestMetricsManagerLogMissedBlocksDivergence(t *testing.T) {
+	t.Parallel()
+
+	config := configPkg.MetricsConfig{Enabled: null.BoolFrom(true), ListenAddr: "invalid"}
+	logger := loggerPkg.GetNopLogger()
+	manager := NewManager(*logger, config)
+
+	manager.LogMissedBlocksDivergence("chain", &types.Validator{
+		Moniker:         "moniker",
+		OperatorAddress: "address",
+	}, -5)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.missedDivergenceGauge))
+	assert.InDelta(t, -5, testutil.ToFloat64(manager.missedDivergenceGauge.With(prometheus.Labels{
+		"chain":   "chain",
+		"moniker": "moniker",
+		"address": "address",
+	})), 0.01)
+}
+
 func TestMetricsManagerLogChainInfo(t *testing.T) {
 	t.Parallel()
 
@@ -412,6 +462,54 @@ func TestMetricsManagerLogReporterQuery(t *testing.T) {
 	})), 0.01)
 }
 
+func TestMetricsManagerLogNodeStats(t *testing.T) {
+	t.Parallel()
+
+	config := configPkg.MetricsConfig{Enabled: null.BoolFrom(true), ListenAddr: "invalid"}
+	logger := loggerPkg.GetNopLogger()
+	manager := NewManager(*logger, config)
+
+	manager.LogNodeStats("chain", types.NodeStats{
+		Pool:             "rpc",
+		Host:             "https://example.com",
+		Successes:        3,
+		Failures:         1,
+		ErrorRate:        0.2,
+		Latency:          time.Second,
+		LatestHeight:     123,
+		Lag:              15,
+		IsLagging:        true,
+		QuarantinedUntil: time.Now().Add(time.Minute),
+	})
+
+	labels := prometheus.Labels{
+		"chain": "chain",
+		"pool":  "rpc",
+		"node":  "https://example.com",
+	}
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeScoreGauge))
+	assert.InDelta(t, 0.4, testutil.ToFloat64(manager.nodeScoreGauge.With(labels)), 0.01)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeLatencyGauge))
+	assert.InDelta(t, 1, testutil.ToFloat64(manager.nodeLatencyGauge.With(labels)), 0.01)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeErrorRateGauge))
+	assert.InDelta(t, 0.2, testutil.ToFloat64(manager.nodeErrorRateGauge.With(labels)), 0.01)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeLatestHeightGauge))
+	assert.InDelta(t, 123, testutil.ToFloat64(manager.nodeLatestHeightGauge.With(labels)), 0.01)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeQuarantinedGauge))
+	assert.InDelta(t, 1, testutil.ToFloat64(manager.nodeQuarantinedGauge.With(labels)), 0.01)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeLagGauge))
+	assert.InDelta(t, 15, testutil.ToFloat64(manager.nodeLagGauge.With(labels)), 0.01)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(manager.nodeLaggingGauge))
+	assert.InDelta(t, 1, testutil.ToFloat64(manager.nodeLaggingGauge.With(labels)), 0.01)
+}
+
 func TestMetricsManagerSetDefaultMetrics(t *testing.T) {
 	t.Parallel()
 
@@ -420,7 +518,8 @@ func TestMetricsManagerSetDefaultMetrics(t *testing.T) {
 	manager := NewManager(*logger, config)
 
 	chainConfig := &configPkg.ChainConfig{
-		RPCEndpoints: []string{"node"},
+		RPCEndpoints:   []string{"node"},
+		SigningWindows: []configPkg.SigningWindowConfig{{Name: "short", Blocks: 100}},
 	}
 
 	manager.SetDefaultMetrics(chainConfig)
@@ -431,11 +530,25 @@ func TestMetricsManagerSetDefaultMetrics(t *testing.T) {
 	})))
 
 	eventNames := constants.GetEventNames()
-	assert.Equal(t, len(eventNames), testutil.CollectAndCount(manager.reportEntriesCounter))
+	// group changes are counted for both the slashing window and the additional one
+	assert.Equal(t, len(eventNames)+1, testutil.CollectAndCount(manager.reportEntriesCounter))
 	for _, name := range eventNames {
+		if name == constants.EventValidatorGroupChanged {
+			continue
+		}
+
+		assert.Zero(t, testutil.ToFloat64(manager.reportEntriesCounter.With(prometheus.Labels{
+			"chain":  "",
+			"type":   string(name),
+			"window": "",
+		})))
+	}
+
+	for _, window := range []string{constants.SlashingWindowName, "short"} {
 		assert.Zero(t, testutil.ToFloat64(manager.reportEntriesCounter.With(prometheus.Labels{
-			"chain": "chain",
-			"type":  string(name),
+			"chain":  "",
+			"type":   string(constants.EventValidatorGroupChanged),
+			"window": window,
 		})))
 	}
 
@@ -0,0 +1,232 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Database is where reports are stored until they are delivered.
+type Database interface {
+	InsertOutboxReport(
+		chain string,
+		reporter constants.ReporterName,
+		height int64,
+		message string,
+		createdAt time.Time,
+	) error
+	GetPendingOutboxReports(chain string, limit int) ([]*types.OutboxReport, error)
+	UpdateOutboxReport(report *types.OutboxReport) error
+	TrimOutboxReportsBefore(chain string, before time.Time) error
+}
+
+// Outbox stores each report rendered for each reporter before it's sent, and delivers
+// them in the background, retrying with exponential backoff if sending fails, so reports
+// are not lost if a messenger is unavailable for a while or the app is restarted.
+// Reports for a reporter are delivered in the order they were created.
+type Outbox struct {
+	logger    zerolog.Logger
+	config    configPkg.OutboxConfig
+	chain     string
+	database  Database
+	reporters []reportersPkg.Reporter
+	onSent    func(reporter constants.ReporterName, err error)
+	trigger   chan struct{}
+	lastTrim  time.Time
+}
+
+func NewOutbox(
+	logger zerolog.Logger,
+	config configPkg.OutboxConfig,
+	chain string,
+	database Database,
+	reporters []reportersPkg.Reporter,
+	onSent func(reporter constants.ReporterName, err error),
+) *Outbox {
+	return &Outbox{
+		logger:    logger.With().Str("component", "outbox").Logger(),
+		config:    config,
+		chain:     chain,
+		database:  database,
+		reporters: reporters,
+		onSent:    onSent,
+		trigger:   make(chan struct{}, 1),
+	}
+}
+
+// Enqueue stores the report for each enabled reporter and wakes up the delivery.
+func (o *Outbox) Enqueue(height int64, report *types.Report) error {
+	now := time.Now()
+
+	for _, reporter := range o.reporters {
+		if !reporter.Enabled() {
+			continue
+		}
+
+		if err := o.database.InsertOutboxReport(
+			o.chain,
+			reporter.Name(),
+			height,
+			reporter.RenderReport(report),
+			now,
+		); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case o.trigger <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Start delivers reports as they are enqueued and retries failed ones until the context is done.
+func (o *Outbox) Start(ctx context.Context) {
+	ticker := time.NewTicker(constants.OutboxDeliverInterval)
+	defer ticker.Stop()
+
+	o.Deliver()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.trigger:
+			o.Deliver()
+		case <-ticker.C:
+			o.Deliver()
+		}
+	}
+}
+
+func (o *Outbox) Deliver() {
+	now := time.Now()
+
+	if now.Sub(o.lastTrim) > constants.OutboxTrimInterval {
+		if err := o.database.TrimOutboxReportsBefore(o.chain, now.Add(-constants.OutboxKeepDuration)); err != nil {
+			o.logger.Error().Err(err).Msg("Error trimming outbox")
+		}
+
+		o.lastTrim = now
+	}
+
+	reports, err := o.database.GetPendingOutboxReports(o.chain, constants.OutboxBatchSize)
+	if err != nil {
+		o.logger.Error().Err(err).Msg("Error getting reports to deliver")
+		return
+	}
+
+	// if a report could not be sent, the next ones for the same reporter are not sent
+	// until it's delivered, so reports are not reordered
+	blockedReporters := map[constants.ReporterName]bool{}
+
+	for _, report := range reports {
+		if blockedReporters[report.Reporter] {
+			continue
+		}
+
+		if report.NextAttemptAt.After(now) {
+			blockedReporters[report.Reporter] = true
+			continue
+		}
+
+		if err := o.DeliverReport(report, now); err != nil {
+			blockedReporters[report.Reporter] = true
+		}
+
+		if err := o.database.UpdateOutboxReport(report); err != nil {
+			o.logger.Error().Err(err).Int64("id", report.ID).Msg("Error updating report delivery status")
+		}
+	}
+}
+
+// DeliverReport sends the report and updates its delivery status.
+func (o *Outbox) DeliverReport(report *types.OutboxReport, now time.Time) error {
+	reporter, found := utils.Find(o.reporters, func(r reportersPkg.Reporter) bool {
+		return r.Name() == report.Reporter
+	})
+
+	if !found || !reporter.Enabled() {
+		o.logger.Warn().
+			Int64("id", report.ID).
+			Str("reporter", string(report.Reporter)).
+			Msg("Reporter is not enabled anymore, not delivering report")
+		report.Status = constants.OutboxStatusFailed
+		report.LastError = "reporter is not enabled"
+		return nil
+	}
+
+	report.Attempts++
+
+	sentChunks, err := reporter.SendReport(report.Message, report.SentChunks)
+	report.SentChunks = sentChunks
+
+	if o.onSent != nil {
+		o.onSent(report.Reporter, err)
+	}
+
+	if err == nil {
+		report.Status = constants.OutboxStatusSent
+		report.LastError = ""
+		return nil
+	}
+
+	report.LastError = err.Error()
+
+	if report.Attempts >= o.config.MaxAttempts {
+		o.logger.Error().
+			Err(err).
+			Int64("id", report.ID).
+			Int64("height", report.Height).
+			Str("reporter", string(report.Reporter)).
+			Int64("attempts", report.Attempts).
+			Msg("Could not deliver report, giving up")
+		report.Status = constants.OutboxStatusFailed
+		return err
+	}
+
+	backoff := o.GetBackoff(report.Attempts, err)
+	report.NextAttemptAt = now.Add(backoff)
+
+	o.logger.Warn().
+		Err(err).
+		Int64("id", report.ID).
+		Int64("height", report.Height).
+		Str("reporter", string(report.Reporter)).
+		Int64("attempts", report.Attempts).
+		Dur("retry_in", backoff).
+		Msg("Could not deliver report, will retry")
+
+	return err
+}
+
+// GetBackoff returns how long to wait before the next attempt: the time the messenger
+// asked to wait for if it's rate limited, otherwise it's doubled with each attempt.
+func (o *Outbox) GetBackoff(attempts int64, err error) time.Duration {
+	var rateLimitedErr *types.RateLimitedError
+	if errors.As(err, &rateLimitedErr) && rateLimitedErr.RetryAfter > 0 {
+		return rateLimitedErr.RetryAfter
+	}
+
+	backoff := o.config.BaseBackoff * time.Second
+	maxBackoff := o.config.MaxBackoff * time.Second
+
+	for i := int64(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
+}
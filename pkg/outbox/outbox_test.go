@@ -0,0 +1,202 @@
+package outbox
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getTestDatabase(t *testing.T) *databasePkg.Database {
+	t.Helper()
+
+	database := databasePkg.NewDatabase(*loggerPkg.GetNopLogger(), configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	return database
+}
+
+func getTestOutbox(
+	database Database,
+	reporters ...reportersPkg.Reporter,
+) *Outbox {
+	return NewOutbox(
+		*loggerPkg.GetNopLogger(),
+		configPkg.OutboxConfig{MaxAttempts: 3, BaseBackoff: 5, MaxBackoff: 60},
+		"chain",
+		database,
+		reporters,
+		nil,
+	)
+}
+
+func getAllReports(t *testing.T, database *databasePkg.Database) []*types.OutboxReport {
+	t.Helper()
+
+	reports, err := database.GetPendingOutboxReports("chain", 100)
+	require.NoError(t, err)
+	return reports
+}
+
+func TestOutboxEnqueueAndDeliver(t *testing.T) {
+	t.Parallel()
+
+	database := getTestDatabase(t)
+	first := &reportersPkg.StubReporter{ReporterName: "first"}
+	disabled := &reportersPkg.StubReporter{ReporterName: "disabled", Disabled: true}
+
+	var sent []constants.ReporterName
+
+	reportsOutbox := getTestOutbox(database, first, disabled)
+	reportsOutbox.onSent = func(reporter constants.ReporterName, err error) {
+		require.NoError(t, err)
+		sent = append(sent, reporter)
+	}
+
+	require.NoError(t, reportsOutbox.Enqueue(100, &types.Report{}))
+	require.Len(t, getAllReports(t, database), 1)
+
+	reportsOutbox.Deliver()
+	require.Equal(t, []string{"first report"}, first.Reports)
+	require.Empty(t, disabled.Reports)
+	require.Equal(t, []constants.ReporterName{"first"}, sent)
+	require.Empty(t, getAllReports(t, database))
+
+	reportsOutbox.Deliver()
+	require.Len(t, first.Reports, 1)
+}
+
+func TestOutboxEnqueueFail(t *testing.T) {
+	t.Parallel()
+
+	client := databasePkg.NewStubDatabaseClient()
+	client.ExecError = errors.New("custom error")
+	database := databasePkg.NewDatabase(*loggerPkg.GetNopLogger(), configPkg.DatabaseConfig{})
+	database.SetClient(client)
+
+	reportsOutbox := getTestOutbox(database, &reportersPkg.StubReporter{ReporterName: "first"})
+	err := reportsOutbox.Enqueue(100, &types.Report{})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestOutboxRetryKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	database := getTestDatabase(t)
+	failing := &reportersPkg.StubReporter{ReporterName: "failing", SendError: errors.New("custom error")}
+	working := &reportersPkg.StubReporter{ReporterName: "working"}
+
+	reportsOutbox := getTestOutbox(database, failing, working)
+	require.NoError(t, reportsOutbox.Enqueue(100, &types.Report{}))
+	require.NoError(t, reportsOutbox.Enqueue(101, &types.Report{}))
+
+	reportsOutbox.Deliver()
+	require.Len(t, working.Reports, 2)
+
+	reports := getAllReports(t, database)
+	require.Len(t, reports, 2)
+	require.Equal(t, int64(1), reports[0].Attempts)
+	require.Equal(t, "custom error", reports[0].LastError)
+	require.True(t, reports[0].NextAttemptAt.After(time.Now()))
+	// the second report was not tried, as the first one for the same reporter failed
+	require.Zero(t, reports[1].Attempts)
+
+	failing.SendError = nil
+
+	// not due yet
+	reportsOutbox.Deliver()
+	require.Empty(t, failing.Reports)
+
+	for _, report := range reports {
+		require.NoError(t, reportsOutbox.DeliverReport(report, time.Now()))
+		require.Equal(t, constants.OutboxStatusSent, report.Status)
+	}
+
+	require.Equal(t, []string{"failing report", "failing report"}, failing.Reports)
+}
+
+func TestOutboxRetrySendsRemainingChunks(t *testing.T) {
+	t.Parallel()
+
+	database := getTestDatabase(t)
+	reporter := &reportersPkg.StubReporter{
+		ReporterName:      "reporter",
+		SendError:         errors.New("custom error"),
+		Chunks:            3,
+		ChunksBeforeError: 2,
+	}
+
+	reportsOutbox := getTestOutbox(database, reporter)
+	require.NoError(t, reportsOutbox.Enqueue(100, &types.Report{}))
+
+	reportsOutbox.Deliver()
+	require.Equal(t, []int64{0, 1}, reporter.SentChunks)
+
+	reports := getAllReports(t, database)
+	require.Len(t, reports, 1)
+	require.Equal(t, int64(2), reports[0].SentChunks)
+
+	reporter.SendError = nil
+	require.NoError(t, reportsOutbox.DeliverReport(reports[0], time.Now()))
+
+	// the chunks sent before are not sent again
+	require.Equal(t, []int64{0, 1, 2}, reporter.SentChunks)
+	require.Equal(t, int64(3), reports[0].SentChunks)
+	require.Equal(t, constants.OutboxStatusSent, reports[0].Status)
+}
+
+func TestOutboxGiveUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	failing := &reportersPkg.StubReporter{ReporterName: "failing", SendError: errors.New("custom error")}
+	reportsOutbox := getTestOutbox(nil, failing)
+
+	report := &types.OutboxReport{ID: 1, Reporter: "failing", Attempts: 2, Status: constants.OutboxStatusPending}
+	err := reportsOutbox.DeliverReport(report, time.Now())
+	require.Error(t, err)
+	require.Equal(t, constants.OutboxStatusFailed, report.Status)
+	require.Equal(t, int64(3), report.Attempts)
+}
+
+func TestOutboxReporterNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	reportsOutbox := getTestOutbox(nil, &reportersPkg.StubReporter{ReporterName: "first", Disabled: true})
+
+	report := &types.OutboxReport{ID: 1, Reporter: "unknown", Status: constants.OutboxStatusPending}
+	require.NoError(t, reportsOutbox.DeliverReport(report, time.Now()))
+	require.Equal(t, constants.OutboxStatusFailed, report.Status)
+
+	report = &types.OutboxReport{ID: 2, Reporter: "first", Status: constants.OutboxStatusPending}
+	require.NoError(t, reportsOutbox.DeliverReport(report, time.Now()))
+	require.Equal(t, constants.OutboxStatusFailed, report.Status)
+}
+
+func TestOutboxGetBackoff(t *testing.T) {
+	t.Parallel()
+
+	reportsOutbox := getTestOutbox(nil)
+	err := errors.New("custom error")
+
+	require.Equal(t, 5*time.Second, reportsOutbox.GetBackoff(1, err))
+	require.Equal(t, 10*time.Second, reportsOutbox.GetBackoff(2, err))
+	require.Equal(t, 40*time.Second, reportsOutbox.GetBackoff(4, err))
+	require.Equal(t, time.Minute, reportsOutbox.GetBackoff(5, err))
+	require.Equal(t, time.Minute, reportsOutbox.GetBackoff(100, err))
+
+	rateLimitedErr := &types.RateLimitedError{RetryAfter: 30 * time.Second, Err: err}
+	require.Equal(t, 30*time.Second, reportsOutbox.GetBackoff(1, rateLimitedErr))
+	require.ErrorIs(t, rateLimitedErr, err)
+	require.Equal(t, "rate limited, retry after 30s: custom error", rateLimitedErr.Error())
+}
@@ -0,0 +1,316 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"main/pkg/metrics"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// QuarantineAfterFailures is the amount of consecutive failed queries
+	// after which the node is not queried unless all other nodes are failing too.
+	QuarantineAfterFailures = 3
+	// QuarantineBaseDuration is the quarantine duration for the first time
+	// the node is quarantined, it doubles with each next failure.
+	QuarantineBaseDuration = 30 * time.Second
+	QuarantineMaxDuration  = 10 * time.Minute
+	// SmoothingFactor is the weight of the latest query when calculating
+	// the moving average of error rate and latency.
+	SmoothingFactor = 0.2
+)
+
+type Node[T any] struct {
+	Host   string
+	Client T
+
+	stats types.NodeStats
+}
+
+type Pool[T any] struct {
+	logger         zerolog.Logger
+	metricsManager *metrics.Manager
+	chain          string
+	name           string
+	mutex          sync.Mutex
+
+	nodes []*Node[T]
+	// how many queries in a row failed on all nodes
+	failedQueriesInRow int64
+}
+
+func NewPool[T any](
+	logger zerolog.Logger,
+	metricsManager *metrics.Manager,
+	chain string,
+	name string,
+	hosts []string,
+	clientFactory func(host string) T,
+) *Pool[T] {
+	nodes := make([]*Node[T], len(hosts))
+	for index, host := range hosts {
+		nodes[index] = &Node[T]{
+			Host:   host,
+			Client: clientFactory(host),
+			stats:  types.NodeStats{Pool: name, Host: host},
+		}
+	}
+
+	return &Pool[T]{
+		logger: logger.With().
+			Str("component", "nodes_pool").
+			Str("pool", name).
+			Logger(),
+		metricsManager: metricsManager,
+		chain:          chain,
+		name:           name,
+		nodes:          nodes,
+	}
+}
+
+func (p *Pool[T]) Name() string {
+	return p.name
+}
+
+func (p *Pool[T]) Len() int {
+	return len(p.nodes)
+}
+
+func (p *Pool[T]) GetNodes() []*Node[T] {
+	nodes := make([]*Node[T], len(p.nodes))
+	copy(nodes, p.nodes)
+	return nodes
+}
+
+// GetOrdered returns nodes in the order they should be queried: healthy nodes
+// with the best score go first, quarantined nodes go last. Nodes with equal scores
+// are shuffled, so the load is spread between them. Nodes lagging behind the chain tip
+// are skipped, unless all nodes are lagging.
+func (p *Pool[T]) GetOrdered() []*Node[T] {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+
+	indexesShuffled := utils.MakeShuffledArray(len(p.nodes))
+	nodes := make([]*Node[T], len(p.nodes))
+
+	for index, indexShuffled := range indexesShuffled {
+		nodes[index] = p.nodes[indexShuffled]
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		first, second := nodes[i].stats, nodes[j].stats
+
+		firstQuarantined, secondQuarantined := first.IsQuarantined(now), second.IsQuarantined(now)
+		if firstQuarantined != secondQuarantined {
+			return !firstQuarantined
+		}
+
+		if firstQuarantined {
+			return first.QuarantinedUntil.Before(second.QuarantinedUntil)
+		}
+
+		return first.Score() > second.Score()
+	})
+
+	notLagging := utils.Filter(nodes, func(node *Node[T]) bool {
+		return !node.stats.IsLagging
+	})
+
+	if len(notLagging) == 0 {
+		return nodes
+	}
+
+	return notLagging
+}
+
+// Query calls the callback on each node in the order of their health until
+// one of them succeeds, and records each node's latency and result.
+func (p *Pool[T]) Query(query string, callback func(node *Node[T]) error) error {
+	nodes := p.GetOrdered()
+	errorsArray := make([]error, len(nodes))
+
+	for index, node := range nodes {
+		p.logger.Trace().
+			Str("host", node.Host).
+			Str("query", query).
+			Msg("Trying making request to node")
+
+		start := time.Now()
+		err := callback(node)
+		p.RecordResult(node, time.Since(start), err)
+
+		if err != nil {
+			p.logger.Warn().
+				Str("host", node.Host).
+				Str("query", query).
+				Err(err).
+				Msg("Request to node failed")
+			errorsArray[index] = err
+			continue
+		}
+
+		p.mutex.Lock()
+		p.failedQueriesInRow = 0
+		p.mutex.Unlock()
+
+		return nil
+	}
+
+	p.logger.Warn().Str("query", query).Msg("All requests failed")
+
+	p.mutex.Lock()
+	p.failedQueriesInRow++
+	p.mutex.Unlock()
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("All %s requests failed:\n", p.name))
+	for index, node := range nodes {
+		sb.WriteString(fmt.Sprintf("#%d: %s -> %s\n", index+1, node.Host, errorsArray[index]))
+	}
+
+	return errors.New(sb.String())
+}
+
+func (p *Pool[T]) GetFailedQueriesInRow() int64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.failedQueriesInRow
+}
+
+func (p *Pool[T]) RecordResult(node *Node[T], latency time.Duration, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stats := &node.stats
+
+	if err == nil {
+		stats.Successes++
+		stats.ConsecutiveFailures = 0
+		stats.QuarantinedUntil = time.Time{}
+		stats.ErrorRate = stats.ErrorRate * (1 - SmoothingFactor)
+
+		if stats.Latency == 0 {
+			stats.Latency = latency
+		} else {
+			stats.Latency = time.Duration(
+				float64(stats.Latency)*(1-SmoothingFactor) + float64(latency)*SmoothingFactor,
+			)
+		}
+	} else {
+		stats.Failures++
+		stats.ConsecutiveFailures++
+		stats.ErrorRate = stats.ErrorRate*(1-SmoothingFactor) + SmoothingFactor
+
+		if stats.ConsecutiveFailures >= QuarantineAfterFailures {
+			quarantineDuration := GetQuarantineDuration(stats.ConsecutiveFailures)
+			stats.QuarantinedUntil = time.Now().Add(quarantineDuration)
+
+			p.logger.Warn().
+				Str("host", node.Host).
+				Int64("failures", stats.ConsecutiveFailures).
+				Dur("duration", quarantineDuration).
+				Msg("Node is failing, quarantining it")
+		}
+	}
+
+	p.metricsManager.LogNodeStats(p.chain, *stats)
+}
+
+func (p *Pool[T]) SetLatestHeight(node *Node[T], height int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	node.stats.LatestHeight = height
+	p.metricsManager.LogNodeStats(p.chain, node.stats)
+}
+
+// SetLag stores the node's latest height and how much it's behind the highest
+// height across all nodes in the pool.
+func (p *Pool[T]) SetLag(node *Node[T], height int64, lag int64, isLagging bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if isLagging && !node.stats.IsLagging {
+		p.logger.Warn().
+			Str("host", node.Host).
+			Int64("height", height).
+			Int64("lag", lag).
+			Msg("Node is lagging behind, excluding it from queries")
+	} else if !isLagging && node.stats.IsLagging {
+		p.logger.Info().
+			Str("host", node.Host).
+			Int64("height", height).
+			Msg("Node has caught up, including it in queries again")
+	}
+
+	node.stats.LatestHeight = height
+	node.stats.Lag = lag
+	node.stats.IsLagging = isLagging
+	p.metricsManager.LogNodeStats(p.chain, node.stats)
+}
+
+// ResetLag marks the node's lag as unknown when its latest height cannot be queried,
+// so it's not kept excluded from (or included in) queries based on an outdated lag.
+// Whether it's healthy is then up to its errors rate.
+func (p *Pool[T]) ResetLag(node *Node[T]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if node.stats.IsLagging {
+		p.logger.Info().
+			Str("host", node.Host).
+			Msg("Could not get node's latest height, not excluding it from queries as lagging anymore")
+	}
+
+	node.stats.Lag = 0
+	node.stats.IsLagging = false
+	p.metricsManager.LogNodeStats(p.chain, node.stats)
+}
+
+func (p *Pool[T]) IsLagging(host string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, node := range p.nodes {
+		if node.Host == host {
+			return node.stats.IsLagging
+		}
+	}
+
+	return false
+}
+
+func (p *Pool[T]) GetStats() []types.NodeStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stats := make([]types.NodeStats, len(p.nodes))
+	for index, node := range p.nodes {
+		stats[index] = node.stats
+	}
+
+	return stats
+}
+
+func GetQuarantineDuration(consecutiveFailures int64) time.Duration {
+	exponent := float64(consecutiveFailures - QuarantineAfterFailures)
+	duration := float64(QuarantineBaseDuration) * math.Pow(2, exponent)
+
+	if duration > float64(QuarantineMaxDuration) {
+		return QuarantineMaxDuration
+	}
+
+	return time.Duration(duration)
+}
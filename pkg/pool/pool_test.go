@@ -0,0 +1,188 @@
+package pool
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func getTestPool(hosts ...string) *Pool[string] {
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+
+	return NewPool(*logger, metricsManager, "chain", "rpc", hosts, func(host string) string {
+		return host
+	})
+}
+
+func TestPoolQueryOk(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("node1", "node2")
+	require.Equal(t, "rpc", pool.Name())
+	require.Equal(t, 2, pool.Len())
+
+	err := pool.Query("query", func(node *Node[string]) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	stats := pool.GetStats()
+	require.Len(t, stats, 2)
+	require.Equal(t, int64(1), stats[0].Successes+stats[1].Successes)
+}
+
+func TestPoolQueryAllFailed(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("node1", "node2")
+
+	err := pool.Query("query", func(node *Node[string]) error {
+		return errors.New("custom error")
+	})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "All rpc requests failed")
+	require.ErrorContains(t, err, "custom error")
+
+	for _, stats := range pool.GetStats() {
+		require.Equal(t, int64(1), stats.Failures)
+		require.Equal(t, int64(1), stats.ConsecutiveFailures)
+	}
+}
+
+func TestPoolFailedQueriesInRow(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("node1", "node2")
+
+	for i := 0; i < 3; i++ {
+		_ = pool.Query("query", func(node *Node[string]) error {
+			return errors.New("custom error")
+		})
+	}
+
+	require.Equal(t, int64(3), pool.GetFailedQueriesInRow())
+
+	err := pool.Query("query", func(node *Node[string]) error {
+		if node.Host == "node1" {
+			return errors.New("custom error")
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Zero(t, pool.GetFailedQueriesInRow())
+}
+
+func TestPoolFailoverToHealthyNode(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("broken", "healthy")
+
+	for range 5 {
+		err := pool.Query("query", func(node *Node[string]) error {
+			if node.Client == "broken" {
+				return errors.New("custom error")
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	nodes := pool.GetOrdered()
+	require.Equal(t, "healthy", nodes[0].Host)
+	require.Equal(t, "broken", nodes[1].Host)
+}
+
+func TestPoolQuarantine(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("broken")
+	node := pool.GetOrdered()[0]
+
+	for range QuarantineAfterFailures - 1 {
+		pool.RecordResult(node, time.Second, errors.New("custom error"))
+	}
+
+	require.False(t, pool.GetStats()[0].IsQuarantined(time.Now()))
+
+	pool.RecordResult(node, time.Second, errors.New("custom error"))
+	require.True(t, pool.GetStats()[0].IsQuarantined(time.Now()))
+
+	pool.RecordResult(node, time.Second, nil)
+
+	stats := pool.GetStats()[0]
+	require.False(t, stats.IsQuarantined(time.Now()))
+	require.Zero(t, stats.ConsecutiveFailures)
+	require.Equal(t, time.Second, stats.Latency)
+}
+
+func TestPoolOrderQuarantined(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("node1", "node2", "node3")
+	nodes := pool.GetOrdered()
+
+	for range QuarantineAfterFailures {
+		pool.RecordResult(nodes[0], time.Second, errors.New("custom error"))
+	}
+
+	for range QuarantineAfterFailures + 1 {
+		pool.RecordResult(nodes[1], time.Second, errors.New("custom error"))
+	}
+
+	ordered := pool.GetOrdered()
+	assert.Equal(t, nodes[2].Host, ordered[0].Host)
+	assert.Equal(t, nodes[0].Host, ordered[1].Host)
+	assert.Equal(t, nodes[1].Host, ordered[2].Host)
+}
+
+func TestPoolSetLatestHeight(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("node")
+	pool.SetLatestHeight(pool.GetOrdered()[0], 123)
+	require.Equal(t, int64(123), pool.GetStats()[0].LatestHeight)
+}
+
+func TestPoolSkipLagging(t *testing.T) {
+	t.Parallel()
+
+	pool := getTestPool("node1", "node2")
+	nodes := pool.GetNodes()
+
+	pool.SetLag(nodes[0], 100, 20, true)
+	require.True(t, pool.IsLagging("node1"))
+	require.False(t, pool.IsLagging("node2"))
+	require.False(t, pool.IsLagging("node3"))
+
+	ordered := pool.GetOrdered()
+	require.Len(t, ordered, 1)
+	require.Equal(t, "node2", ordered[0].Host)
+
+	pool.SetLag(nodes[1], 100, 20, true)
+	require.Len(t, pool.GetOrdered(), 2)
+
+	pool.SetLag(nodes[0], 120, 0, false)
+	require.False(t, pool.IsLagging("node1"))
+
+	stats := pool.GetStats()[0]
+	require.Equal(t, int64(120), stats.LatestHeight)
+	require.Zero(t, stats.Lag)
+}
+
+func TestGetQuarantineDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, QuarantineBaseDuration, GetQuarantineDuration(QuarantineAfterFailures))
+	assert.Equal(t, 2*QuarantineBaseDuration, GetQuarantineDuration(QuarantineAfterFailures+1))
+	assert.Equal(t, QuarantineMaxDuration, GetQuarantineDuration(100))
+}
@@ -0,0 +1,42 @@
+package populators
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/discovery"
+)
+
+type ConsumersDiscoveryPopulator struct {
+	Config       *configPkg.ChainConfig
+	Discovery    *discovery.Discovery
+	OnDiscovered func(provider string, consumers []*configPkg.ChainConfig)
+}
+
+func NewConsumersDiscoveryPopulator(
+	config *configPkg.ChainConfig,
+	discovery *discovery.Discovery,
+	onDiscovered func(provider string, consumers []*configPkg.ChainConfig),
+) *ConsumersDiscoveryPopulator {
+	return &ConsumersDiscoveryPopulator{
+		Config:       config,
+		Discovery:    discovery,
+		OnDiscovered: onDiscovered,
+	}
+}
+func (p *ConsumersDiscoveryPopulator) Populate() error {
+	consumers, err := p.Discovery.Discover()
+	if err != nil {
+		return err
+	}
+
+	p.OnDiscovered(p.Config.Name, consumers)
+	return nil
+}
+
+func (p *ConsumersDiscoveryPopulator) Enabled() bool {
+	return p.Config.IsConsumerDiscoveryEnabled() && p.OnDiscovered != nil
+}
+
+func (p *ConsumersDiscoveryPopulator) Name() constants.PopulatorType {
+	return constants.PopulatorConsumersDiscovery
+}
@@ -0,0 +1,29 @@
+package populators
+
+import (
+	"main/pkg/constants"
+	"main/pkg/data"
+)
+
+type NodesLagPopulator struct {
+	DataManager *data.Manager
+}
+
+func NewNodesLagPopulator(
+	dataManager *data.Manager,
+) *NodesLagPopulator {
+	return &NodesLagPopulator{
+		DataManager: dataManager,
+	}
+}
+func (p *NodesLagPopulator) Populate() error {
+	return p.DataManager.CheckNodesLag()
+}
+
+func (p *NodesLagPopulator) Enabled() bool {
+	return true
+}
+
+func (p *NodesLagPopulator) Name() constants.PopulatorType {
+	return constants.PopulatorNodesLag
+}
@@ -1,6 +1,7 @@
 package populators
 
 import (
+	"context"
 	"main/pkg/constants"
 	"time"
 
@@ -33,7 +34,8 @@ func NewWrapper(
 	}
 }
 
-func (w *Wrapper) Start() {
+// Start populates data at the interval until the context is done.
+func (w *Wrapper) Start(ctx context.Context) {
 	if w.Duration == 0 {
 		w.Logger.Info().
 			Str("name", string(w.Populator.Name())).
@@ -56,9 +58,15 @@ func (w *Wrapper) Start() {
 	w.TryPopulate()
 
 	timer := time.NewTicker(w.Duration)
+	defer timer.Stop()
 
 	for {
 		select {
+		case <-ctx.Done():
+			w.Logger.Debug().
+				Str("name", string(w.Populator.Name())).
+				Msg("Populator is stopped")
+			return
 		case <-timer.C:
 			w.TryPopulate()
 		}
@@ -0,0 +1,142 @@
+package populators
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/metrics"
+	"main/pkg/state"
+	"main/pkg/types"
+
+	"github.com/rs/zerolog"
+)
+
+// ReconciliationPopulator cross-checks validators' missed blocks counted from the stored blocks
+// with the on-chain missed blocks counter, as the local state can be incorrect if a node
+// has returned bad blocks. Optionally, it removes the blocks a validator is counted as missed at
+// if it has more missed blocks locally than on chain, so they are fetched again.
+type ReconciliationPopulator struct {
+	Config         *configPkg.ChainConfig
+	StateManager   *state.Manager
+	MetricsManager *metrics.Manager
+	Logger         zerolog.Logger
+}
+
+func NewReconciliationPopulator(
+	config *configPkg.ChainConfig,
+	stateManager *state.Manager,
+	metricsManager *metrics.Manager,
+	logger zerolog.Logger,
+) *ReconciliationPopulator {
+	return &ReconciliationPopulator{
+		Config:         config,
+		StateManager:   stateManager,
+		MetricsManager: metricsManager,
+		Logger: logger.With().
+			Str("component", "reconciliation_populator").
+			Logger(),
+	}
+}
+
+func (p *ReconciliationPopulator) Populate() error {
+	toRefetch := make([]int64, 0)
+	discrepancies := 0
+	skipped := 0
+
+	for _, validator := range p.StateManager.GetValidators() {
+		// the on-chain counter is reset when a validator is jailed
+		if validator.SigningInfo == nil || validator.SigningInfo.Tombstoned || validator.Jailed {
+			continue
+		}
+
+		// fails if some blocks in the window are not fetched yet, like while backfilling,
+		// the validator is compared on the next run then
+		signatureInfo, err := p.StateManager.GetValidatorMissedBlocks(validator)
+		if err != nil {
+			p.Logger.Debug().
+				Err(err).
+				Str("valoper", validator.OperatorAddress).
+				Msg("Could not get validator missed blocks, skipping")
+
+			skipped++
+			continue
+		}
+
+		// the local counter is taken from the signing info if a validator
+		// was not active during the whole window, so there's nothing to compare
+		if signatureInfo.NotActive > 0 {
+			continue
+		}
+
+		divergence := signatureInfo.GetNotSigned() - validator.SigningInfo.MissedBlocksCounter
+		p.MetricsManager.LogMissedBlocksDivergence(p.Config.Name, validator, divergence)
+
+		if max(divergence, -divergence) <= p.Config.Reconciliation.Tolerance {
+			continue
+		}
+
+		discrepancies++
+
+		p.Logger.Warn().
+			Str("valoper", validator.OperatorAddress).
+			Str("moniker", validator.Moniker).
+			Int64("local", signatureInfo.GetNotSigned()).
+			Int64("on_chain", validator.SigningInfo.MissedBlocksCounter).
+			Int64("divergence", divergence).
+			Msg("Local missed blocks counter differs from the on-chain one")
+
+		// if a validator has fewer missed blocks locally, it's unknown which blocks are wrong
+		if !p.Config.Reconciliation.Refetch.Bool || divergence < 0 {
+			continue
+		}
+
+		heights, err := p.GetMissedHeights(validator, signatureInfo.GetNotSigned())
+		if err != nil {
+			return err
+		}
+
+		toRefetch = append(toRefetch, heights...)
+	}
+
+	p.Logger.Info().
+		Int("discrepancies", discrepancies).
+		Int("skipped", skipped).
+		Msg("Reconciled missed blocks with signing infos")
+
+	if len(toRefetch) == 0 {
+		return nil
+	}
+
+	p.Logger.Info().
+		Int("count", len(toRefetch)).
+		Msg("Removing blocks with local missed blocks not matching the on-chain ones to re-fetch them")
+
+	return p.StateManager.RemoveBlocks(toRefetch)
+}
+
+// GetMissedHeights returns the heights within the slashing window the validator
+// is counted as missed at locally.
+func (p *ReconciliationPopulator) GetMissedHeights(validator *types.Validator, count int64) ([]int64, error) {
+	missedBlocks, err := p.StateManager.FindLastMissedBlocks(validator.ConsensusAddressHex, count)
+	if err != nil {
+		return nil, err
+	}
+
+	windowStart := p.StateManager.GetLastBlockHeight() - p.StateManager.GetBlocksToCheck()
+	heights := make([]int64, 0, len(missedBlocks))
+
+	for _, missedBlock := range missedBlocks {
+		if missedBlock.Height > windowStart {
+			heights = append(heights, missedBlock.Height)
+		}
+	}
+
+	return heights, nil
+}
+
+func (p *ReconciliationPopulator) Enabled() bool {
+	return true
+}
+
+func (p *ReconciliationPopulator) Name() constants.PopulatorType {
+	return constants.PopulatorReconciliation
+}
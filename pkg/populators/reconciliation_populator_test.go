@@ -0,0 +1,177 @@
+package populators
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/snapshot"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func getReconciliationTestPopulator(
+	t *testing.T,
+	refetch bool,
+	onChainMissed int64,
+) *ReconciliationPopulator {
+	t.Helper()
+
+	config := &configPkg.ChainConfig{
+		Name:           "chain",
+		BlocksWindow:   5,
+		StoreBlocks:    10,
+		FirstBlock:     1,
+		Reconciliation: configPkg.ReconciliationConfig{Tolerance: 1, Refetch: null.BoolFrom(refetch)},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+
+	// the validator has missed blocks 7, 8 and 9, the last 5 blocks are 6-10
+	for height := int64(1); height <= 10; height++ {
+		signatures := map[string]int32{"address": constants.ValidatorSigned}
+		if height >= 7 && height <= 9 {
+			signatures = map[string]int32{}
+		}
+
+		require.NoError(t, stateManager.AddBlock(&types.Block{
+			Height:     height,
+			Time:       time.Unix(height, 0),
+			Signatures: signatures,
+			Validators: map[string]bool{"address": true},
+		}))
+	}
+
+	stateManager.SetValidators(types.ValidatorsMap{
+		"validator": {
+			OperatorAddress:     "validator",
+			ConsensusAddressHex: "address",
+			SigningInfo:         &types.SigningInfo{MissedBlocksCounter: onChainMissed},
+		},
+	})
+
+	return NewReconciliationPopulator(config, stateManager, metricsManager, *logger)
+}
+
+func TestReconciliationPopulatorWithinTolerance(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, true, 2)
+	require.NoError(t, populator.Populate())
+
+	for height := int64(1); height <= 10; height++ {
+		require.True(t, populator.StateManager.HasBlockAtHeight(height))
+	}
+}
+
+func TestReconciliationPopulatorRefetchDisabled(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, false, 0)
+	require.NoError(t, populator.Populate())
+
+	for height := int64(1); height <= 10; height++ {
+		require.True(t, populator.StateManager.HasBlockAtHeight(height))
+	}
+}
+
+func TestReconciliationPopulatorFewerLocalMissedBlocks(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, true, 5)
+	require.NoError(t, populator.Populate())
+
+	for height := int64(1); height <= 10; height++ {
+		require.True(t, populator.StateManager.HasBlockAtHeight(height))
+	}
+}
+
+func TestReconciliationPopulatorRefetch(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, true, 0)
+	require.NoError(t, populator.Populate())
+
+	for height := int64(1); height <= 10; height++ {
+		missed := height >= 7 && height <= 9
+		require.Equal(t, !missed, populator.StateManager.HasBlockAtHeight(height))
+	}
+
+	require.Equal(
+		t,
+		[]int64{9, 8, 7},
+		populator.StateManager.GetMissingBlocksSinceLatest(10, 1),
+	)
+
+	missedBlocks, err := populator.StateManager.FindLastMissedBlocks("address", 10)
+	require.NoError(t, err)
+	require.Empty(t, missedBlocks)
+}
+
+func TestReconciliationPopulatorSkipsNotComparable(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, true, 0)
+	populator.StateManager.SetValidators(types.ValidatorsMap{
+		"no-signing-info": {OperatorAddress: "no-signing-info", ConsensusAddressHex: "address"},
+		"jailed": {
+			OperatorAddress:     "jailed",
+			ConsensusAddressHex: "address",
+			Jailed:              true,
+			SigningInfo:         &types.SigningInfo{},
+		},
+		"tombstoned": {
+			OperatorAddress:     "tombstoned",
+			ConsensusAddressHex: "address",
+			SigningInfo:         &types.SigningInfo{Tombstoned: true},
+		},
+		// was not active during the whole window, so the local counter is taken from signing info
+		"inactive": {
+			OperatorAddress:     "inactive",
+			ConsensusAddressHex: "other",
+			SigningInfo:         &types.SigningInfo{MissedBlocksCounter: 100},
+		},
+	})
+
+	require.NoError(t, populator.Populate())
+
+	for height := int64(1); height <= 10; height++ {
+		require.True(t, populator.StateManager.HasBlockAtHeight(height))
+	}
+}
+
+func TestReconciliationPopulatorSkipsMissingBlocks(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, true, 0)
+
+	// as if block 6 was not fetched yet, so the window cannot be counted
+	require.NoError(t, populator.StateManager.RemoveBlocks([]int64{6}))
+	require.NoError(t, populator.Populate())
+
+	for height := int64(7); height <= 10; height++ {
+		require.True(t, populator.StateManager.HasBlockAtHeight(height))
+	}
+}
+
+func TestReconciliationPopulatorBase(t *testing.T) {
+	t.Parallel()
+
+	populator := getReconciliationTestPopulator(t, true, 0)
+	require.True(t, populator.Enabled())
+	require.Equal(t, constants.PopulatorType(constants.PopulatorReconciliation), populator.Name())
+}
@@ -4,8 +4,10 @@ import (
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/data"
+	"main/pkg/events"
 	"main/pkg/metrics"
 	"main/pkg/state"
+	"main/pkg/types"
 
 	"github.com/rs/zerolog"
 )
@@ -15,7 +17,13 @@ type SlashingParamsPopulator struct {
 	DataManager    *data.Manager
 	StateManager   *state.Manager
 	MetricsManager *metrics.Manager
+	OnReport       func(height int64, report *types.Report)
 	Logger         zerolog.Logger
+
+	// Fetched is whether the params were fetched before. On the first fetch, they are
+	// compared with the ones stored in the database, if any, otherwise they overwrite
+	// the params from config and are not reported as a change.
+	Fetched bool
 }
 
 func NewSlashingParamsPopulator(
@@ -23,6 +31,7 @@ func NewSlashingParamsPopulator(
 	dataManager *data.Manager,
 	stateManager *state.Manager,
 	metricsManager *metrics.Manager,
+	onReport func(height int64, report *types.Report),
 	logger zerolog.Logger,
 ) *SlashingParamsPopulator {
 	return &SlashingParamsPopulator{
@@ -30,6 +39,7 @@ func NewSlashingParamsPopulator(
 		DataManager:    dataManager,
 		StateManager:   stateManager,
 		MetricsManager: metricsManager,
+		OnReport:       onReport,
 		Logger: logger.With().
 			Str("component", "slashing_params_populator").
 			Logger(),
@@ -45,14 +55,38 @@ func (p *SlashingParamsPopulator) Populate() error {
 		return err
 	}
 
-	minSignedPerWindow := params.Params.MinSignedPerWindow.MustFloat64()
+	oldParams := p.Config.GetSlashingParams()
+	firstFetch := !p.Fetched
+
+	if firstFetch {
+		if storedParams, found, err := p.StateManager.LoadSlashingParams(); err != nil {
+			p.Logger.Warn().Err(err).Msg("Could not load stored slashing params")
+		} else if found {
+			oldParams = storedParams
+			p.Fetched = true
+		}
+	}
+
+	newParams := types.SlashingParams{
+		SignedBlocksWindow:      params.Params.SignedBlocksWindow,
+		MinSignedPerWindow:      params.Params.MinSignedPerWindow.MustFloat64(),
+		DowntimeJailDuration:    params.Params.DowntimeJailDuration,
+		SlashFractionDowntime:   params.Params.SlashFractionDowntime.MustFloat64(),
+		SlashFractionDoubleSign: params.Params.SlashFractionDoubleSign.MustFloat64(),
+	}
 
-	p.Config.BlocksWindow = params.Params.SignedBlocksWindow
-	p.Config.MinSignedPerWindow = minSignedPerWindow
+	p.Config.SetSlashingParams(newParams)
+
+	if firstFetch || oldParams != newParams {
+		p.StateManager.SaveSlashingParams(newParams)
+	}
 
 	p.Logger.Info().
 		Int64("blocks_window", p.Config.BlocksWindow).
 		Float64("min_signed_per_window", p.Config.MinSignedPerWindow).
+		Float64("slash_fraction_downtime", p.Config.SlashFractionDowntime).
+		Float64("slash_fraction_double_sign", p.Config.SlashFractionDoubleSign).
+		Dur("downtime_jail_duration", p.Config.DowntimeJailDuration).
 		Msg("Got slashing params")
 
 	p.MetricsManager.LogSlashingParams(
@@ -61,7 +95,21 @@ func (p *SlashingParamsPopulator) Populate() error {
 		p.Config.MinSignedPerWindow,
 		p.Config.StoreBlocks,
 	)
-	p.Config.RecalculateMissedBlocksGroups()
+
+	if p.Fetched && oldParams != newParams && p.OnReport != nil {
+		p.Logger.Info().
+			Interface("old", oldParams).
+			Interface("new", newParams).
+			Msg("Slashing params have changed")
+
+		p.OnReport(p.StateManager.GetLastBlockHeight(), &types.Report{
+			Events: []types.ReportEvent{
+				events.SlashingParamsChanged{OldParams: oldParams, NewParams: newParams},
+			},
+		})
+	}
+
+	p.Fetched = true
 
 	return nil
 }
@@ -0,0 +1,200 @@
+package populators
+
+import (
+	"main/assets"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/data"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	"main/pkg/grpc"
+	"main/pkg/http"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/snapshot"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func getSlashingParamsTestPopulator(reports *[]*types.Report) *SlashingParamsPopulator {
+	logger := loggerPkg.GetNopLogger()
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(databasePkg.NewStubDatabaseClient())
+
+	return getSlashingParamsTestPopulatorWithDatabase(reports, database)
+}
+
+func getSlashingParamsTestPopulatorWithDatabase(
+	reports *[]*types.Report,
+	database *databasePkg.Database,
+) *SlashingParamsPopulator {
+	config := &configPkg.ChainConfig{
+		Name:               "chain",
+		FetcherType:        constants.FetcherTypeCosmosLCD,
+		LCDEndpoints:       []string{"https://example.com"},
+		BlocksWindow:       100,
+		MinSignedPerWindow: 0.5,
+		Thresholds:         []float64{0, 50, 100},
+		EmojisStart:        []string{"🟡", "🔴"},
+		EmojisEnd:          []string{"🟢", "🟡"},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+
+	dataManager := data.NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+
+	return NewSlashingParamsPopulator(
+		config,
+		dataManager,
+		stateManager,
+		metricsManager,
+		func(height int64, report *types.Report) {
+			*reports = append(*reports, report)
+		},
+		*logger,
+	)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSlashingParamsPopulatorFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/slashing/v1beta1/params",
+		httpmock.NewBytesResponder(500, []byte("error")),
+	)
+
+	reports := []*types.Report{}
+	populator := getSlashingParamsTestPopulator(&reports)
+
+	err := populator.Populate()
+	require.Error(t, err)
+	require.Equal(t, int64(100), populator.Config.BlocksWindow)
+	require.False(t, populator.Fetched)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSlashingParamsPopulatorChanged(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/slashing/v1beta1/params",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-slashing-params.json")),
+	)
+
+	reports := []*types.Report{}
+	populator := getSlashingParamsTestPopulator(&reports)
+
+	// the first fetch overwrites the config values and is not reported
+	err := populator.Populate()
+	require.NoError(t, err)
+	require.Empty(t, reports)
+	require.Equal(t, types.SlashingParams{
+		SignedBlocksWindow:      10000,
+		MinSignedPerWindow:      0.05,
+		DowntimeJailDuration:    10 * time.Minute,
+		SlashFractionDowntime:   0.0001,
+		SlashFractionDoubleSign: 0.05,
+	}, populator.Config.GetSlashingParams())
+	require.Equal(t, int64(5000), populator.Config.MissedBlocksGroups[1].Start)
+
+	err = populator.Populate()
+	require.NoError(t, err)
+	require.Empty(t, reports)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/slashing/v1beta1/params",
+		httpmock.NewStringResponder(200, `{
+			"params": {
+				"signed_blocks_window": "20000",
+				"min_signed_per_window": "0.050000000000000000",
+				"downtime_jail_duration": "600s",
+				"slash_fraction_double_sign": "0.050000000000000000",
+				"slash_fraction_downtime": "0.000100000000000000"
+			}
+		}`),
+	)
+
+	err = populator.Populate()
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Len(t, reports[0].Events, 1)
+	require.Equal(t, int64(10000), populator.Config.MissedBlocksGroups[1].Start)
+
+	event, ok := reports[0].Events[0].(events.SlashingParamsChanged)
+	require.True(t, ok)
+	require.Equal(t, []string{"signed blocks window: 10000 -> 20000"}, event.GetChanges())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSlashingParamsPopulatorChangedWhileStopped(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/slashing/v1beta1/params",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("lcd-slashing-params.json")),
+	)
+
+	logger := loggerPkg.GetNopLogger()
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	reports := []*types.Report{}
+	require.NoError(t, getSlashingParamsTestPopulatorWithDatabase(&reports, database).Populate())
+	require.Empty(t, reports)
+
+	// as if the app was restarted with the same params on chain
+	require.NoError(t, getSlashingParamsTestPopulatorWithDatabase(&reports, database).Populate())
+	require.Empty(t, reports)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/slashing/v1beta1/params",
+		httpmock.NewStringResponder(200, `{
+			"params": {
+				"signed_blocks_window": "20000",
+				"min_signed_per_window": "0.050000000000000000",
+				"downtime_jail_duration": "600s",
+				"slash_fraction_double_sign": "0.050000000000000000",
+				"slash_fraction_downtime": "0.000100000000000000"
+			}
+		}`),
+	)
+
+	// as if the app was restarted after the params have changed
+	populator := getSlashingParamsTestPopulatorWithDatabase(&reports, database)
+	require.NoError(t, populator.Populate())
+	require.True(t, populator.Fetched)
+	require.Len(t, reports, 1)
+
+	event, ok := reports[0].Events[0].(events.SlashingParamsChanged)
+	require.True(t, ok)
+	require.Equal(t, []string{"signed blocks window: 10000 -> 20000"}, event.GetChanges())
+
+	stored, found, err := populator.StateManager.LoadSlashingParams()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, int64(20000), stored.SignedBlocksWindow)
+}
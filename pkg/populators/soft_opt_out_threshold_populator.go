@@ -0,0 +1,59 @@
+package populators
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/data"
+	"main/pkg/state"
+
+	"github.com/rs/zerolog"
+)
+
+type SoftOptOutThresholdPopulator struct {
+	Config       *configPkg.ChainConfig
+	DataManager  *data.Manager
+	StateManager *state.Manager
+	Logger       zerolog.Logger
+}
+
+func NewSoftOptOutThresholdPopulator(
+	config *configPkg.ChainConfig,
+	dataManager *data.Manager,
+	stateManager *state.Manager,
+	logger zerolog.Logger,
+) *SoftOptOutThresholdPopulator {
+	return &SoftOptOutThresholdPopulator{
+		Config:       config,
+		DataManager:  dataManager,
+		StateManager: stateManager,
+		Logger: logger.With().
+			Str("component", "soft_opt_out_threshold_populator").
+			Logger(),
+	}
+}
+func (p *SoftOptOutThresholdPopulator) Populate() error {
+	threshold, err := p.DataManager.GetConsumerSoftOptOutThreshold(p.StateManager.GetLastBlockHeight() - 1)
+	if err != nil {
+		p.Logger.Warn().
+			Err(err).
+			Msg("Error updating soft opt-out threshold")
+
+		return err
+	}
+
+	p.Config.ConsumerSoftOptOut = threshold
+
+	p.Logger.Info().
+		Float64("soft_opt_out_threshold", p.Config.ConsumerSoftOptOut).
+		Msg("Got soft opt-out threshold")
+
+	return nil
+}
+
+func (p *SoftOptOutThresholdPopulator) Enabled() bool {
+	return p.Config.IsConsumer.Bool
+}
+
+func (p *SoftOptOutThresholdPopulator) Name() constants.PopulatorType {
+	return constants.PopulatorSoftOptOutThreshold
+}
@@ -0,0 +1,146 @@
+package populators
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/state"
+	"main/pkg/types"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// UnjailRemindersPopulator reminds about jailed validators that can unjail but haven't yet,
+// once they become eligible and then every constants.UnjailReminderRepeatInterval,
+// up to constants.UnjailReminderMaxCount times. Validators that could unjail long ago are
+// reminded about only if someone is subscribed to them. Reminders are persisted,
+// so they are not sent again after a restart.
+type UnjailRemindersPopulator struct {
+	Config       *configPkg.ChainConfig
+	StateManager *state.Manager
+	OnReport     func(height int64, report *types.Report)
+	Logger       zerolog.Logger
+
+	// Reminders are the reminders sent about each validator, by its operator address,
+	// they are loaded from the database on the first run.
+	Reminders types.UnjailReminders
+	loaded    bool
+}
+
+func NewUnjailRemindersPopulator(
+	config *configPkg.ChainConfig,
+	stateManager *state.Manager,
+	onReport func(height int64, report *types.Report),
+	logger zerolog.Logger,
+) *UnjailRemindersPopulator {
+	return &UnjailRemindersPopulator{
+		Config:       config,
+		StateManager: stateManager,
+		OnReport:     onReport,
+		Logger: logger.With().
+			Str("component", "unjail_reminders_populator").
+			Logger(),
+		Reminders: types.UnjailReminders{},
+	}
+}
+
+func (p *UnjailRemindersPopulator) Populate() error {
+	if !p.loaded {
+		reminders, err := p.StateManager.LoadUnjailReminders()
+		if err != nil {
+			return err
+		}
+
+		p.Reminders = reminders
+		p.loaded = true
+	}
+
+	p.SendReminders(time.Now())
+	return nil
+}
+
+func (p *UnjailRemindersPopulator) SendReminders(now time.Time) {
+	report := &types.Report{Events: make([]types.ReportEvent, 0)}
+	validators := p.StateManager.GetValidators()
+	changed := false
+
+	// validators are not fetched yet, like right after a restart,
+	// so it's unknown whether the stored reminders are still relevant
+	if len(validators) == 0 {
+		return
+	}
+
+	for operatorAddress, reminder := range p.Reminders {
+		validator, ok := validators[operatorAddress]
+		if !ok || !validator.Jailed {
+			delete(p.Reminders, operatorAddress)
+			changed = true
+			continue
+		}
+
+		// jailed again after the last reminder, so it should be reminded again once it can unjail
+		if unjailTime, ok := validator.GetUnjailTime(); !ok || reminder.LastReminded.Before(unjailTime) {
+			delete(p.Reminders, operatorAddress)
+			changed = true
+		}
+	}
+
+	for operatorAddress, validator := range validators {
+		if !validator.Jailed {
+			continue
+		}
+
+		unjailTime, ok := validator.GetUnjailTime()
+		if !ok || now.Before(unjailTime) {
+			continue
+		}
+
+		reminder, reminded := p.Reminders[operatorAddress]
+		if reminded &&
+			(reminder.Count >= constants.UnjailReminderMaxCount ||
+				now.Sub(reminder.LastReminded) < constants.UnjailReminderRepeatInterval) {
+			continue
+		}
+
+		if now.Sub(unjailTime) > constants.UnjailReminderMaxAge && !p.StateManager.HasNotifiers(operatorAddress) {
+			continue
+		}
+
+		p.Logger.Info().
+			Str("valoper", operatorAddress).
+			Str("moniker", validator.Moniker).
+			Time("jailed_until", unjailTime).
+			Int("reminder", reminder.Count+1).
+			Msg("Validator can unjail but is still jailed")
+
+		p.Reminders[operatorAddress] = types.UnjailReminder{
+			LastReminded: now,
+			Count:        reminder.Count + 1,
+		}
+		changed = true
+
+		report.Events = append(report.Events, events.ValidatorCanUnjail{
+			Validator:   validator,
+			JailedUntil: unjailTime,
+		})
+	}
+
+	if changed {
+		p.StateManager.SaveUnjailReminders(p.Reminders)
+	}
+
+	if report.Empty() {
+		return
+	}
+
+	p.OnReport(p.StateManager.GetLastBlockHeight(), report)
+}
+
+func (p *UnjailRemindersPopulator) Enabled() bool {
+	return p.OnReport != nil
+}
+
+func (p *UnjailRemindersPopulator) Name() constants.PopulatorType {
+	return constants.PopulatorUnjailReminders
+}
@@ -0,0 +1,267 @@
+package populators
+
+import (
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/snapshot"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getUnjailRemindersTestPopulator(
+	validators types.ValidatorsMap,
+	reports *[]*types.Report,
+) *UnjailRemindersPopulator {
+	config := &configPkg.ChainConfig{Name: "chain"}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(databasePkg.NewStubDatabaseClient())
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	stateManager.SetValidators(validators)
+
+	return NewUnjailRemindersPopulator(config, stateManager, func(height int64, report *types.Report) {
+		*reports = append(*reports, report)
+	}, *logger)
+}
+
+func TestUnjailRemindersPopulatorSkipsNotEligible(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	reports := []*types.Report{}
+	populator := getUnjailRemindersTestPopulator(types.ValidatorsMap{
+		"active": {OperatorAddress: "active", SigningInfo: &types.SigningInfo{
+			JailedUntil: now.Add(-time.Hour),
+		}},
+		"still-jailed": {OperatorAddress: "still-jailed", Jailed: true, SigningInfo: &types.SigningInfo{
+			JailedUntil: now.Add(time.Hour),
+		}},
+		"tombstoned": {OperatorAddress: "tombstoned", Jailed: true, SigningInfo: &types.SigningInfo{
+			Tombstoned:  true,
+			JailedUntil: now.Add(-time.Hour),
+		}},
+		"no-signing-info": {OperatorAddress: "no-signing-info", Jailed: true},
+	}, &reports)
+
+	populator.SendReminders(now)
+	require.Empty(t, reports)
+}
+
+func TestUnjailRemindersPopulatorRemindsAndRepeats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	jailedUntil := now.Add(-time.Minute)
+	reports := []*types.Report{}
+	validator := &types.Validator{
+		OperatorAddress: "validator",
+		Jailed:          true,
+		SigningInfo:     &types.SigningInfo{JailedUntil: jailedUntil},
+	}
+	populator := getUnjailRemindersTestPopulator(types.ValidatorsMap{"validator": validator}, &reports)
+
+	populator.SendReminders(now)
+	require.Len(t, reports, 1)
+	require.Len(t, reports[0].Events, 1)
+	require.Equal(t, constants.EventValidatorCanUnjail, reports[0].Events[0].Type())
+
+	event, ok := reports[0].Events[0].(events.ValidatorCanUnjail)
+	require.True(t, ok)
+	require.Equal(t, jailedUntil, event.JailedUntil)
+
+	// not reminding again until the repeat interval passes
+	populator.SendReminders(now.Add(time.Hour))
+	require.Len(t, reports, 1)
+
+	populator.SendReminders(now.Add(constants.UnjailReminderRepeatInterval))
+	require.Len(t, reports, 2)
+}
+
+func TestUnjailRemindersPopulatorJailedAgain(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	reports := []*types.Report{}
+	validator := &types.Validator{
+		OperatorAddress: "validator",
+		Jailed:          true,
+		SigningInfo:     &types.SigningInfo{JailedUntil: now.Add(-time.Minute)},
+	}
+	populator := getUnjailRemindersTestPopulator(types.ValidatorsMap{"validator": validator}, &reports)
+
+	populator.SendReminders(now)
+	require.Len(t, reports, 1)
+
+	// unjailed, then jailed again and can unjail again an hour later
+	validator.SigningInfo.JailedUntil = now.Add(time.Hour)
+	populator.SendReminders(now.Add(30 * time.Minute))
+	require.Len(t, reports, 1)
+	require.Empty(t, populator.Reminders)
+
+	populator.SendReminders(now.Add(2 * time.Hour))
+	require.Len(t, reports, 2)
+}
+
+func TestUnjailRemindersPopulatorUnjailed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	reports := []*types.Report{}
+	validator := &types.Validator{
+		OperatorAddress: "validator",
+		Jailed:          true,
+		SigningInfo:     &types.SigningInfo{JailedUntil: now.Add(-time.Minute)},
+	}
+	populator := getUnjailRemindersTestPopulator(types.ValidatorsMap{"validator": validator}, &reports)
+
+	populator.SendReminders(now)
+	require.Len(t, reports, 1)
+
+	validator.Jailed = false
+	populator.SendReminders(now.Add(time.Minute))
+	require.Len(t, reports, 1)
+	require.Empty(t, populator.Reminders)
+}
+
+func TestUnjailRemindersPopulatorMaxCount(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	reports := []*types.Report{}
+	validator := &types.Validator{
+		OperatorAddress: "validator",
+		Jailed:          true,
+		SigningInfo:     &types.SigningInfo{JailedUntil: now.Add(-time.Minute)},
+	}
+	populator := getUnjailRemindersTestPopulator(types.ValidatorsMap{"validator": validator}, &reports)
+	// someone is subscribed, so it's reminded about even after UnjailReminderMaxAge
+	populator.StateManager.AddNotifier("validator", constants.TelegramReporterName, "user", "user")
+
+	for index := 0; index < constants.UnjailReminderMaxCount+3; index++ {
+		populator.SendReminders(now.Add(time.Duration(index) * constants.UnjailReminderRepeatInterval))
+	}
+
+	require.Len(t, reports, constants.UnjailReminderMaxCount)
+}
+
+func TestUnjailRemindersPopulatorSkipsAbandoned(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	reports := []*types.Report{}
+	jailedUntil := now.Add(-constants.UnjailReminderMaxAge - time.Hour)
+	populator := getUnjailRemindersTestPopulator(types.ValidatorsMap{
+		"abandoned": {
+			OperatorAddress: "abandoned",
+			Jailed:          true,
+			SigningInfo:     &types.SigningInfo{JailedUntil: jailedUntil},
+		},
+		"subscribed": {
+			OperatorAddress: "subscribed",
+			Jailed:          true,
+			SigningInfo:     &types.SigningInfo{JailedUntil: jailedUntil},
+		},
+	}, &reports)
+	populator.StateManager.AddNotifier("subscribed", constants.TelegramReporterName, "user", "user")
+
+	populator.SendReminders(now)
+	require.Len(t, reports, 1)
+	require.Len(t, reports[0].Events, 1)
+	require.Equal(t, "subscribed", reports[0].Events[0].GetValidator().OperatorAddress)
+}
+
+func TestUnjailRemindersPopulatorPersisted(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{Name: "chain"}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	stateManager.SetValidators(types.ValidatorsMap{
+		"validator": {
+			OperatorAddress: "validator",
+			Jailed:          true,
+			SigningInfo:     &types.SigningInfo{JailedUntil: time.Now().Add(-time.Minute)},
+		},
+	})
+
+	reports := []*types.Report{}
+	onReport := func(height int64, report *types.Report) {
+		reports = append(reports, report)
+	}
+
+	require.NoError(t, NewUnjailRemindersPopulator(config, stateManager, onReport, *logger).Populate())
+	require.Len(t, reports, 1)
+
+	// as if the app was restarted
+	populator := NewUnjailRemindersPopulator(config, stateManager, onReport, *logger)
+	require.NoError(t, populator.Populate())
+	require.Len(t, reports, 1)
+	require.Equal(t, 1, populator.Reminders["validator"].Count)
+}
+
+func TestUnjailRemindersPopulatorValidatorsNotLoaded(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{Name: "chain"}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	validators := types.ValidatorsMap{
+		"validator": {
+			OperatorAddress: "validator",
+			Jailed:          true,
+			SigningInfo:     &types.SigningInfo{JailedUntil: time.Now().Add(-time.Minute)},
+		},
+	}
+
+	reports := []*types.Report{}
+	onReport := func(height int64, report *types.Report) {
+		reports = append(reports, report)
+	}
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	stateManager.SetValidators(validators)
+	require.NoError(t, NewUnjailRemindersPopulator(config, stateManager, onReport, *logger).Populate())
+	require.Len(t, reports, 1)
+
+	// as if the app was restarted and validators are not fetched yet
+	stateManager = statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	populator := NewUnjailRemindersPopulator(config, stateManager, onReport, *logger)
+	require.NoError(t, populator.Populate())
+	require.Len(t, reports, 1)
+	require.Equal(t, 1, populator.Reminders["validator"].Count)
+
+	stateManager.SetValidators(validators)
+	require.NoError(t, populator.Populate())
+	require.Len(t, reports, 1)
+	require.Equal(t, 1, populator.Reminders["validator"].Count)
+
+	reminders, err := stateManager.LoadUnjailReminders()
+	require.NoError(t, err)
+	require.Equal(t, 1, reminders["validator"].Count)
+}
@@ -0,0 +1,188 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/metrics"
+	snapshotPkg "main/pkg/snapshot"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"github.com/rs/zerolog"
+)
+
+type Event struct {
+	Height int64
+	Event  types.ReportEvent
+}
+
+// Replayer runs stored blocks through snapshots and reports the same way
+// it's done when processing new blocks, to see which events a config would produce.
+type Replayer struct {
+	logger          zerolog.Logger
+	config          *configPkg.ChainConfig
+	source          Source
+	stateManager    *statePkg.Manager
+	snapshotManager *snapshotPkg.Manager
+}
+
+func NewReplayer(
+	logger zerolog.Logger,
+	config *configPkg.ChainConfig,
+	source Source,
+) *Replayer {
+	replayLogger := logger.With().Str("component", "replayer").Logger()
+	metricsManager := metrics.NewManager(replayLogger, configPkg.MetricsConfig{})
+	snapshotManager := snapshotPkg.NewManager(replayLogger, config, metricsManager)
+
+	return &Replayer{
+		logger:          replayLogger,
+		config:          config,
+		source:          source,
+		stateManager:    statePkg.NewManager(replayLogger, config, metricsManager, snapshotManager, nil),
+		snapshotManager: snapshotManager,
+	}
+}
+
+func (r *Replayer) Replay() ([]Event, error) {
+	validators, err := r.source.GetValidators()
+	if err != nil {
+		return nil, err
+	}
+
+	// validators are only known at the moment they were stored, so the jailed status
+	// and signing infos are dropped to count missed blocks only from the blocks
+	replayValidators := make(types.ValidatorsMap, len(validators))
+	for _, validator := range validators {
+		validatorCopy := *validator
+		validatorCopy.Jailed = false
+		validatorCopy.SigningInfo = nil
+		replayValidators[validator.OperatorAddress] = &validatorCopy
+	}
+
+	r.stateManager.SetValidators(replayValidators)
+
+	heights, err := r.source.GetHeights()
+	if err != nil {
+		return nil, err
+	}
+
+	replayedEvents := make([]Event, 0)
+
+	for _, chunk := range utils.SplitIntoChunks(heights, constants.StateBlocksLoadBatchSize) {
+		blocks, err := r.source.GetBlocksInRange(chunk[0], chunk[len(chunk)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, height := range chunk {
+			block, ok := blocks[height]
+			if !ok {
+				return nil, fmt.Errorf("block at height %d is not found", height)
+			}
+
+			blockEvents, err := r.ProcessBlock(block)
+			if err != nil {
+				return nil, err
+			}
+
+			replayedEvents = append(replayedEvents, blockEvents...)
+		}
+	}
+
+	r.logger.Info().
+		Int("blocks", len(heights)).
+		Int("events", len(replayedEvents)).
+		Msg("Replayed blocks")
+
+	return replayedEvents, nil
+}
+
+func (r *Replayer) ProcessBlock(block *types.Block) ([]Event, error) {
+	if err := r.stateManager.ReplayBlock(block); err != nil {
+		return nil, err
+	}
+
+	if r.snapshotManager.HasNewerSnapshot() &&
+		block.Height-r.snapshotManager.GetNewerHeight() < r.config.SnapshotsInterval {
+		return nil, nil
+	}
+
+	blocksCount := r.stateManager.GetBlocksCountSinceLatest(r.config.BlocksWindow)
+	neededBlocks := utils.MinInt64(r.config.BlocksWindow, r.stateManager.GetLastBlockHeight()-r.config.FirstBlock+1)
+
+	if blocksCount < neededBlocks {
+		return nil, nil
+	}
+
+	snapshot, err := r.stateManager.GetSnapshot()
+	if err != nil {
+		r.logger.Debug().Err(err).Int64("height", block.Height).Msg("Could not generate snapshot, skipping")
+		return nil, nil //nolint:nilerr // gaps in stored blocks are skipped, as when processing new blocks
+	}
+
+	hasOlderSnapshot := r.snapshotManager.HasNewerSnapshot()
+	r.snapshotManager.CommitNewSnapshot(block.Height, snapshot)
+
+	if !hasOlderSnapshot {
+		return nil, nil
+	}
+
+	report, err := r.snapshotManager.GetReport()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(report.Events, func(event types.ReportEvent) Event {
+		return Event{Height: block.Height, Event: event}
+	}), nil
+}
+
+func FormatEvent(event Event) string {
+	validator := event.Event.GetValidator()
+
+	if groupChanged, ok := event.Event.(events.ValidatorGroupChanged); ok {
+		return fmt.Sprintf(
+			"%d\t%s\t%s\t%s: %d -> %d",
+			event.Height,
+			event.Event.Type(),
+			validator.Moniker,
+			groupChanged.GetDescription(),
+			groupChanged.MissedBlocksBefore,
+			groupChanged.MissedBlocksAfter,
+		)
+	}
+
+	return fmt.Sprintf("%d\t%s\t%s", event.Height, event.Event.Type(), validator.Moniker)
+}
+
+// PrintEvents prints events one per line, followed by the count of events of each type.
+func PrintEvents(output io.Writer, replayedEvents []Event) error {
+	counts := map[constants.EventName]int{}
+
+	for _, event := range replayedEvents {
+		counts[event.Event.Type()]++
+
+		if _, err := fmt.Fprintln(output, FormatEvent(event)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(output, "Total events: %d\n", len(replayedEvents)); err != nil {
+		return err
+	}
+
+	for _, eventName := range constants.GetEventNames() {
+		if count, ok := counts[eventName]; ok {
+			if _, err := fmt.Fprintf(output, "%s: %d\n", eventName, count); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package replay
+
+import (
+	"bytes"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	databasePkg "main/pkg/database"
+	"main/pkg/events"
+	loggerPkg "main/pkg/logger"
+	snapshotPkg "main/pkg/snapshot"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+)
+
+func getReplayConfig(snapshotsInterval int64) *configPkg.ChainConfig {
+	config := &configPkg.ChainConfig{
+		Name:              "chain",
+		BlocksWindow:      10,
+		FirstBlock:        1,
+		SnapshotsInterval: snapshotsInterval,
+		Thresholds:        []float64{0, 50, 100},
+		EmojisStart:       []string{"x", "y"},
+		EmojisEnd:         []string{"a", "b"},
+	}
+	config.RecalculateMissedBlocksGroups()
+	return config
+}
+
+// getReplayDump returns 40 blocks, with the first validator missing blocks 21-30.
+func getReplayDump() *Dump {
+	validators := types.Validators{
+		{OperatorAddress: "first", ConsensusAddressHex: "first", Moniker: "first", Jailed: true, VotingPower: math.LegacyNewDec(1)},
+		{OperatorAddress: "second", ConsensusAddressHex: "second", Moniker: "second", VotingPower: math.LegacyNewDec(1)},
+	}
+
+	blocks := make([]*types.Block, 0)
+	for height := int64(1); height <= 40; height++ {
+		block := &types.Block{
+			Height:     height,
+			Time:       time.Unix(height, 0),
+			Signatures: map[string]int32{"first": constants.ValidatorSigned, "second": constants.ValidatorSigned},
+			Validators: map[string]bool{"first": true, "second": true},
+		}
+
+		if height > 20 && height <= 30 {
+			block.Signatures["first"] = 1
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return &Dump{Validators: validators, Blocks: blocks}
+}
+
+func TestReplayEveryBlock(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	replayer := NewReplayer(*logger, getReplayConfig(1), NewDumpSource(getReplayDump()))
+
+	replayedEvents, err := replayer.Replay()
+	require.NoError(t, err)
+	require.Len(t, replayedEvents, 2)
+
+	require.Equal(t, int64(25), replayedEvents[0].Height)
+	skipping, ok := replayedEvents[0].Event.(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	require.Equal(t, "first", skipping.Validator.OperatorAddress)
+	require.True(t, skipping.IsIncreasing())
+
+	require.Equal(t, int64(36), replayedEvents[1].Height)
+	recovering, ok := replayedEvents[1].Event.(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	require.False(t, recovering.IsIncreasing())
+}
+
+func TestReplaySnapshotsInterval(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	replayer := NewReplayer(*logger, getReplayConfig(5), NewDumpSource(getReplayDump()))
+
+	replayedEvents, err := replayer.Replay()
+	require.NoError(t, err)
+	// snapshots are taken at heights 1, 6, 11 and so on
+	require.Len(t, replayedEvents, 2)
+	require.Equal(t, int64(26), replayedEvents[0].Height)
+	require.Equal(t, int64(36), replayedEvents[1].Height)
+}
+
+func TestReplayDatabaseNoSnapshot(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	replayer := NewReplayer(*logger, getReplayConfig(1), NewDatabaseSource(database, "chain"))
+
+	_, err := replayer.Replay()
+	require.Error(t, err)
+}
+
+func TestReplayDatabase(t *testing.T) {
+	t.Parallel()
+
+	logger := loggerPkg.GetNopLogger()
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{
+		Type: constants.DatabaseTypeSqlite,
+		Path: t.TempDir() + "/database.sqlite",
+	})
+	database.Init()
+
+	dump := getReplayDump()
+	for _, block := range dump.Blocks {
+		require.NoError(t, database.InsertBlock("chain", block))
+	}
+
+	entries := types.Entries{}
+	for _, validator := range dump.Validators {
+		entries[validator.OperatorAddress] = &types.Entry{Validator: validator}
+	}
+
+	require.NoError(t, database.SetSnapshot("chain", &snapshotPkg.Info{
+		Height:   40,
+		Snapshot: snapshotPkg.Snapshot{Entries: entries},
+	}))
+
+	replayer := NewReplayer(*logger, getReplayConfig(1), NewDatabaseSource(database, "chain"))
+
+	replayedEvents, err := replayer.Replay()
+	require.NoError(t, err)
+	require.Len(t, replayedEvents, 2)
+	require.Equal(t, int64(25), replayedEvents[0].Height)
+	require.Equal(t, int64(36), replayedEvents[1].Height)
+}
+
+func TestPrintEvents(t *testing.T) {
+	t.Parallel()
+
+	config := getReplayConfig(1)
+	validator := &types.Validator{Moniker: "first"}
+
+	var output bytes.Buffer
+	err := PrintEvents(&output, []Event{
+		{Height: 25, Event: events.ValidatorGroupChanged{
+			Validator:               validator,
+			MissedBlocksBefore:      4,
+			MissedBlocksAfter:       5,
+			MissedBlocksGroupBefore: config.MissedBlocksGroups[0],
+			MissedBlocksGroupAfter:  config.MissedBlocksGroups[1],
+		}},
+		{Height: 30, Event: events.ValidatorJailed{Validator: validator}},
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"25\tValidatorGroupChanged\tfirst\tis skipping blocks (> 50.0%): 4 -> 5\n"+
+			"30\tValidatorJailed\tfirst\n"+
+			"Total events: 2\n"+
+			"ValidatorJailed: 1\n"+
+			"ValidatorGroupChanged: 1\n",
+		output.String(),
+	)
+}
@@ -0,0 +1,104 @@
+package replay
+
+import (
+	databasePkg "main/pkg/database"
+	"main/pkg/types"
+	"slices"
+)
+
+// Source provides stored blocks and validators to replay.
+type Source interface {
+	GetHeights() ([]int64, error)
+	GetBlocksInRange(fromHeight, toHeight int64) (map[int64]*types.Block, error)
+	GetValidators() (types.Validators, error)
+}
+
+type DatabaseSource struct {
+	database *databasePkg.Database
+	chain    string
+}
+
+func NewDatabaseSource(database *databasePkg.Database, chain string) *DatabaseSource {
+	return &DatabaseSource{database: database, chain: chain}
+}
+
+func (s *DatabaseSource) GetHeights() ([]int64, error) {
+	times, err := s.database.GetBlocksTimes(s.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]int64, 0, len(times))
+	for height := range times {
+		heights = append(heights, height)
+	}
+
+	slices.Sort(heights)
+	return heights, nil
+}
+
+func (s *DatabaseSource) GetBlocksInRange(fromHeight, toHeight int64) (map[int64]*types.Block, error) {
+	return s.database.GetBlocksInRange(s.chain, fromHeight, toHeight)
+}
+
+// GetValidators takes validators from the last stored snapshot, as validators
+// themselves are not stored.
+func (s *DatabaseSource) GetValidators() (types.Validators, error) {
+	snapshot, err := s.database.GetLastSnapshot(s.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make(types.Validators, 0, len(snapshot.Snapshot.Entries))
+	for _, entry := range snapshot.Snapshot.Entries {
+		validators = append(validators, entry.Validator)
+	}
+
+	return validators, nil
+}
+
+// Dump is a JSON file with blocks and validators to replay.
+type Dump struct {
+	Validators types.Validators `json:"validators"`
+	Blocks     []*types.Block   `json:"blocks"`
+}
+
+type DumpSource struct {
+	blocks     map[int64]*types.Block
+	validators types.Validators
+}
+
+func NewDumpSource(dump *Dump) *DumpSource {
+	blocks := make(map[int64]*types.Block, len(dump.Blocks))
+	for _, block := range dump.Blocks {
+		blocks[block.Height] = block
+	}
+
+	return &DumpSource{blocks: blocks, validators: dump.Validators}
+}
+
+func (s *DumpSource) GetHeights() ([]int64, error) {
+	heights := make([]int64, 0, len(s.blocks))
+	for height := range s.blocks {
+		heights = append(heights, height)
+	}
+
+	slices.Sort(heights)
+	return heights, nil
+}
+
+func (s *DumpSource) GetBlocksInRange(fromHeight, toHeight int64) (map[int64]*types.Block, error) {
+	blocks := map[int64]*types.Block{}
+
+	for height, block := range s.blocks {
+		if height >= fromHeight && height <= toHeight {
+			blocks[height] = block
+		}
+	}
+
+	return blocks, nil
+}
+
+func (s *DumpSource) GetValidators() (types.Validators, error) {
+	return s.validators, nil
+}
@@ -1,8 +1,10 @@
 package discord
 
 import (
+	"errors"
 	"main/pkg/config"
 	"main/pkg/constants"
+	dataPkg "main/pkg/data"
 	"main/pkg/events"
 	"main/pkg/metrics"
 	snapshotPkg "main/pkg/snapshot"
@@ -33,6 +35,7 @@ type Reporter struct {
 	Manager          *statePkg.Manager
 	MetricsManager   *metrics.Manager
 	SnapshotManager  *snapshotPkg.Manager
+	DataManager      *dataPkg.Manager
 	TemplatesManager templatesPkg.Manager
 	Commands         map[string]*Command
 }
@@ -44,6 +47,7 @@ func NewReporter(
 	manager *statePkg.Manager,
 	metricsManager *metrics.Manager,
 	snapshotManager *snapshotPkg.Manager,
+	dataManager *dataPkg.Manager,
 ) *Reporter {
 	return &Reporter{
 		Token:            chainConfig.DiscordConfig.Token,
@@ -54,6 +58,7 @@ func NewReporter(
 		Manager:          manager,
 		MetricsManager:   metricsManager,
 		SnapshotManager:  snapshotManager,
+		DataManager:      dataManager,
 		TemplatesManager: templatesPkg.NewManager(logger, constants.DiscordReporterName),
 		Commands:         make(map[string]*Command, 0),
 		Version:          version,
@@ -92,8 +97,11 @@ func (reporter *Reporter) Init() {
 		"help":        reporter.GetHelpCommand(),
 		"notifiers":   reporter.GetNotifiersCommand(),
 		"jails":       reporter.GetJailsCommand(),
+		"evidence":    reporter.GetEvidenceCommand(),
 		"events":      reporter.GetValidatorEventsCommand(),
+		"missed":      reporter.GetMissedBlocksCommand(),
 		"jailscount":  reporter.GetJailsCountCommand(),
+		"nodes":       reporter.GetNodesCommand(),
 	}
 
 	for query := range reporter.Commands {
@@ -207,6 +215,16 @@ func (reporter *Reporter) Start() {
 
 }
 
+func (reporter *Reporter) Stop() {
+	if reporter.DiscordSession == nil {
+		return
+	}
+
+	if err := reporter.DiscordSession.Close(); err != nil {
+		reporter.Logger.Warn().Err(err).Msg("Error closing Discord session")
+	}
+}
+
 func (reporter *Reporter) Enabled() bool {
 	return reporter.Token != "" && reporter.Guild != "" && reporter.Channel != ""
 }
@@ -217,6 +235,12 @@ func (reporter *Reporter) Name() constants.ReporterName {
 
 func (reporter *Reporter) SerializeEvent(event types.ReportEvent) types.RenderEventItem {
 	validator := event.GetValidator()
+
+	// chain-level events are not related to any validator
+	if validator == nil {
+		return types.RenderEventItem{Event: event, Notifiers: make(types.Notifiers, 0)}
+	}
+
 	notifiers := reporter.Manager.GetNotifiersForReporter(validator.OperatorAddress, constants.DiscordReporterName)
 
 	eventToRender := types.RenderEventItem{
@@ -225,14 +249,17 @@ func (reporter *Reporter) SerializeEvent(event types.ReportEvent) types.RenderEv
 		ValidatorLink: reporter.Config.ExplorerConfig.GetValidatorLink(validator),
 	}
 
-	if eventChanged, ok := event.(events.ValidatorGroupChanged); ok && eventChanged.IsIncreasing() {
+	// time till jail only makes sense for the slashing window
+	if eventChanged, ok := event.(events.ValidatorGroupChanged); ok &&
+		eventChanged.IsIncreasing() &&
+		eventChanged.IsSlashingWindow() {
 		eventToRender.TimeToJail = reporter.Manager.GetTimeTillJail(eventChanged.MissedBlocksAfter)
 	}
 
 	return eventToRender
 }
 
-func (reporter *Reporter) Send(report *types.Report) error {
+func (reporter *Reporter) RenderReport(report *types.Report) string {
 	reporter.MetricsManager.LogReport(reporter.Config.Name, report)
 
 	var sb strings.Builder
@@ -242,16 +269,20 @@ func (reporter *Reporter) Send(report *types.Report) error {
 		sb.WriteString(reporter.TemplatesManager.SerializeEvent(eventToRender) + "\n")
 	}
 
-	reportString := sb.String()
+	return sb.String()
+}
 
-	chunks := utils.SplitStringIntoChunks(reportString, MaxMessageSize)
+func (reporter *Reporter) SendReport(message string, sentChunks int64) (int64, error) {
+	chunks := utils.SplitStringIntoChunks(message, MaxMessageSize)
 
 	reporter.Logger.Trace().
-		Str("report", reportString).
+		Str("report", message).
 		Int("chunks", len(chunks)).
+		Int64("sent_chunks", sentChunks).
 		Msg("Sending a report")
 
-	for _, chunk := range chunks {
+	for index := sentChunks; index < int64(len(chunks)); index++ {
+		chunk := chunks[index]
 		_, err := reporter.DiscordSession.ChannelMessageSend(
 			reporter.Channel,
 			strings.TrimSpace(chunk),
@@ -261,11 +292,51 @@ func (reporter *Reporter) Send(report *types.Report) error {
 				Err(err).
 				Str("chunk", chunk).
 				Msg("Could not send report chunk")
-			return err
+
+			var rateLimitErr *discordgo.RateLimitError
+			if errors.As(err, &rateLimitErr) && rateLimitErr.TooManyRequests != nil {
+				return index, &types.RateLimitedError{
+					RetryAfter: rateLimitErr.TooManyRequests.RetryAfter,
+					Err:        err,
+				}
+			}
+
+			return index, err
 		}
 	}
 
-	return nil
+	return int64(len(chunks)), nil
+}
+
+func (reporter *Reporter) SendOfflineNotice() error {
+	if reporter.DiscordSession == nil {
+		return errors.New("Discord session is not initialized")
+	}
+
+	notice, err := reporter.TemplatesManager.Render("Offline", reporter.Config)
+	if err != nil {
+		return err
+	}
+
+	_, err = reporter.DiscordSession.ChannelMessageSend(reporter.Channel, notice)
+	return err
+}
+
+func (reporter *Reporter) SendWatchdogAlert(alert types.WatchdogAlert) error {
+	if reporter.DiscordSession == nil {
+		return errors.New("Discord session is not initialized")
+	}
+
+	message, err := reporter.TemplatesManager.Render("WatchdogAlert", watchdogAlertRender{
+		Config: reporter.Config,
+		Alert:  alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = reporter.DiscordSession.ChannelMessageSend(reporter.Channel, message)
+	return err
 }
 
 func (reporter *Reporter) BotRespond(s *discordgo.Session, i *discordgo.InteractionCreate, text string) {
@@ -0,0 +1,47 @@
+package discord
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetEvidenceCommand() *Command {
+	return &Command{
+		Info: &discordgo.ApplicationCommand{
+			Name:        "evidence",
+			Description: "See latest double signs evidence",
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			reporter.MetricsManager.LogReporterQuery(reporter.Config.Name, constants.DiscordReporterName, "evidence")
+
+			evidenceRaw, err := reporter.Manager.FindLastEvidence()
+			if err != nil {
+				reporter.Logger.Error().Err(err).Msg("Error searching for evidence!")
+				return
+			}
+
+			evidenceRendered := utils.Map(evidenceRaw, func(e *types.Evidence) renderedEvidence {
+				validatorLink := types.Link{Text: e.ValidatorAddress}
+				if validator, found := reporter.Manager.GetValidator(e.OperatorAddress); found {
+					validatorLink = reporter.Config.ExplorerConfig.GetValidatorLink(validator)
+				}
+
+				return renderedEvidence{
+					Evidence:      e,
+					ValidatorLink: validatorLink,
+				}
+			})
+
+			renderedTemplate, err := reporter.TemplatesManager.Render("Evidence", evidenceRendered)
+			if err != nil {
+				reporter.Logger.Error().Err(err).Msg("Error rendering evidence")
+				return
+			}
+
+			reporter.BotRespond(s, i, renderedTemplate)
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package discord
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetMissedBlocksCommand() *Command {
+	minCount := float64(1)
+
+	return &Command{
+		Info: &discordgo.ApplicationCommand{
+			Name:        "missed",
+			Description: "See heights and times of latest blocks missed by a validator",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "address",
+					Description: "Validator address",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "count",
+					Description: "How many latest missed blocks to show",
+					Required:    false,
+					MinValue:    &minCount,
+					MaxValue:    constants.MissedBlocksMaxCount,
+				},
+			},
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			reporter.MetricsManager.LogReporterQuery(reporter.Config.Name, constants.DiscordReporterName, "missed")
+
+			options := i.ApplicationCommandData().Options
+			address, _ := options[0].Value.(string)
+			count := int64(constants.MissedBlocksDefaultCount)
+
+			if len(options) >= 2 {
+				count = min(options[1].IntValue(), constants.MissedBlocksMaxCount)
+			}
+
+			snapshot, found := reporter.SnapshotManager.GetNewerSnapshot()
+			if !found {
+				reporter.Logger.Info().Msg("No older snapshot on discord missed blocks query!")
+				reporter.BotRespond(s, i, "Error getting validator missed blocks!")
+				return
+			}
+
+			userEntries := snapshot.Entries.ByValidatorAddresses([]string{address})
+			if len(userEntries) == 0 {
+				reporter.BotRespond(s, i, "Validator is not found!")
+				return
+			}
+
+			validator := userEntries[0].Validator
+
+			missedBlocks, err := reporter.Manager.FindLastMissedBlocks(validator.ConsensusAddressHex, count)
+			if err != nil {
+				reporter.BotRespond(s, i, "Error searching for missed blocks!")
+				return
+			}
+
+			renderedTemplate, err := reporter.TemplatesManager.Render("MissedBlocks", missedBlocksRender{
+				ValidatorLink: reporter.Config.ExplorerConfig.GetValidatorLink(validator),
+				Count:         len(missedBlocks),
+				Ranges:        types.GroupMissedBlocks(missedBlocks),
+			})
+			if err != nil {
+				reporter.Logger.Error().Err(err).Msg("Error rendering missed blocks")
+				return
+			}
+
+			reporter.BotRespond(s, i, renderedTemplate)
+		},
+	}
+}
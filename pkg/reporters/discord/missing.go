@@ -52,10 +52,10 @@ func (reporter *Reporter) GetMissingCommand() *Command {
 					link.Text = fmt.Sprintf("%s %s", group.EmojiEnd, v.Validator.Moniker)
 
 					return missingValidatorsEntry{
-						Validator:    v.Validator,
-						Link:         link,
-						NotSigned:    v.SignatureInfo.GetNotSigned(),
-						BlocksWindow: reporter.Config.BlocksWindow,
+						Validator: v.Validator,
+						Link:      link,
+						NotSigned: v.SignatureInfo.GetNotSigned(),
+						Config:    reporter.Config,
 					}
 				}),
 			}
@@ -0,0 +1,32 @@
+package discord
+
+import (
+	"main/pkg/constants"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func (reporter *Reporter) GetNodesCommand() *Command {
+	return &Command{
+		Info: &discordgo.ApplicationCommand{
+			Name:        "nodes",
+			Description: "See the health of RPC and LCD nodes the app is querying",
+		},
+		Handler: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			reporter.MetricsManager.LogReporterQuery(reporter.Config.Name, constants.DiscordReporterName, "nodes")
+
+			template, err := reporter.TemplatesManager.Render("Nodes", nodesRender{
+				Config: reporter.Config,
+				Nodes:  reporter.DataManager.GetNodesStats(),
+				Now:    time.Now(),
+			})
+			if err != nil {
+				reporter.Logger.Error().Err(err).Msg("Error rendering nodes template")
+				return
+			}
+
+			reporter.BotRespond(s, i, template)
+		},
+	}
+}
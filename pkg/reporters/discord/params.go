@@ -31,6 +31,7 @@ func (reporter *Reporter) GetParamsCommand() *Command {
 				BlockTime:       blockTime,
 				MaxTimeToJail:   maxTimeToJail,
 				ValidatorsCount: len(activeValidators),
+				Nodes:           reporter.DataManager.GetRPCNodesStats(),
 			})
 			if err != nil {
 				reporter.Logger.Error().Err(err).Msg("Error rendering params template")
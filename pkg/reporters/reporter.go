@@ -8,8 +8,14 @@ import (
 type Reporter interface {
 	Init()
 	Start()
+	Stop()
 	Name() constants.ReporterName
 	Enabled() bool
 	SerializeEvent(event types.ReportEvent) types.RenderEventItem
-	Send(report *types.Report) error
+	RenderReport(report *types.Report) string
+	// SendReport sends a rendered report, which might be split into multiple chunks, skipping
+	// the first sentChunks ones, and returns how many chunks are sent in total, even if it fails.
+	SendReport(message string, sentChunks int64) (int64, error)
+	SendOfflineNotice() error
+	SendWatchdogAlert(alert types.WatchdogAlert) error
 }
@@ -0,0 +1,69 @@
+package reporters
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+// StubReporter records everything sent via it, SendError is returned on each send.
+// Each report is sent as Chunks chunks, if set, and ChunksBeforeError of them are sent before SendError.
+type StubReporter struct {
+	ReporterName      constants.ReporterName
+	Disabled          bool
+	SendError         error
+	Chunks            int64
+	ChunksBeforeError int64
+
+	Reports []string
+	// SentChunks are the chunks sent, by their index.
+	SentChunks []int64
+	Alerts     []types.WatchdogAlert
+}
+
+func (r *StubReporter) Init()  {}
+func (r *StubReporter) Start() {}
+func (r *StubReporter) Stop()  {}
+
+func (r *StubReporter) Name() constants.ReporterName {
+	return r.ReporterName
+}
+
+func (r *StubReporter) Enabled() bool {
+	return !r.Disabled
+}
+
+func (r *StubReporter) SerializeEvent(event types.ReportEvent) types.RenderEventItem {
+	return types.RenderEventItem{Event: event}
+}
+
+func (r *StubReporter) RenderReport(report *types.Report) string {
+	return string(r.ReporterName) + " report"
+}
+
+func (r *StubReporter) SendReport(message string, sentChunks int64) (int64, error) {
+	chunks := max(r.Chunks, 1)
+
+	for index := sentChunks; index < chunks; index++ {
+		if r.SendError != nil && index >= r.ChunksBeforeError {
+			return index, r.SendError
+		}
+
+		r.SentChunks = append(r.SentChunks, index)
+	}
+
+	r.Reports = append(r.Reports, message)
+	return chunks, nil
+}
+
+func (r *StubReporter) SendOfflineNotice() error {
+	return r.SendError
+}
+
+func (r *StubReporter) SendWatchdogAlert(alert types.WatchdogAlert) error {
+	if r.SendError != nil {
+		return r.SendError
+	}
+
+	r.Alerts = append(r.Alerts, alert)
+	return nil
+}
@@ -55,7 +55,7 @@ func TestReporterEventsInvalidInvocation(t *testing.T) {
 	database.SetClient(dbClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -104,7 +104,7 @@ func TestReporterEventsErrorFetchingSnapshot(t *testing.T) {
 	database.SetClient(dbClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -155,7 +155,7 @@ func TestReporterEventsNotSubscribed(t *testing.T) {
 	snapshotManager.CommitNewSnapshot(100, snapshot.Snapshot{Entries: map[string]*types.Entry{}})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -214,7 +214,7 @@ func TestReporterEventsErrorFetchingEvents(t *testing.T) {
 	}})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -303,7 +303,7 @@ func TestReporterEventsOk(t *testing.T) {
 	}})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
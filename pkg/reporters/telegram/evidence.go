@@ -0,0 +1,37 @@
+package telegram
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleEvidence(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got evidence query")
+
+	reporter.MetricsManager.LogReporterQuery(reporter.Config.Name, constants.TelegramReporterName, "evidence")
+
+	evidenceRaw, err := reporter.Manager.FindLastEvidence()
+	if err != nil {
+		return reporter.BotReply(c, "Error searching for evidence!")
+	}
+
+	evidenceRendered := utils.Map(evidenceRaw, func(e *types.Evidence) renderedEvidence {
+		validatorLink := types.Link{Text: e.ValidatorAddress}
+		if validator, found := reporter.Manager.GetValidator(e.OperatorAddress); found {
+			validatorLink = reporter.Config.ExplorerConfig.GetValidatorLink(validator)
+		}
+
+		return renderedEvidence{
+			Evidence:      e,
+			ValidatorLink: validatorLink,
+		}
+	})
+
+	return reporter.ReplyRender(c, "Evidence", evidenceRendered)
+}
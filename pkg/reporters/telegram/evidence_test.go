@@ -0,0 +1,191 @@
+package telegram
+
+import (
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/snapshot"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestReporterEvidenceFailedToFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error searching for evidence!"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	dbClient := databasePkg.NewStubDatabaseClient()
+	database.SetClient(dbClient)
+
+	dbClient.Mock.
+		ExpectQuery("validator_address, operator_address, height, block_height, time FROM evidence").
+		WillReturnError(errors.New("custom error"))
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/evidence",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleEvidence(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterEvidenceOkEmpty(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Nobody has double signed since the app launch."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	dbClient := databasePkg.NewStubDatabaseClient()
+	database.SetClient(dbClient)
+
+	dbClient.Mock.
+		ExpectQuery("validator_address, operator_address, height, block_height, time FROM evidence").
+		WillReturnRows(sqlmock.NewRows([]string{"validator_address", "operator_address", "height", "block_height", "time"}))
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/evidence",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleEvidence(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterEvidenceOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/evidence.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+		ExplorerConfig: configPkg.ExplorerConfig{
+			ValidatorLinkPattern: "https://example.com/validators/%s",
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	dbClient := databasePkg.NewStubDatabaseClient()
+	database.SetClient(dbClient)
+
+	renderTime, err := time.Parse(time.RFC3339, "2025-01-19T11:03:00Z")
+	require.NoError(t, err)
+
+	dbClient.Mock.
+		ExpectQuery("validator_address, operator_address, height, block_height, time FROM evidence").
+		WillReturnRows(sqlmock.
+			NewRows([]string{"validator_address", "operator_address", "height", "block_height", "time"}).
+			AddRow("ABCDEF", "validator1", 200, 201, renderTime.Unix()).
+			AddRow("FEDCBA", "", 100, 102, renderTime.Add(-time.Hour).Unix()))
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	stateManager.SetValidators(types.ValidatorsMap{
+		"validator1": {OperatorAddress: "validator1", Moniker: "validator1"},
+	})
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/evidence",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err = reporter.HandleEvidence(ctx)
+	require.NoError(t, err)
+}
@@ -39,7 +39,7 @@ func TestReporterHelpOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
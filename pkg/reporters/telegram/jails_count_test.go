@@ -51,7 +51,7 @@ func TestReporterJailsCountFailedToFetchSnapshot(t *testing.T) {
 	database.SetClient(dbClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -110,7 +110,7 @@ func TestReporterJailsCountFailedToFetchEventsCount(t *testing.T) {
 		WillReturnError(errors.New("custom error"))
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -172,7 +172,7 @@ func TestReporterJailsCountValidatorNotFound(t *testing.T) {
 		)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -231,7 +231,7 @@ func TestReporterJailsCountEmpty(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"validator", "count"}))
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -298,7 +298,7 @@ func TestReporterJailsCountOk(t *testing.T) {
 		)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -59,7 +59,7 @@ func TestReporterJailsFailedToFetch(t *testing.T) {
 		WillReturnError(errors.New("custom error"))
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -112,7 +112,7 @@ func TestReporterJailsOkEmpty(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"event", "height", "validator", "payload", "time"}))
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -209,7 +209,7 @@ func TestReporterJailsOk(t *testing.T) {
 		)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
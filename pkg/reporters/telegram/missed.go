@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"fmt"
+	"html"
+	"main/pkg/constants"
+	"main/pkg/types"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleMissedBlocks(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got missed blocks query")
+
+	reporter.MetricsManager.LogReporterQuery(reporter.Config.Name, constants.TelegramReporterName, "missed")
+
+	args := strings.Split(c.Text(), " ")
+	if len(args) < 2 {
+		return reporter.BotReply(c, html.EscapeString(fmt.Sprintf(
+			"Usage: %s <validator address> [count]",
+			args[0],
+		)))
+	}
+
+	address := args[1]
+	count := int64(constants.MissedBlocksDefaultCount)
+
+	if len(args) >= 3 {
+		parsedCount, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil || parsedCount <= 0 {
+			return reporter.BotReply(c, html.EscapeString(fmt.Sprintf(
+				"Usage: %s <validator address> [count]",
+				args[0],
+			)))
+		}
+
+		count = min(parsedCount, constants.MissedBlocksMaxCount)
+	}
+
+	snapshot, found := reporter.SnapshotManager.GetNewerSnapshot()
+	if !found {
+		reporter.Logger.Info().
+			Str("sender", c.Sender().Username).
+			Str("text", c.Text()).
+			Msg("No older snapshot on telegram missed blocks query!")
+		return reporter.BotReply(c, "Error getting validator missed blocks!")
+	}
+
+	userEntries := snapshot.Entries.ByValidatorAddresses([]string{address})
+	if len(userEntries) == 0 {
+		return reporter.BotReply(c, "Validator is not found!")
+	}
+
+	validator := userEntries[0].Validator
+
+	missedBlocks, err := reporter.Manager.FindLastMissedBlocks(validator.ConsensusAddressHex, count)
+	if err != nil {
+		return reporter.BotReply(c, "Error searching for missed blocks!")
+	}
+
+	return reporter.ReplyRender(c, "MissedBlocks", missedBlocksRender{
+		ValidatorLink: reporter.Config.ExplorerConfig.GetValidatorLink(validator),
+		Count:         len(missedBlocks),
+		Ranges:        types.GroupMissedBlocks(missedBlocks),
+	})
+}
@@ -0,0 +1,337 @@
+package telegram
+
+import (
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	databasePkg "main/pkg/database"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/snapshot"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestReporterMissedBlocksInvalidInvocation(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Usage: /missed &lt;validator address&gt; [count]"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missed",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleMissedBlocks(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterMissedBlocksInvalidCount(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Usage: /missed &lt;validator address&gt; [count]"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missed validator -5",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleMissedBlocks(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterMissedBlocksErrorFetchingSnapshot(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error getting validator missed blocks!"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missed validator",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleMissedBlocks(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterMissedBlocksValidatorNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Validator is not found!"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	snapshotManager.CommitNewSnapshot(100, snapshot.Snapshot{Entries: map[string]*types.Entry{}})
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missed validator",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleMissedBlocks(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterMissedBlocksErrorFetchingMissedBlocks(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("Error searching for missed blocks!"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	dbClient := databasePkg.NewStubDatabaseClient()
+	database.SetClient(dbClient)
+
+	dbClient.Mock.
+		ExpectQuery("SELECT height, time FROM missed_blocks").
+		WillReturnError(errors.New("custom error"))
+
+	snapshotManager.CommitNewSnapshot(100, snapshot.Snapshot{Entries: map[string]*types.Entry{
+		"validator": {
+			Validator: &types.Validator{OperatorAddress: "validator", Moniker: "validator"},
+		},
+	}})
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missed validator",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleMissedBlocks(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterMissedBlocksOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/missed-blocks.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name:           "chain",
+		ExplorerConfig: configPkg.ExplorerConfig{ValidatorLinkPattern: "https://example.com/validators/%s"},
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	dbClient := databasePkg.NewStubDatabaseClient()
+	database.SetClient(dbClient)
+
+	renderTime, err := time.Parse(time.RFC3339, "2025-01-19T11:03:00Z")
+	require.NoError(t, err)
+
+	dbClient.Mock.
+		ExpectQuery("SELECT height, time FROM missed_blocks").
+		WithArgs("chain", "consensus", int64(3)).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"height", "time"}).
+			AddRow(12045, renderTime.Unix()).
+			AddRow(12044, renderTime.Add(-time.Second).Unix()).
+			AddRow(12001, renderTime.Add(-time.Minute).Unix()),
+		)
+
+	snapshotManager.CommitNewSnapshot(100, snapshot.Snapshot{Entries: map[string]*types.Entry{
+		"validator": {
+			Validator: &types.Validator{
+				OperatorAddress:     "validator",
+				ConsensusAddressHex: "consensus",
+				Moniker:             "validator",
+			},
+		},
+	}})
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missed validator 3",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err = reporter.HandleMissedBlocks(ctx)
+	require.NoError(t, err)
+}
@@ -41,7 +41,7 @@ func TestReporterMissingFailedToFetch(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -92,7 +92,85 @@ func TestReporterMissingOk(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	snapshotManager.CommitNewSnapshot(123, snapshot.Snapshot{
+		Entries: types.Entries{
+			"validator1": &types.Entry{
+				IsActive:      true,
+				Validator:     &types.Validator{Moniker: "moniker1"},
+				SignatureInfo: types.SignatureInto{NotSigned: 5},
+			},
+			"validator2": &types.Entry{
+				IsActive:      true,
+				Validator:     &types.Validator{Moniker: "moniker2"},
+				SignatureInfo: types.SignatureInto{NotSigned: 25},
+			},
+			"validator3": &types.Entry{
+				IsActive:      false,
+				Validator:     &types.Validator{Moniker: "moniker3"},
+				SignatureInfo: types.SignatureInto{NotSigned: 8},
+			},
+			"validator4": &types.Entry{
+				IsActive:      true,
+				Validator:     &types.Validator{Moniker: "moniker4"},
+				SignatureInfo: types.SignatureInto{NotSigned: 15},
+			},
+		},
+	})
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/missing",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleMissingValidators(ctx)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterMissingJailBudgetOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/missing-jail-budget.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name:               "chain",
+		BlocksWindow:       100,
+		MinSignedPerWindow: 0.5,
+		ThresholdMode:      "jail-budget",
+		Thresholds:         []float64{0, 10, 100},
+		EmojisStart:        []string{"🟢", "🟡"},
+		EmojisEnd:          []string{"🟢", "🟡"},
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	config.RecalculateMissedBlocksGroups()
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	snapshotManager.CommitNewSnapshot(123, snapshot.Snapshot{
@@ -0,0 +1,23 @@
+package telegram
+
+import (
+	"main/pkg/constants"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+func (reporter *Reporter) HandleNodes(c tele.Context) error {
+	reporter.Logger.Info().
+		Str("sender", c.Sender().Username).
+		Str("text", c.Text()).
+		Msg("Got nodes query")
+
+	reporter.MetricsManager.LogReporterQuery(reporter.Config.Name, constants.TelegramReporterName, "nodes")
+
+	return reporter.ReplyRender(c, "Nodes", nodesRender{
+		Config: reporter.Config,
+		Nodes:  reporter.DataManager.GetNodesStats(),
+		Now:    time.Now(),
+	})
+}
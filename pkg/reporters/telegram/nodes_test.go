@@ -0,0 +1,70 @@
+package telegram
+
+import (
+	"main/assets"
+	configPkg "main/pkg/config"
+	dataPkg "main/pkg/data"
+	"main/pkg/grpc"
+	"main/pkg/http"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v3"
+)
+
+//nolint:paralleltest // disabled
+func TestReporterNodesOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasBytes(assets.GetBytesOrPanic("responses/nodes.html")),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://rpc1.example.com", "https://rpc2.example.com"},
+		LCDEndpoints: []string{"https://lcd.example.com"},
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
+	dataManager := dataPkg.NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, dataManager)
+	reporter.Init()
+
+	ctx := reporter.TelegramBot.NewContext(tele.Update{
+		ID: 1,
+		Message: &tele.Message{
+			Sender: &tele.User{Username: "testuser"},
+			Text:   "/nodes",
+			Chat:   &tele.Chat{ID: 2},
+		},
+	})
+
+	err := reporter.HandleNodes(ctx)
+	require.NoError(t, err)
+}
@@ -32,5 +32,6 @@ func (reporter *Reporter) HandleParams(c tele.Context) error {
 		BlockTime:       blockTime,
 		MaxTimeToJail:   maxTimeToJail,
 		ValidatorsCount: len(activeValidators),
+		Nodes:           reporter.DataManager.GetRPCNodesStats(),
 	})
 }
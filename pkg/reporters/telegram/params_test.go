@@ -3,7 +3,10 @@ package telegram
 import (
 	"main/assets"
 	configPkg "main/pkg/config"
+	dataPkg "main/pkg/data"
 	databasePkg "main/pkg/database"
+	"main/pkg/grpc"
+	"main/pkg/http"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
 	"main/pkg/snapshot"
@@ -44,10 +47,12 @@ func TestReporterParamsFailedToFetchSnapshot(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
-	database.SetClient(&databasePkg.StubDatabaseClient{})
+	databaseClient := databasePkg.NewStubDatabaseClient()
+	databaseClient.ExpectEmptyValidatorsDictionary()
+	database.SetClient(databaseClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	currentTime := time.Now()
@@ -88,11 +93,25 @@ func TestReporterParamsOkSovereign(t *testing.T) {
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
 	)
 
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-status.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example2.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-status-lagging.json")),
+	)
+
 	config := &configPkg.ChainConfig{
 		Name:               "chain",
 		BlocksWindow:       100,
 		SnapshotsInterval:  1,
 		MinSignedPerWindow: 0.02,
+		MaxNodeLag:         10,
+		RPCEndpoints:       []string{"https://example.com", "https://example2.com"},
 		Thresholds:         []float64{0, 10, 100},
 		EmojisStart:        []string{"🟢", "🟡"},
 		EmojisEnd:          []string{"🟢", "🟡"},
@@ -108,12 +127,23 @@ func TestReporterParamsOkSovereign(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
-	database.SetClient(&databasePkg.StubDatabaseClient{})
+	databaseClient := databasePkg.NewStubDatabaseClient()
+	databaseClient.ExpectEmptyValidatorsDictionary()
+	database.SetClient(databaseClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	dataManager := dataPkg.NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, dataManager)
 	reporter.Init()
 
+	err := dataManager.CheckNodesLag()
+	require.NoError(t, err)
+
 	snapshotManager.CommitNewSnapshot(123, snapshot.Snapshot{
 		Entries: types.Entries{
 			"validator1": &types.Entry{
@@ -141,7 +171,7 @@ func TestReporterParamsOkSovereign(t *testing.T) {
 
 	currentTime := time.Now()
 
-	err := stateManager.AddBlock(&types.Block{Height: 1, Time: currentTime})
+	err = stateManager.AddBlock(&types.Block{Height: 1, Time: currentTime})
 	require.NoError(t, err)
 
 	err = stateManager.AddBlock(&types.Block{Height: 2, Time: currentTime.Add(5 * time.Second)})
@@ -198,10 +228,18 @@ func TestReporterParamsOkConsumer(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
-	database.SetClient(&databasePkg.StubDatabaseClient{})
+	databaseClient := databasePkg.NewStubDatabaseClient()
+	databaseClient.ExpectEmptyValidatorsDictionary()
+	database.SetClient(databaseClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	dataManager := dataPkg.NewManager(
+		*logger,
+		config,
+		http.NewPools(*logger, metricsManager, config),
+		grpc.NewPools(*logger, metricsManager, config),
+	)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, dataManager)
 	reporter.Init()
 
 	snapshotManager.CommitNewSnapshot(123, snapshot.Snapshot{
@@ -49,7 +49,7 @@ func TestReporterStatusNotSubscribed(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -97,7 +97,7 @@ func TestReporterStatusNoSnapshot(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	stateManager.SetValidators(types.ValidatorsMap{
@@ -163,7 +163,7 @@ func TestReporterStatusOk(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	stateManager.SetValidators(types.ValidatorsMap{
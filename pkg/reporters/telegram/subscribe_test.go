@@ -47,7 +47,7 @@ func TestReporterSubscribeInvalidInvocation(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -93,7 +93,7 @@ func TestReporterSubscribeValidatorNotFound(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -142,7 +142,7 @@ func TestReporterSubscribeValidatorAlreadySubscribed(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	stateManager.SetValidators(types.ValidatorsMap{
@@ -197,7 +197,7 @@ func TestReporterSubscribeValidatorOk(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	stateManager.SetValidators(types.ValidatorsMap{
@@ -1,7 +1,9 @@
 package telegram
 
 import (
+	"errors"
 	"main/pkg/constants"
+	dataPkg "main/pkg/data"
 	"main/pkg/events"
 	"main/pkg/metrics"
 	snapshotPkg "main/pkg/snapshot"
@@ -31,6 +33,7 @@ type Reporter struct {
 	Config           *config.ChainConfig
 	Manager          *statePkg.Manager
 	SnapshotManager  *snapshotPkg.Manager
+	DataManager      *dataPkg.Manager
 	MetricsManager   *metrics.Manager
 	TemplatesManager templatesPkg.Manager
 
@@ -48,6 +51,7 @@ func NewReporter(
 	manager *statePkg.Manager,
 	metricsManager *metrics.Manager,
 	snapshotManager *snapshotPkg.Manager,
+	dataManager *dataPkg.Manager,
 ) *Reporter {
 	return &Reporter{
 		Token:            chainConfig.TelegramConfig.Token,
@@ -58,6 +62,7 @@ func NewReporter(
 		Manager:          manager,
 		MetricsManager:   metricsManager,
 		SnapshotManager:  snapshotManager,
+		DataManager:      dataManager,
 		TemplatesManager: templatesPkg.NewManager(logger, constants.TelegramReporterName),
 		Version:          version,
 		StopChannel:      make(chan bool),
@@ -86,7 +91,9 @@ func (reporter *Reporter) Init() {
 
 	queries := []string{
 		"help",
+		"missed",
 		"missing",
+		"nodes",
 		"notifiers",
 		"params",
 		"status",
@@ -110,8 +117,11 @@ func (reporter *Reporter) Init() {
 	bot.Handle("/params", reporter.HandleParams)
 	bot.Handle("/config", reporter.HandleParams)
 	bot.Handle("/jails", reporter.HandleJailsList)
+	bot.Handle("/evidence", reporter.HandleEvidence)
 	bot.Handle("/events", reporter.HandleValidatorEventsList)
+	bot.Handle("/missed", reporter.HandleMissedBlocks)
 	bot.Handle("/jailscount", reporter.HandleJailsCount)
+	bot.Handle("/nodes", reporter.HandleNodes)
 
 	reporter.TelegramBot = bot
 }
@@ -129,6 +139,10 @@ func (reporter *Reporter) Start() {
 }
 
 func (reporter *Reporter) Stop() {
+	if reporter.TelegramBot == nil {
+		return
+	}
+
 	reporter.StopChannel <- true
 }
 
@@ -142,6 +156,12 @@ func (reporter *Reporter) GetStateManager() *statePkg.Manager {
 
 func (reporter *Reporter) SerializeEvent(event types.ReportEvent) types.RenderEventItem {
 	validator := event.GetValidator()
+
+	// chain-level events are not related to any validator
+	if validator == nil {
+		return types.RenderEventItem{Event: event, Notifiers: make(types.Notifiers, 0)}
+	}
+
 	notifiers := reporter.Manager.GetNotifiersForReporter(validator.OperatorAddress, constants.TelegramReporterName)
 
 	eventToRender := types.RenderEventItem{
@@ -150,14 +170,17 @@ func (reporter *Reporter) SerializeEvent(event types.ReportEvent) types.RenderEv
 		ValidatorLink: reporter.Config.ExplorerConfig.GetValidatorLink(validator),
 	}
 
-	if eventChanged, ok := event.(events.ValidatorGroupChanged); ok && eventChanged.IsIncreasing() {
+	// time till jail only makes sense for the slashing window
+	if eventChanged, ok := event.(events.ValidatorGroupChanged); ok &&
+		eventChanged.IsIncreasing() &&
+		eventChanged.IsSlashingWindow() {
 		eventToRender.TimeToJail = reporter.Manager.GetTimeTillJail(eventChanged.MissedBlocksAfter)
 	}
 
 	return eventToRender
 }
 
-func (reporter *Reporter) Send(report *types.Report) error {
+func (reporter *Reporter) RenderReport(report *types.Report) string {
 	reporter.MetricsManager.LogReport(reporter.Config.Name, report)
 
 	var sb strings.Builder
@@ -167,15 +190,62 @@ func (reporter *Reporter) Send(report *types.Report) error {
 		sb.WriteString(reporter.TemplatesManager.SerializeEvent(eventToRender) + "\n")
 	}
 
-	reportString := sb.String()
+	return sb.String()
+}
+
+func (reporter *Reporter) SendReport(message string, sentChunks int64) (int64, error) {
+	chunks := utils.SplitStringIntoChunks(message, MaxMessageSize)
+
+	reporter.Logger.Trace().
+		Str("report", message).
+		Int("chunks", len(chunks)).
+		Int64("sent_chunks", sentChunks).
+		Msg("Sending a report")
+
+	for index := sentChunks; index < int64(len(chunks)); index++ {
+		if err := reporter.BotSendChunk(chunks[index]); err != nil {
+			var floodErr tele.FloodError
+			if errors.As(err, &floodErr) {
+				return index, &types.RateLimitedError{
+					RetryAfter: time.Duration(floodErr.RetryAfter) * time.Second,
+					Err:        err,
+				}
+			}
+
+			return index, err
+		}
+	}
+
+	return int64(len(chunks)), nil
+}
 
-	reporter.Logger.Trace().Str("report", reportString).Msg("Sending a report")
+func (reporter *Reporter) SendOfflineNotice() error {
+	if reporter.TelegramBot == nil {
+		return errors.New("Telegram bot is not initialized")
+	}
 
-	if err := reporter.BotSend(reportString); err != nil {
-		reporter.Logger.Err(err).Msg("Could not send Telegram message")
+	notice, err := reporter.TemplatesManager.Render("Offline", reporter.Config)
+	if err != nil {
 		return err
 	}
-	return nil
+
+	return reporter.BotSend(notice)
+}
+
+func (reporter *Reporter) SendWatchdogAlert(alert types.WatchdogAlert) error {
+	if reporter.TelegramBot == nil {
+		return errors.New("Telegram bot is not initialized")
+	}
+
+	message, err := reporter.TemplatesManager.Render("WatchdogAlert", watchdogAlertRender{
+		Config: reporter.Config,
+		Alert:  alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	return reporter.BotSend(message)
 }
 
 func (reporter *Reporter) Name() constants.ReporterName {
@@ -186,19 +256,27 @@ func (reporter *Reporter) BotSend(msg string) error {
 	messages := utils.SplitStringIntoChunks(msg, MaxMessageSize)
 
 	for _, message := range messages {
-		if _, err := reporter.TelegramBot.Send(
-			&tele.User{ID: reporter.Chat},
-			strings.TrimSpace(message),
-			tele.ModeHTML,
-			tele.NoPreview,
-		); err != nil {
-			reporter.Logger.Error().Err(err).Msg("Could not send Telegram message")
+		if err := reporter.BotSendChunk(message); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func (reporter *Reporter) BotSendChunk(message string) error {
+	if _, err := reporter.TelegramBot.Send(
+		&tele.User{ID: reporter.Chat},
+		strings.TrimSpace(message),
+		tele.ModeHTML,
+		tele.NoPreview,
+	); err != nil {
+		reporter.Logger.Error().Err(err).Msg("Could not send Telegram message")
+		return err
+	}
+
+	return nil
+}
+
 func (reporter *Reporter) BotReply(c tele.Context, msg string) error {
 	messages := utils.SplitStringIntoChunks(msg, MaxMessageSize)
 
@@ -31,7 +31,7 @@ func TestReporterInitNoCredentials(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 	reporter.Start()
 
@@ -54,7 +54,7 @@ func TestReporterInitFailedToFetchBot(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 }
 
@@ -76,7 +76,7 @@ func TestReporterInitOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 
 	go reporter.Start()
@@ -106,7 +106,7 @@ func TestReporterBotReplyFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -150,10 +150,10 @@ func TestReporterSendFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 
-	err := reporter.Send(&types.Report{
+	_, err := reporter.SendReport(reporter.RenderReport(&types.Report{
 		Events: []types.ReportEvent{
 			events.ValidatorGroupChanged{
 				MissedBlocksBefore:      10,
@@ -163,7 +163,7 @@ func TestReporterSendFail(t *testing.T) {
 				Validator:               &types.Validator{OperatorAddress: "validator", Moniker: "moniker"},
 			},
 		},
-	})
+	}), 0)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "custom error")
 }
@@ -200,10 +200,12 @@ func TestReporterSendOk(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
-	database.SetClient(&databasePkg.StubDatabaseClient{})
+	databaseClient := databasePkg.NewStubDatabaseClient()
+	databaseClient.ExpectEmptyValidatorsDictionary()
+	database.SetClient(databaseClient)
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	currentTime := time.Now()
@@ -214,7 +216,7 @@ func TestReporterSendOk(t *testing.T) {
 	err = stateManager.AddBlock(&types.Block{Height: 2, Time: currentTime.Add(5 * time.Second)})
 	require.NoError(t, err)
 
-	err = reporter.Send(&types.Report{
+	_, err = reporter.SendReport(reporter.RenderReport(&types.Report{
 		Events: []types.ReportEvent{
 			events.ValidatorGroupChanged{
 				MissedBlocksBefore:      10,
@@ -224,6 +226,178 @@ func TestReporterSendOk(t *testing.T) {
 				Validator:               &types.Validator{OperatorAddress: "validator", Moniker: "moniker"},
 			},
 		},
+	}), 0)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterSendReportRemainingChunks(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("second"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
+	reporter.Init()
+
+	// the first chunk was sent before, so only the second one is sent
+	sentChunks, err := reporter.SendReport(strings.Repeat("a", MaxMessageSize)+"\nsecond", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), sentChunks)
+	// getMe and one sendMessage
+	require.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled
+func TestReporterSendChainEventOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("<strong>⚙️ Slashing params have changed:</strong> signed blocks window: 1000 -> 2000"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
+	database := databasePkg.NewDatabase(*logger, configPkg.DatabaseConfig{})
+	database.SetClient(databasePkg.NewStubDatabaseClient())
+
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
+	reporter.Init()
+
+	_, err := reporter.SendReport(reporter.RenderReport(&types.Report{
+		Events: []types.ReportEvent{
+			events.SlashingParamsChanged{
+				OldParams: types.SlashingParams{SignedBlocksWindow: 1000},
+				NewParams: types.SlashingParams{SignedBlocksWindow: 2000},
+			},
+		},
+	}), 0)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterSendOfflineNoticeNotInitialized(t *testing.T) {
+	config := &configPkg.ChainConfig{Name: "chain"}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
+
+	err := reporter.SendOfflineNotice()
+	require.Error(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterSendOfflineNoticeOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("🔌 <strong>Chain</strong>: missed blocks checker is going offline, no alerts will be sent until it is back."),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name:       "chain",
+		PrettyName: "Chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
+	reporter.Init()
+
+	err := reporter.SendOfflineNotice()
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // disabled
+func TestReporterSendWatchdogAlertOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/getMe",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-bot-ok.json")))
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://api.telegram.org/botxxx:yyy/sendMessage",
+		types.TelegramResponseHasText("🚨 <strong>chain</strong>: all rpc endpoints failed 10 queries in a row"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("telegram-send-message-ok.json")),
+	)
+
+	config := &configPkg.ChainConfig{
+		Name: "chain",
+		TelegramConfig: configPkg.TelegramConfig{
+			Token:  "xxx:yyy",
+			Chat:   1,
+			Admins: []int64{1},
+		},
+	}
+	logger := loggerPkg.GetNopLogger()
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
+	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
+	reporter.Init()
+
+	err := reporter.SendWatchdogAlert(types.WatchdogAlert{
+		Key:         "pool-failures-rpc",
+		Description: "all rpc endpoints failed 10 queries in a row",
 	})
 	require.NoError(t, err)
 }
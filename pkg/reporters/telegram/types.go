@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"html/template"
 	"main/pkg/config"
+	"main/pkg/constants"
 	"main/pkg/types"
 	"main/pkg/utils"
 	"time"
@@ -15,17 +16,14 @@ type missingValidatorsRender struct {
 }
 
 type missingValidatorsEntry struct {
-	Validator    *types.Validator
-	NotSigned    int64
-	Link         types.Link
-	BlocksWindow int64
+	Validator *types.Validator
+	NotSigned int64
+	Link      types.Link
+	Config    *config.ChainConfig
 }
 
 func (e missingValidatorsEntry) FormatMissed() string {
-	return fmt.Sprintf(
-		"%.2f",
-		float64(e.NotSigned)/float64(e.BlocksWindow)*100,
-	)
+	return e.Config.FormatMissedBlocksPercent(e.NotSigned)
 }
 
 type notifierRender struct {
@@ -43,6 +41,7 @@ type paramsRender struct {
 	BlockTime       time.Duration
 	MaxTimeToJail   time.Duration
 	ValidatorsCount int
+	Nodes           []types.NodeStats
 }
 
 func (r paramsRender) FormatMinSignedPerWindow() string {
@@ -58,11 +57,41 @@ func (r paramsRender) FormatTimeToJail() string {
 }
 
 func (r paramsRender) FormatGroupPercent(group *config.MissedBlocksGroup) string {
-	return fmt.Sprintf(
+	base := float64(r.Config.GetMissedBlocksBase())
+	formatted := fmt.Sprintf(
 		"%.2f%% - %.2f%%",
-		float64(group.Start)/float64(r.Config.BlocksWindow)*100,
-		float64(group.End)/float64(r.Config.BlocksWindow)*100,
+		float64(group.Start)/base*100,
+		float64(group.End)/base*100,
 	)
+
+	if r.Config.ThresholdMode == constants.ThresholdModeJailBudget {
+		return formatted + " of jail limit"
+	}
+
+	return formatted
+}
+
+func (r paramsRender) FormatThresholdMode() string {
+	switch r.Config.ThresholdMode {
+	case constants.ThresholdModeJailBudget:
+		return "percent of missed blocks allowed before jail"
+	case constants.ThresholdModeAbsolute:
+		return "missed blocks count"
+	default:
+		return "percent of blocks window"
+	}
+}
+
+func (r paramsRender) FormatNodeLag(node types.NodeStats) string {
+	if node.LatestHeight == 0 {
+		return "latest height unknown"
+	}
+
+	if node.IsLagging {
+		return fmt.Sprintf("height %d, %d blocks behind, not used for queries", node.LatestHeight, node.Lag)
+	}
+
+	return fmt.Sprintf("height %d, %d blocks behind", node.LatestHeight, node.Lag)
 }
 
 func (r paramsRender) FormatSnapshotInterval() string {
@@ -86,7 +115,7 @@ type statusRender struct {
 }
 
 func (s statusRender) FormatNotSignedPercent(entry statusEntry) string {
-	return fmt.Sprintf("%.2f", float64(entry.SigningInfo.GetNotSigned())/float64(s.ChainConfig.BlocksWindow)*100)
+	return s.ChainConfig.FormatMissedBlocksPercent(entry.SigningInfo.GetNotSigned())
 }
 
 func (s statusRender) FormatVotingPower(entry statusEntry) string {
@@ -112,3 +141,45 @@ type renderedJailsCount struct {
 	ValidatorLink types.Link
 	JailsCount    int
 }
+
+type nodesRender struct {
+	Config *config.ChainConfig
+	Nodes  []types.NodeStats
+	Now    time.Time
+}
+
+func (r nodesRender) IsQuarantined(node types.NodeStats) bool {
+	return node.IsQuarantined(r.Now)
+}
+
+func (r nodesRender) FormatQuarantinedFor(node types.NodeStats) string {
+	return utils.FormatDuration(node.QuarantinedUntil.Sub(r.Now))
+}
+
+func (r nodesRender) FormatScore(node types.NodeStats) string {
+	return fmt.Sprintf("%.2f", node.Score())
+}
+
+func (r nodesRender) FormatLatency(node types.NodeStats) string {
+	return node.Latency.Round(time.Millisecond).String()
+}
+
+func (r nodesRender) FormatErrorRate(node types.NodeStats) string {
+	return fmt.Sprintf("%.2f%%", node.ErrorRate*100)
+}
+
+type missedBlocksRender struct {
+	ValidatorLink types.Link
+	Count         int
+	Ranges        []types.MissedBlocksRange
+}
+
+type watchdogAlertRender struct {
+	Config *config.ChainConfig
+	Alert  types.WatchdogAlert
+}
+
+type renderedEvidence struct {
+	Evidence      *types.Evidence
+	ValidatorLink types.Link
+}
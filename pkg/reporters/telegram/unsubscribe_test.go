@@ -47,7 +47,7 @@ func TestReporterUnsubscribeInvalidInvocation(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -93,7 +93,7 @@ func TestReporterUnsubscribeValidatorNotFound(t *testing.T) {
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	snapshotManager := snapshot.NewManager(*logger, config, metricsManager)
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -142,7 +142,7 @@ func TestReporterUnsubscribeValidatorNotSubscribed(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	stateManager.SetValidators(types.ValidatorsMap{
@@ -195,7 +195,7 @@ func TestReporterUnsubscribeValidatorAlreadySubscribed(t *testing.T) {
 	database.SetClient(&databasePkg.StubDatabaseClient{})
 
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, snapshotManager, database)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, snapshotManager, nil)
 	reporter.Init()
 
 	stateManager.SetValidators(types.ValidatorsMap{
@@ -36,7 +36,7 @@ func TestReplyRenderFailedToRender(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -71,7 +71,7 @@ func TestReplyRenderFailedToSend(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{})
 	stateManager := statePkg.NewManager(*logger, config, metricsManager, nil, nil)
-	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil)
+	reporter := NewReporter(config, "1.2.3", *logger, stateManager, metricsManager, nil, nil)
 	reporter.Init()
 
 	ctx := reporter.TelegramBot.NewContext(tele.Update{
@@ -47,10 +47,10 @@ func (reporter *Reporter) HandleListValidators(c tele.Context) error {
 			link.Text = fmt.Sprintf("%s %s", group.EmojiEnd, v.Validator.Moniker)
 
 			return missingValidatorsEntry{
-				Validator:    v.Validator,
-				Link:         link,
-				NotSigned:    v.SignatureInfo.GetNotSigned(),
-				BlocksWindow: reporter.Config.BlocksWindow,
+				Validator: v.Validator,
+				Link:      link,
+				NotSigned: v.SignatureInfo.GetNotSigned(),
+				Config:    reporter.Config,
 			}
 		}),
 	}
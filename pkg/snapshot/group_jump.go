@@ -0,0 +1,41 @@
+package snapshot
+
+import (
+	"main/pkg/events"
+)
+
+// GroupJump is a validator's missed blocks counter moving by more than one missed blocks group
+// between two snapshots. It might be a real sudden outage, but it might also be caused by incomplete
+// blocks data, so it's only reported once it's confirmed by the next snapshot
+// or by the on-chain missed blocks counter.
+type GroupJump struct {
+	// Event is the event to report once the jump is confirmed.
+	Event       events.ValidatorGroupChanged
+	BeforeIndex int
+	AfterIndex  int
+}
+
+// IsConfirmedBy returns whether a validator being in a group with this index in the next snapshot
+// confirms the jump, which is if it's in the same group or has moved further in the same direction,
+// like when an outage keeps getting worse.
+func (j GroupJump) IsConfirmedBy(index int) bool {
+	if j.AfterIndex > j.BeforeIndex {
+		return index >= j.AfterIndex
+	}
+
+	return index <= j.AfterIndex
+}
+
+// DiscardedGroupJump is a group jump that was not confirmed by the next snapshot,
+// as a validator has moved back.
+type DiscardedGroupJump struct {
+	GroupJump
+	Reason string
+}
+
+// GroupJumps are unconfirmed group jumps by validator operator address and window.
+type GroupJumps map[string]GroupJump
+
+func GetGroupJumpKey(operatorAddress string, window string) string {
+	return operatorAddress + "/" + window
+}
@@ -42,6 +42,13 @@ func (m *Manager) CommitNewSnapshot(height int64, snapshot Snapshot) {
 	}
 }
 
+// CopyFrom takes snapshots from another manager, so a restarted chain
+// can generate a report without waiting for two new snapshots.
+func (m *Manager) CopyFrom(other *Manager) {
+	m.olderSnapshot = other.olderSnapshot
+	m.newerSnapshot = other.newerSnapshot
+}
+
 func (m *Manager) HasNewerSnapshot() bool {
 	return m.newerSnapshot != nil
 }
@@ -55,7 +62,31 @@ func (m *Manager) GetNewerHeight() int64 {
 }
 
 func (m *Manager) GetReport() (*types.Report, error) {
-	return m.newerSnapshot.Snapshot.GetReport(m.olderSnapshot.Snapshot, m.config)
+	report, err := m.newerSnapshot.Snapshot.GetReport(m.olderSnapshot.Snapshot, m.config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, jump := range m.newerSnapshot.Snapshot.GroupJumps {
+		m.logger.Info().
+			Str("valoper", jump.Event.Validator.OperatorAddress).
+			Str("window", jump.Event.GetWindow()).
+			Int64("missed_before", jump.Event.MissedBlocksBefore).
+			Int64("missed_after", jump.Event.MissedBlocksAfter).
+			Msg("Validator has moved by more than one missed blocks group, waiting for the next snapshot to confirm it")
+	}
+
+	for _, jump := range m.newerSnapshot.Snapshot.DiscardedGroupJumps {
+		m.logger.Warn().
+			Str("valoper", jump.Event.Validator.OperatorAddress).
+			Str("window", jump.Event.GetWindow()).
+			Int64("missed_before", jump.Event.MissedBlocksBefore).
+			Int64("missed_after", jump.Event.MissedBlocksAfter).
+			Str("reason", jump.Reason).
+			Msg("Discarding missed blocks group jump as anomalous")
+	}
+
+	return report, nil
 }
 
 func (m *Manager) GetNewerSnapshot() (*Snapshot, bool) {
@@ -57,6 +57,49 @@ func TestManagerCommitNewSnapshot(t *testing.T) {
 	assert.True(t, report.Empty(), "Report should be empty!")
 }
 
+func TestManagerGetReportGroupJumps(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetDefaultLogger()
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+			{Start: 100, End: 200},
+		},
+	}
+
+	metricsManager := metrics.NewManager(*log, configPkg.MetricsConfig{Enabled: null.BoolFrom(true)})
+	manager := NewManager(*log, config, metricsManager)
+
+	for height, missed := range []int64{0, 125, 1, 130, 135} {
+		manager.CommitNewSnapshot(int64(height), Snapshot{
+			Entries: types.Entries{
+				"validator": {
+					IsActive:      true,
+					NeedsToSign:   true,
+					Validator:     &types.Validator{OperatorAddress: "validator"},
+					SignatureInfo: types.SignatureInto{NotSigned: missed},
+				},
+			},
+		})
+
+		if height == 0 {
+			continue
+		}
+
+		report, err := manager.GetReport()
+		require.NoError(t, err, "Error should not be presented!")
+
+		// the jump to 125 is discarded, the jump to 130 is reported once it's confirmed
+		if height == 4 {
+			assert.Len(t, report.Events, 1, "Report should have 1 entry!")
+		} else {
+			assert.True(t, report.Empty(), "Report should be empty!")
+		}
+	}
+}
+
 func TestManagerGetNewerSnapshot(t *testing.T) {
 	t.Parallel()
 
@@ -86,3 +129,22 @@ func TestManagerGetNewerSnapshot(t *testing.T) {
 	assert.True(t, foundLater, "Snapshot should be presented!")
 	assert.NotNil(t, secondSnapshot, "Snapshot should be presented!")
 }
+
+func TestManagerCopyFrom(t *testing.T) {
+	t.Parallel()
+
+	log := logger.GetDefaultLogger()
+	config := &configPkg.ChainConfig{}
+	metricsManager := metrics.NewManager(*log, configPkg.MetricsConfig{Enabled: null.BoolFrom(true)})
+
+	manager := NewManager(*log, config, metricsManager)
+	manager.CommitNewSnapshot(10, Snapshot{Entries: types.Entries{}})
+	manager.CommitNewSnapshot(20, Snapshot{Entries: types.Entries{}})
+
+	otherManager := NewManager(*log, config, metricsManager)
+	otherManager.CopyFrom(manager)
+
+	assert.True(t, otherManager.HasNewerSnapshot(), "Should have newer snapshot!")
+	assert.Equal(t, int64(10), otherManager.GetOlderHeight(), "Height mismatch!")
+	assert.Equal(t, int64(20), otherManager.GetNewerHeight(), "Height mismatch!")
+}
@@ -1,6 +1,7 @@
 package snapshot
 
 import (
+	"fmt"
 	"main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/events"
@@ -14,6 +15,13 @@ import (
 
 type Snapshot struct {
 	Entries types.Entries
+
+	// GroupJumps are the group jumps compared to the older snapshot waiting to be confirmed
+	// by the next one, DiscardedGroupJumps are the older snapshot jumps that this one has not confirmed.
+	// Both are set when generating a report, GroupJumps are stored with the snapshot,
+	// so the jumps waiting to be confirmed are not lost on restart.
+	GroupJumps          GroupJumps
+	DiscardedGroupJumps []DiscardedGroupJump `json:"-"`
 }
 
 func (snapshot *Snapshot) GetReport(
@@ -22,6 +30,16 @@ func (snapshot *Snapshot) GetReport(
 ) (*types.Report, error) {
 	var entries []types.ReportEvent
 
+	snapshot.GroupJumps = GroupJumps{}
+	snapshot.DiscardedGroupJumps = nil
+
+	// calculated once they are needed, as groups are the same for all validators
+	var windowsGroups []config.MissedBlocksGroups
+
+	// snapshots stored before NeedsToSign was added have it unset for all validators,
+	// so whether validators were required to sign blocks there is unknown
+	compareSignatories := olderSnapshot.HasSignatories()
+
 	for valoper, entry := range snapshot.Entries {
 		olderEntry, ok := olderSnapshot.Entries[valoper]
 		if !ok {
@@ -45,8 +63,11 @@ func (snapshot *Snapshot) GetReport(
 		}
 
 		if entry.Validator.Jailed && !olderEntry.Validator.Jailed && olderEntry.IsActive {
+			jailedUntil, _ := entry.Validator.GetUnjailTime()
 			entries = append(entries, events.ValidatorJailed{
-				Validator: entry.Validator,
+				Validator:     entry.Validator,
+				JailedUntil:   jailedUntil,
+				SlashFraction: chainConfig.SlashFractionDowntime,
 			})
 		}
 
@@ -56,18 +77,32 @@ func (snapshot *Snapshot) GetReport(
 			})
 		}
 
-		if entry.IsActive && olderEntry.IsActive && entry.NeedsToSign && !olderEntry.NeedsToSign {
+		if compareSignatories && entry.IsActive && olderEntry.IsActive && entry.NeedsToSign && !olderEntry.NeedsToSign {
 			entries = append(entries, events.ValidatorJoinedSignatory{
 				Validator: entry.Validator,
 			})
 		}
 
-		if entry.IsActive && olderEntry.IsActive && !entry.NeedsToSign && olderEntry.NeedsToSign {
+		if compareSignatories && entry.IsActive && olderEntry.IsActive && !entry.NeedsToSign && olderEntry.NeedsToSign {
 			entries = append(entries, events.ValidatorLeftSignatory{
 				Validator: entry.Validator,
 			})
 		}
 
+		if entry.Validator.OptedIn.Valid && olderEntry.Validator.OptedIn.Valid {
+			if entry.Validator.OptedIn.Bool && !olderEntry.Validator.OptedIn.Bool {
+				entries = append(entries, events.ValidatorOptedIn{
+					Validator: entry.Validator,
+				})
+			}
+
+			if !entry.Validator.OptedIn.Bool && olderEntry.Validator.OptedIn.Bool {
+				entries = append(entries, events.ValidatorOptedOut{
+					Validator: entry.Validator,
+				})
+			}
+		}
+
 		if entry.IsActive && !olderEntry.IsActive {
 			entries = append(entries, events.ValidatorActive{
 				Validator: entry.Validator,
@@ -81,10 +116,7 @@ func (snapshot *Snapshot) GetReport(
 		}
 
 		if entry.Validator.ConsensusAddressValcons != olderEntry.Validator.ConsensusAddressValcons {
-			entries = append(entries, events.ValidatorChangedKey{
-				Validator:    entry.Validator,
-				OldValidator: olderEntry.Validator,
-			})
+			entries = append(entries, GetChangedKeyEvent(entry.Validator, olderEntry.Validator))
 		}
 
 		if entry.Validator.Moniker != olderEntry.Validator.Moniker {
@@ -102,37 +134,67 @@ func (snapshot *Snapshot) GetReport(
 		}
 
 		isTombstoned := hasNewerSigningInfo && entry.Validator.SigningInfo.Tombstoned
-		if isTombstoned || entry.Validator.Jailed || !entry.IsActive {
+		// validators that are not required to sign blocks (like bottom validators on consumer chains
+		// that are soft opted-out) are not alerted about missing blocks
+		if isTombstoned || entry.Validator.Jailed || !entry.IsActive || !entry.NeedsToSign {
 			continue
 		}
 
-		missedBlocksBefore := olderEntry.SignatureInfo.GetNotSigned()
-		missedBlocksAfter := entry.SignatureInfo.GetNotSigned()
+		// the on-chain missed blocks counter is for the slashing window, so it can confirm group jumps there
+		var onChainMissedBlocks *int64
+		if hasNewerSigningInfo {
+			onChainMissedBlocks = &entry.Validator.SigningInfo.MissedBlocksCounter
+		}
 
-		beforeGroup, beforeIndex, err := chainConfig.MissedBlocksGroups.GetGroup(missedBlocksBefore)
+		groupChanged, changed, err := snapshot.GetGroupChangedEvent(
+			olderSnapshot,
+			entry.Validator,
+			"",
+			chainConfig.MissedBlocksGroups,
+			olderEntry.SignatureInfo.GetNotSigned(),
+			entry.SignatureInfo.GetNotSigned(),
+			onChainMissedBlocks,
+		)
 		if err != nil {
 			return nil, err
 		}
-		afterGroup, afterIndex, err := chainConfig.MissedBlocksGroups.GetGroup(missedBlocksAfter)
-		if err != nil {
-			return nil, err
+
+		if changed {
+			entries = append(entries, groupChanged)
 		}
 
-		// To fix anomalies, like a validator jumping from 0 to 9500 missed blocks
-		if math.Abs(float64(beforeIndex-afterIndex)) > 1 {
-			continue
+		if windowsGroups == nil {
+			windowsGroups = make([]config.MissedBlocksGroups, len(chainConfig.SigningWindows))
+			for index, window := range chainConfig.SigningWindows {
+				windowsGroups[index] = window.GetMissedBlocksGroups()
+			}
 		}
 
-		missedBlocksGroupsEqual := beforeGroup.Start == afterGroup.Start
+		for index, window := range chainConfig.SigningWindows {
+			olderSignatureInfo, hasOlder := olderEntry.WindowsSignatureInfo[window.Name]
+			signatureInfo, hasNewer := entry.WindowsSignatureInfo[window.Name]
 
-		if !missedBlocksGroupsEqual && !entry.Validator.Jailed {
-			entries = append(entries, events.ValidatorGroupChanged{
-				Validator:               entry.Validator,
-				MissedBlocksBefore:      missedBlocksBefore,
-				MissedBlocksAfter:       missedBlocksAfter,
-				MissedBlocksGroupBefore: beforeGroup,
-				MissedBlocksGroupAfter:  afterGroup,
-			})
+			// the window might have been added after the older snapshot was taken
+			if !hasOlder || !hasNewer {
+				continue
+			}
+
+			windowGroupChanged, windowChanged, err := snapshot.GetGroupChangedEvent(
+				olderSnapshot,
+				entry.Validator,
+				window.Name,
+				windowsGroups[index],
+				olderSignatureInfo.GetNotSigned(),
+				signatureInfo.GetNotSigned(),
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if windowChanged {
+				entries = append(entries, windowGroupChanged)
+			}
 		}
 	}
 
@@ -154,6 +216,12 @@ func (snapshot *Snapshot) GetReport(
 				return utils.BoolToFloat64(firstConverted.IsIncreasing()) > utils.BoolToFloat64(secondConverted.IsIncreasing())
 			}
 
+			// the slashing window goes first, as missed blocks in different windows are not comparable
+			if firstConverted.Window != secondConverted.Window {
+				return firstConverted.IsSlashingWindow() ||
+					(!secondConverted.IsSlashingWindow() && firstConverted.Window < secondConverted.Window)
+			}
+
 			return firstConverted.MissedBlocksAfter > secondConverted.MissedBlocksAfter
 		}
 
@@ -166,7 +234,112 @@ func (snapshot *Snapshot) GetReport(
 	return &types.Report{Events: entries}, nil
 }
 
+// HasSignatories returns whether any validator in the snapshot is required to sign blocks.
+func (snapshot *Snapshot) HasSignatories() bool {
+	for _, entry := range snapshot.Entries {
+		if entry.NeedsToSign {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetGroupChangedEvent returns an event and true if a validator has moved to another missed blocks group
+// in a window (empty for the slashing window), and false if the group is the same. If a validator has moved
+// by more than one group, which might be caused by incomplete blocks data, it's reported only if it's
+// confirmed by the on-chain missed blocks counter, if it's known, otherwise it's kept in GroupJumps
+// to be confirmed by the next snapshot, unless the next snapshot moves it back.
+func (snapshot *Snapshot) GetGroupChangedEvent(
+	olderSnapshot Snapshot,
+	validator *types.Validator,
+	window string,
+	groups config.MissedBlocksGroups,
+	missedBlocksBefore int64,
+	missedBlocksAfter int64,
+	onChainMissedBlocks *int64,
+) (events.ValidatorGroupChanged, bool, error) {
+	afterGroup, afterIndex, err := groups.GetGroup(missedBlocksAfter)
+	if err != nil {
+		return events.ValidatorGroupChanged{}, false, err
+	}
+
+	jumpKey := GetGroupJumpKey(validator.OperatorAddress, window)
+
+	if jump, ok := olderSnapshot.GroupJumps[jumpKey]; ok {
+		if jump.IsConfirmedBy(afterIndex) {
+			jump.Event.Validator = validator
+			jump.Event.MissedBlocksAfter = missedBlocksAfter
+			jump.Event.MissedBlocksGroupAfter = afterGroup
+			return jump.Event, true, nil
+		}
+
+		snapshot.DiscardedGroupJumps = append(snapshot.DiscardedGroupJumps, DiscardedGroupJump{
+			GroupJump: jump,
+			Reason: fmt.Sprintf(
+				"not confirmed by the next snapshot, which has %d missed blocks",
+				missedBlocksAfter,
+			),
+		})
+
+		// the older snapshot data is not trusted, so comparing with the one before the jump
+		missedBlocksBefore = jump.Event.MissedBlocksBefore
+	}
+
+	beforeGroup, beforeIndex, err := groups.GetGroup(missedBlocksBefore)
+	if err != nil {
+		return events.ValidatorGroupChanged{}, false, err
+	}
+
+	if beforeIndex == afterIndex {
+		return events.ValidatorGroupChanged{}, false, nil
+	}
+
+	event := events.ValidatorGroupChanged{
+		Validator:               validator,
+		MissedBlocksBefore:      missedBlocksBefore,
+		MissedBlocksAfter:       missedBlocksAfter,
+		MissedBlocksGroupBefore: beforeGroup,
+		MissedBlocksGroupAfter:  afterGroup,
+		Window:                  window,
+	}
+
+	if math.Abs(float64(beforeIndex-afterIndex)) <= 1 {
+		return event, true, nil
+	}
+
+	if onChainMissedBlocks != nil {
+		if _, onChainIndex, err := groups.GetGroup(*onChainMissedBlocks); err == nil && onChainIndex == afterIndex {
+			return event, true, nil
+		}
+	}
+
+	snapshot.GroupJumps[jumpKey] = GroupJump{Event: event, BeforeIndex: beforeIndex, AfterIndex: afterIndex}
+	return events.ValidatorGroupChanged{}, false, nil
+}
+
 type Info struct {
 	Height   int64
 	Snapshot Snapshot
 }
+
+// GetChangedKeyEvent returns an event for a validator whose consensus address has changed.
+// On consumer chains, it's caused either by assigning, changing or removing a consumer key,
+// or by changing the key on the provider chain if no consumer key is assigned.
+func GetChangedKeyEvent(validator, oldValidator *types.Validator) types.ReportEvent {
+	// provider addresses are not set for sovereign chains, and for snapshots stored before they were added
+	if validator.ProviderConsensusAddressValcons == "" || oldValidator.ProviderConsensusAddressValcons == "" {
+		return events.ValidatorChangedKey{Validator: validator, OldValidator: oldValidator}
+	}
+
+	switch {
+	case validator.AssignedConsumerKey && !oldValidator.AssignedConsumerKey:
+		return events.ValidatorAssignedConsumerKey{Validator: validator}
+	case validator.AssignedConsumerKey && oldValidator.AssignedConsumerKey:
+		return events.ValidatorChangedConsumerKey{Validator: validator, OldValidator: oldValidator}
+	case !validator.AssignedConsumerKey && oldValidator.AssignedConsumerKey:
+		return events.ValidatorRemovedConsumerKey{Validator: validator, OldValidator: oldValidator}
+	default:
+		return events.ValidatorChangedKey{Validator: validator, OldValidator: oldValidator}
+	}
+}
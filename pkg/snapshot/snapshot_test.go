@@ -1,11 +1,16 @@
 package snapshot
 
 import (
+	"encoding/json"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
+	"main/pkg/events"
+	"main/pkg/utils"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"main/pkg/types"
 
@@ -39,6 +44,7 @@ func TestValidatorGroupChanged(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -46,6 +52,7 @@ func TestValidatorGroupChanged(t *testing.T) {
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 50},
 		},
@@ -58,6 +65,103 @@ func TestValidatorGroupChanged(t *testing.T) {
 	assert.Equal(t, constants.EventValidatorGroupChanged, report.Events[0].Type())
 }
 
+func TestValidatorGroupChangedInSigningWindows(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+		},
+		SigningWindows: []configPkg.SigningWindowConfig{
+			{
+				Name:        "short",
+				Blocks:      10,
+				Thresholds:  []float64{0, 10, 50, 100},
+				EmojisStart: []string{"x", "x", "x"},
+				EmojisEnd:   []string{"x", "x", "x"},
+			},
+			// not present in the older snapshot, so no events are generated
+			{
+				Name:        "medium",
+				Blocks:      20,
+				Thresholds:  []float64{0, 10, 50, 100},
+				EmojisStart: []string{"x", "x", "x"},
+				EmojisEnd:   []string{"x", "x", "x"},
+			},
+		},
+	}
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:             true,
+			NeedsToSign:          true,
+			Validator:            &types.Validator{},
+			SignatureInfo:        types.SignatureInto{NotSigned: 45},
+			WindowsSignatureInfo: map[string]types.SignatureInto{"short": {NotSigned: 0}},
+		},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{},
+			SignatureInfo: types.SignatureInto{NotSigned: 50},
+			WindowsSignatureInfo: map[string]types.SignatureInto{
+				"short":  {NotSigned: 3},
+				"medium": {NotSigned: 20},
+			},
+		},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, config)
+	require.NoError(t, err)
+	require.Len(t, report.Events, 2)
+
+	slashingEvent, ok := report.Events[0].(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	assert.Equal(t, constants.SlashingWindowName, slashingEvent.GetWindow())
+	assert.Equal(t, int64(50), slashingEvent.MissedBlocksAfter)
+
+	windowEvent, ok := report.Events[1].(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	assert.Equal(t, "short", windowEvent.GetWindow())
+	assert.Equal(t, int64(3), windowEvent.MissedBlocksAfter)
+	assert.Equal(t, "is skipping blocks (> 10.0% of last 10 blocks)", windowEvent.GetDescription())
+}
+
+func TestValidatorGroupChangedNotNeedsToSign(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+		},
+	}
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   false,
+			Validator:     &types.Validator{Jailed: false},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   false,
+			Validator:     &types.Validator{Jailed: false},
+			SignatureInfo: types.SignatureInto{NotSigned: 50},
+		},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+}
+
 func TestValidatorGroupChangedAnomaly(t *testing.T) {
 	t.Parallel()
 
@@ -72,6 +176,7 @@ func TestValidatorGroupChangedAnomaly(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -79,6 +184,7 @@ func TestValidatorGroupChangedAnomaly(t *testing.T) {
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 125},
 		},
@@ -87,6 +193,238 @@ func TestValidatorGroupChangedAnomaly(t *testing.T) {
 	report, err := newerSnapshot.GetReport(olderSnapshot, config)
 	require.NoError(t, err)
 	assert.Empty(t, report.Events)
+	assert.Len(t, newerSnapshot.GroupJumps, 1)
+}
+
+func TestValidatorGroupJumpConfirmedByNextSnapshot(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+			{Start: 100, End: 200},
+		},
+	}
+
+	getSnapshot := func(missed int64) *Snapshot {
+		return &Snapshot{Entries: types.Entries{
+			"validator": {
+				IsActive:      true,
+				NeedsToSign:   true,
+				Validator:     &types.Validator{OperatorAddress: "validator"},
+				SignatureInfo: types.SignatureInto{NotSigned: missed},
+			},
+		}}
+	}
+
+	firstSnapshot := getSnapshot(0)
+	secondSnapshot := getSnapshot(125)
+	thirdSnapshot := getSnapshot(130)
+
+	report, err := secondSnapshot.GetReport(*firstSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+	require.Contains(t, secondSnapshot.GroupJumps, GetGroupJumpKey("validator", ""))
+
+	report, err = thirdSnapshot.GetReport(*secondSnapshot, config)
+	require.NoError(t, err)
+	require.Len(t, report.Events, 1)
+	assert.Empty(t, thirdSnapshot.GroupJumps)
+	assert.Empty(t, thirdSnapshot.DiscardedGroupJumps)
+
+	event, ok := report.Events[0].(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	assert.Equal(t, int64(0), event.MissedBlocksBefore)
+	assert.Equal(t, int64(130), event.MissedBlocksAfter)
+	assert.Equal(t, int64(0), event.MissedBlocksGroupBefore.Start)
+	assert.Equal(t, int64(100), event.MissedBlocksGroupAfter.Start)
+}
+
+func TestValidatorGroupJumpConfirmedByContinuingOutage(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+			{Start: 100, End: 149},
+			{Start: 150, End: 200},
+		},
+	}
+
+	getSnapshot := func(missed int64) *Snapshot {
+		return &Snapshot{Entries: types.Entries{
+			"validator": {
+				IsActive:      true,
+				NeedsToSign:   true,
+				Validator:     &types.Validator{OperatorAddress: "validator"},
+				SignatureInfo: types.SignatureInto{NotSigned: missed},
+			},
+		}}
+	}
+
+	firstSnapshot := getSnapshot(0)
+	secondSnapshot := getSnapshot(125)
+	thirdSnapshot := getSnapshot(175)
+
+	report, err := secondSnapshot.GetReport(*firstSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+
+	// the outage got worse and the validator has moved even further, which confirms the jump
+	report, err = thirdSnapshot.GetReport(*secondSnapshot, config)
+	require.NoError(t, err)
+	require.Len(t, report.Events, 1)
+	assert.Empty(t, thirdSnapshot.GroupJumps)
+	assert.Empty(t, thirdSnapshot.DiscardedGroupJumps)
+
+	event, ok := report.Events[0].(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	assert.Equal(t, int64(0), event.MissedBlocksBefore)
+	assert.Equal(t, int64(175), event.MissedBlocksAfter)
+	assert.Equal(t, int64(0), event.MissedBlocksGroupBefore.Start)
+	assert.Equal(t, int64(150), event.MissedBlocksGroupAfter.Start)
+}
+
+func TestValidatorGroupJumpConfirmedAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+			{Start: 100, End: 200},
+		},
+	}
+
+	getSnapshot := func(missed int64) *Snapshot {
+		return &Snapshot{Entries: types.Entries{
+			"validator": {
+				IsActive:      true,
+				NeedsToSign:   true,
+				Validator:     &types.Validator{OperatorAddress: "validator"},
+				SignatureInfo: types.SignatureInto{NotSigned: missed},
+			},
+		}}
+	}
+
+	firstSnapshot := getSnapshot(0)
+	secondSnapshot := getSnapshot(125)
+
+	report, err := secondSnapshot.GetReport(*firstSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+
+	// as if the app was restarted and the snapshot was loaded from the database
+	var loadedSnapshot Info
+	require.NoError(t, json.Unmarshal(utils.MustJSONMarshall(Info{Height: 1, Snapshot: *secondSnapshot}), &loadedSnapshot))
+	require.Len(t, loadedSnapshot.Snapshot.GroupJumps, 1)
+
+	thirdSnapshot := getSnapshot(130)
+	report, err = thirdSnapshot.GetReport(loadedSnapshot.Snapshot, config)
+	require.NoError(t, err)
+	require.Len(t, report.Events, 1)
+
+	event, ok := report.Events[0].(events.ValidatorGroupChanged)
+	require.True(t, ok)
+	assert.Equal(t, int64(0), event.MissedBlocksBefore)
+	assert.Equal(t, int64(130), event.MissedBlocksAfter)
+	assert.Equal(t, int64(0), event.MissedBlocksGroupBefore.Start)
+	assert.Equal(t, int64(100), event.MissedBlocksGroupAfter.Start)
+}
+
+func TestGroupJumpIsConfirmedBy(t *testing.T) {
+	t.Parallel()
+
+	up := GroupJump{BeforeIndex: 0, AfterIndex: 2}
+	assert.True(t, up.IsConfirmedBy(2))
+	assert.True(t, up.IsConfirmedBy(3))
+	assert.False(t, up.IsConfirmedBy(1))
+
+	down := GroupJump{BeforeIndex: 3, AfterIndex: 1}
+	assert.True(t, down.IsConfirmedBy(1))
+	assert.True(t, down.IsConfirmedBy(0))
+	assert.False(t, down.IsConfirmedBy(2))
+}
+
+func TestValidatorGroupJumpDiscarded(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+			{Start: 100, End: 200},
+		},
+	}
+
+	getSnapshot := func(missed int64) *Snapshot {
+		return &Snapshot{Entries: types.Entries{
+			"validator": {
+				IsActive:      true,
+				NeedsToSign:   true,
+				Validator:     &types.Validator{OperatorAddress: "validator"},
+				SignatureInfo: types.SignatureInto{NotSigned: missed},
+			},
+		}}
+	}
+
+	firstSnapshot := getSnapshot(0)
+	secondSnapshot := getSnapshot(125)
+	thirdSnapshot := getSnapshot(1)
+
+	report, err := secondSnapshot.GetReport(*firstSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+
+	// compared with the snapshot before the jump, so the validator has not changed its group
+	report, err = thirdSnapshot.GetReport(*secondSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+	assert.Empty(t, thirdSnapshot.GroupJumps)
+	require.Len(t, thirdSnapshot.DiscardedGroupJumps, 1)
+	assert.Equal(
+		t,
+		"not confirmed by the next snapshot, which has 1 missed blocks",
+		thirdSnapshot.DiscardedGroupJumps[0].Reason,
+	)
+	assert.Equal(t, int64(125), thirdSnapshot.DiscardedGroupJumps[0].Event.MissedBlocksAfter)
+}
+
+func TestValidatorGroupJumpConfirmedOnChain(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+			{Start: 100, End: 200},
+		},
+	}
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{SigningInfo: &types.SigningInfo{MissedBlocksCounter: 0}},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{SigningInfo: &types.SigningInfo{MissedBlocksCounter: 120}},
+			SignatureInfo: types.SignatureInto{NotSigned: 125},
+		},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, config)
+	require.NoError(t, err)
+	require.Len(t, report.Events, 1)
+	assert.Equal(t, constants.EventValidatorGroupChanged, report.Events[0].Type())
+	assert.Empty(t, newerSnapshot.GroupJumps)
 }
 
 func TestValidatorTombstoned(t *testing.T) {
@@ -133,6 +471,7 @@ func TestValidatorJailed(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -160,6 +499,53 @@ func TestValidatorJailed(t *testing.T) {
 	assert.Contains(t, entriesTypes, constants.EventValidatorInactive)
 }
 
+func TestValidatorJailedWithDetails(t *testing.T) {
+	t.Parallel()
+
+	jailedUntil := time.Unix(1700000000, 0)
+
+	config := &configPkg.ChainConfig{
+		SlashFractionDowntime: 0.0001,
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+		},
+	}
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{Jailed: false},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive: false,
+			Validator: &types.Validator{
+				Jailed:      true,
+				SigningInfo: &types.SigningInfo{JailedUntil: jailedUntil},
+			},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, config)
+	require.NoError(t, err)
+	require.Len(t, report.Events, 2)
+
+	jailedEvents := utils.Filter(report.Events, func(event types.ReportEvent) bool {
+		return event.Type() == constants.EventValidatorJailed
+	})
+	require.Len(t, jailedEvents, 1)
+
+	jailedEvent, ok := jailedEvents[0].(events.ValidatorJailed)
+	require.True(t, ok)
+	assert.Equal(t, jailedUntil, jailedEvent.JailedUntil)
+	assert.InDelta(t, 0.0001, jailedEvent.SlashFraction, 0.000001)
+}
+
 func TestValidatorUnjailed(t *testing.T) {
 	t.Parallel()
 
@@ -208,6 +594,12 @@ func TestValidatorJoinedSignatory(t *testing.T) {
 			Validator:     &types.Validator{},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
+		"signatory": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
 	}}
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
@@ -216,6 +608,12 @@ func TestValidatorJoinedSignatory(t *testing.T) {
 			Validator:     &types.Validator{},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
+		"signatory": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
 	}}
 
 	report, err := newerSnapshot.GetReport(olderSnapshot, config)
@@ -226,6 +624,38 @@ func TestValidatorJoinedSignatory(t *testing.T) {
 	assert.Equal(t, constants.EventValidatorJoinedSignatory, report.Events[0].Type())
 }
 
+func TestValidatorSignatoryOldSnapshot(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.ChainConfig{
+		MissedBlocksGroups: []*configPkg.MissedBlocksGroup{
+			{Start: 0, End: 49},
+			{Start: 50, End: 99},
+		},
+	}
+
+	// stored before NeedsToSign was added
+	var olderSnapshot Snapshot
+	require.NoError(t, json.Unmarshal([]byte(`{"Entries":{"validator":{
+		"IsActive":true,
+		"Validator":{"OperatorAddress":"validator"},
+		"SignatureInfo":{"NotSigned":0}
+	}}}`), &olderSnapshot))
+
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {
+			IsActive:      true,
+			NeedsToSign:   true,
+			Validator:     &types.Validator{OperatorAddress: "validator"},
+			SignatureInfo: types.SignatureInto{NotSigned: 0},
+		},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, config)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+}
+
 func TestValidatorLeftSignatory(t *testing.T) {
 	t.Parallel()
 
@@ -274,6 +704,7 @@ func TestValidatorInactive(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -309,6 +740,99 @@ func TestValidatorChangedKey(t *testing.T) {
 	assert.Equal(t, constants.EventValidatorChangedKey, report.Events[0].Type())
 }
 
+func TestValidatorOptedIn(t *testing.T) {
+	t.Parallel()
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {Validator: &types.Validator{OptedIn: null.BoolFrom(false)}},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {Validator: &types.Validator{OptedIn: null.BoolFrom(true)}},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, nil)
+	require.NoError(t, err)
+	assert.Len(t, report.Events, 1)
+	assert.Equal(t, constants.EventValidatorOptedIn, report.Events[0].Type())
+}
+
+func TestValidatorOptedOut(t *testing.T) {
+	t.Parallel()
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {Validator: &types.Validator{OptedIn: null.BoolFrom(true)}},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {Validator: &types.Validator{OptedIn: null.BoolFrom(false)}},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, nil)
+	require.NoError(t, err)
+	assert.Len(t, report.Events, 1)
+	assert.Equal(t, constants.EventValidatorOptedOut, report.Events[0].Type())
+}
+
+func TestValidatorOptedInUnknown(t *testing.T) {
+	t.Parallel()
+
+	olderSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {Validator: &types.Validator{}},
+	}}
+	newerSnapshot := Snapshot{Entries: types.Entries{
+		"validator": {Validator: &types.Validator{OptedIn: null.BoolFrom(true)}},
+	}}
+
+	report, err := newerSnapshot.GetReport(olderSnapshot, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Events)
+}
+
+func TestValidatorConsumerKeyChanges(t *testing.T) {
+	t.Parallel()
+
+	sovereign := &types.Validator{ConsensusAddressValcons: "key1"}
+	withoutConsumerKey := &types.Validator{
+		ConsensusAddressValcons:         "provider",
+		ProviderConsensusAddressValcons: "provider",
+	}
+	withConsumerKey := &types.Validator{
+		ConsensusAddressValcons:         "consumer1",
+		ProviderConsensusAddressValcons: "provider",
+		AssignedConsumerKey:             true,
+	}
+	withNewConsumerKey := &types.Validator{
+		ConsensusAddressValcons:         "consumer2",
+		ProviderConsensusAddressValcons: "provider",
+		AssignedConsumerKey:             true,
+	}
+	withNewProviderKey := &types.Validator{
+		ConsensusAddressValcons:         "provider2",
+		ProviderConsensusAddressValcons: "provider2",
+	}
+
+	testCases := []struct {
+		older     *types.Validator
+		newer     *types.Validator
+		eventName constants.EventName
+	}{
+		{older: sovereign, newer: withConsumerKey, eventName: constants.EventValidatorChangedKey},
+		{older: withoutConsumerKey, newer: withConsumerKey, eventName: constants.EventValidatorAssignedConsumerKey},
+		{older: withConsumerKey, newer: withNewConsumerKey, eventName: constants.EventValidatorChangedConsumerKey},
+		{older: withConsumerKey, newer: withoutConsumerKey, eventName: constants.EventValidatorRemovedConsumerKey},
+		{older: withoutConsumerKey, newer: withNewProviderKey, eventName: constants.EventValidatorChangedKey},
+	}
+
+	for _, testCase := range testCases {
+		olderSnapshot := Snapshot{Entries: types.Entries{"validator": {Validator: testCase.older}}}
+		newerSnapshot := Snapshot{Entries: types.Entries{"validator": {Validator: testCase.newer}}}
+
+		report, err := newerSnapshot.GetReport(olderSnapshot, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Events, 1)
+		require.Equal(t, testCase.eventName, report.Events[0].Type())
+	}
+}
+
 func TestValidatorChangedMoniker(t *testing.T) {
 	t.Parallel()
 
@@ -361,6 +885,7 @@ func TestValidatorActive(t *testing.T) {
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -445,6 +970,7 @@ func TestNewMissedBlocksGroupNotPresent(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -452,6 +978,7 @@ func TestNewMissedBlocksGroupNotPresent(t *testing.T) {
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 150},
 		},
@@ -475,6 +1002,7 @@ func TestOldMissedBlocksGroupNotPresent(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 150},
 		},
@@ -482,6 +1010,7 @@ func TestOldMissedBlocksGroupNotPresent(t *testing.T) {
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 0},
 		},
@@ -505,16 +1034,19 @@ func TestSorting(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator1": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
 		"validator2": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
 		"validator3": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false, SigningInfo: &types.SigningInfo{Tombstoned: false}},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
@@ -522,16 +1054,19 @@ func TestSorting(t *testing.T) {
 	newerSnapshot := Snapshot{Entries: types.Entries{
 		"validator1": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: true},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
 		"validator2": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false},
 			SignatureInfo: types.SignatureInto{NotSigned: 75},
 		},
 		"validator3": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{Jailed: false, SigningInfo: &types.SigningInfo{Tombstoned: true}},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
@@ -560,21 +1095,25 @@ func TestSortingMissedBlocksGroups(t *testing.T) {
 	olderSnapshot := Snapshot{Entries: types.Entries{
 		"validator1": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator1"},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
 		"validator2": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator2"},
 			SignatureInfo: types.SignatureInto{NotSigned: 75},
 		},
 		"validator3": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator3"},
 			SignatureInfo: types.SignatureInto{NotSigned: 125},
 		},
 		"validator4": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator4"},
 			SignatureInfo: types.SignatureInto{NotSigned: 75},
 		},
@@ -583,24 +1122,28 @@ func TestSortingMissedBlocksGroups(t *testing.T) {
 		// skipping blocks: 25 -> 75
 		"validator1": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator1"},
 			SignatureInfo: types.SignatureInto{NotSigned: 75},
 		},
 		// skipping blocks: 75 -> 125
 		"validator2": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator2"},
 			SignatureInfo: types.SignatureInto{NotSigned: 125},
 		},
 		// recovering: 125 -> 75
 		"validator3": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator3"},
 			SignatureInfo: types.SignatureInto{NotSigned: 75},
 		},
 		// recovering: 75 -> 25
 		"validator4": {
 			IsActive:      true,
+			NeedsToSign:   true,
 			Validator:     &types.Validator{OperatorAddress: "validator4"},
 			SignatureInfo: types.SignatureInto{NotSigned: 25},
 		},
@@ -4,17 +4,22 @@ import (
 	"main/pkg/types"
 	"main/pkg/utils"
 	"sync"
+	"time"
 )
 
+// Blocks keeps heights and times of all the stored blocks, while full blocks
+// are only kept for the recent heights and are loaded from the database when needed.
 type Blocks struct {
 	mutex      sync.RWMutex
 	blocks     types.BlocksMap
+	times      map[int64]time.Time
 	lastHeight int64
 }
 
 func NewBlocks() *Blocks {
 	return &Blocks{
 		blocks:     make(types.BlocksMap),
+		times:      make(map[int64]time.Time),
 		lastHeight: 0,
 	}
 }
@@ -24,6 +29,7 @@ func (b *Blocks) AddBlock(block *types.Block) {
 	defer b.mutex.Unlock()
 
 	b.blocks[block.Height] = block
+	b.times[block.Height] = block.Time
 
 	if block.Height > b.lastHeight {
 		b.lastHeight = block.Height
@@ -34,7 +40,7 @@ func (b *Blocks) HasBlockAtHeight(height int64) bool {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	_, ok := b.blocks[height]
+	_, ok := b.times[height]
 	return ok
 }
 
@@ -42,8 +48,33 @@ func (b *Blocks) TrimBefore(trimHeight int64) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	for height := range b.blocks {
+	for height := range b.times {
 		if height <= trimHeight {
+			delete(b.times, height)
+			delete(b.blocks, height)
+		}
+	}
+}
+
+// RemoveBlocks removes blocks at the heights, so they are fetched again.
+func (b *Blocks) RemoveBlocks(heights []int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, height := range heights {
+		delete(b.times, height)
+		delete(b.blocks, height)
+	}
+}
+
+// EvictBefore removes full blocks at or before the height from memory,
+// keeping their heights and times.
+func (b *Blocks) EvictBefore(evictHeight int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for height := range b.blocks {
+		if height <= evictHeight {
 			delete(b.blocks, height)
 		}
 	}
@@ -54,10 +85,27 @@ func (b *Blocks) SetBlocks(blocks map[int64]*types.Block) {
 	defer b.mutex.Unlock()
 
 	b.blocks = blocks
+	b.times = make(map[int64]time.Time, len(blocks))
+
+	for height, block := range blocks {
+		b.times[height] = block.Time
+	}
+
+	b.setLastHeight()
+}
+
+func (b *Blocks) SetTimes(times map[int64]time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.times = times
+	b.setLastHeight()
+}
 
+func (b *Blocks) setLastHeight() {
 	var lastHeight int64 = 0
 
-	for height := range b.blocks {
+	for height := range b.times {
 		if height > lastHeight {
 			lastHeight = height
 		}
@@ -74,6 +122,32 @@ func (b *Blocks) GetBlock(height int64) (*types.Block, bool) {
 	return block, ok
 }
 
+// GetHeightsInRange returns heights of stored blocks in the [fromHeight, toHeight] range.
+func (b *Blocks) GetHeightsInRange(fromHeight, toHeight int64) []int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	heights := make([]int64, 0)
+
+	if toHeight-fromHeight+1 > int64(len(b.times)) {
+		for height := range b.times {
+			if height >= fromHeight && height <= toHeight {
+				heights = append(heights, height)
+			}
+		}
+
+		return heights
+	}
+
+	for height := fromHeight; height <= toHeight; height++ {
+		if _, ok := b.times[height]; ok {
+			heights = append(heights, height)
+		}
+	}
+
+	return heights
+}
+
 func (b *Blocks) GetLatestBlock() *types.Block {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
@@ -81,19 +155,30 @@ func (b *Blocks) GetLatestBlock() *types.Block {
 	return b.blocks[b.lastHeight]
 }
 
+// GetEarliestBlock returns the earliest stored block. If it's not kept in memory,
+// only its height and time are returned.
 func (b *Blocks) GetEarliestBlock() *types.Block {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
 	earliestHeight := b.lastHeight
 
-	for height := range b.blocks {
+	for height := range b.times {
 		if height < earliestHeight {
 			earliestHeight = height
 		}
 	}
 
-	return b.blocks[earliestHeight]
+	if block, ok := b.blocks[earliestHeight]; ok {
+		return block
+	}
+
+	blockTime, ok := b.times[earliestHeight]
+	if !ok {
+		return nil
+	}
+
+	return &types.Block{Height: earliestHeight, Time: blockTime}
 }
 
 func (b *Blocks) GetCountSinceLatest(expected int64) int64 {
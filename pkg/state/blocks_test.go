@@ -3,6 +3,7 @@ package state
 import (
 	"main/pkg/types"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -141,3 +142,37 @@ func TestBlocksTrimBlocksBefore(t *testing.T) {
 	assert.True(t, state.HasBlockAtHeight(4), "Blocks mismatch!")
 	assert.True(t, state.HasBlockAtHeight(5), "Blocks mismatch!")
 }
+
+func TestBlocksEvictBefore(t *testing.T) {
+	t.Parallel()
+
+	blocks := NewBlocks()
+	blocks.AddBlock(&types.Block{Height: 1})
+	blocks.AddBlock(&types.Block{Height: 2})
+	blocks.AddBlock(&types.Block{Height: 3})
+
+	blocks.EvictBefore(2)
+
+	_, found := blocks.GetBlock(2)
+	assert.False(t, found, "Block should be evicted!")
+	assert.True(t, blocks.HasBlockAtHeight(2), "Block height should be kept!")
+
+	earliest := blocks.GetEarliestBlock()
+	assert.Equal(t, int64(1), earliest.Height, "Blocks mismatch!")
+}
+
+func TestBlocksSetTimes(t *testing.T) {
+	t.Parallel()
+
+	blocks := NewBlocks()
+	blocks.SetTimes(map[int64]time.Time{
+		1: time.Unix(1, 0),
+		5: time.Unix(5, 0),
+		7: time.Unix(7, 0),
+	})
+
+	assert.Equal(t, int64(7), blocks.lastHeight, "Height mismatch!")
+	assert.Nil(t, blocks.GetLatestBlock(), "Block should not be in memory!")
+	assert.Equal(t, time.Unix(1, 0), blocks.GetEarliestBlock().Time, "Time mismatch!")
+	assert.Equal(t, []int64{5}, blocks.GetHeightsInRange(2, 6), "Heights mismatch!")
+}
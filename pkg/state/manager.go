@@ -1,6 +1,9 @@
 package state
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	databasePkg "main/pkg/database"
@@ -31,7 +34,7 @@ func NewManager(
 	snapshotManager *snapshotPkg.Manager,
 	database *databasePkg.Database,
 ) *Manager {
-	return &Manager{
+	manager := &Manager{
 		logger:          logger.With().Str("component", "state_manager").Logger(),
 		config:          chainConfig,
 		metricsManager:  metricsManager,
@@ -39,22 +42,53 @@ func NewManager(
 		state:           NewState(),
 		database:        database,
 	}
+
+	if database != nil {
+		manager.state.SetBlocksLoader(manager)
+	}
+
+	return manager
 }
 
 func (m *Manager) Init() {
 	blocksStart := time.Now()
 
-	blocks, err := m.database.GetAllBlocks(m.config.Name)
+	times, err := m.database.GetBlocksTimes(m.config.Name)
 	if err != nil {
-		m.logger.Fatal().Err(err).Msg("Could not get blocks from the database")
+		m.logger.Fatal().Err(err).Msg("Could not get blocks times from the database")
 	}
 
-	m.state.SetBlocks(blocks)
+	m.state.SetBlocksTimes(times)
+
+	lastHeight := m.state.GetLastBlockHeight()
+	blocks, err := m.database.GetBlocksInRange(
+		m.config.Name,
+		lastHeight-constants.StateRecentBlocksCount,
+		lastHeight,
+	)
+	if err != nil {
+		m.logger.Fatal().Err(err).Msg("Could not get recent blocks from the database")
+	}
+
+	m.state.CacheBlocks(blocks)
 	m.logger.Info().
-		Int("len", len(blocks)).
+		Int("len", len(times)).
+		Int("recent", len(blocks)).
 		Float64("duration", time.Since(blocksStart).Seconds()).
 		Msg("Loaded older blocks from database")
 
+	windowStart := time.Now()
+
+	m.LoadWindow()
+	if err := m.state.UpdateWindow(m.GetBlocksToCheck()); err != nil {
+		m.logger.Error().Err(err).Msg("Could not update signatures window")
+	} else {
+		m.SaveWindow()
+		m.logger.Info().
+			Float64("duration", time.Since(windowStart).Seconds()).
+			Msg("Loaded signatures window")
+	}
+
 	notifiersStart := time.Now()
 
 	notifiers, err := m.database.GetAllNotifiers(m.config.Name)
@@ -68,6 +102,11 @@ func (m *Manager) Init() {
 		Float64("duration", time.Since(notifiersStart).Seconds()).
 		Msg("Loaded notifiers from database")
 
+	if m.snapshotManager.HasNewerSnapshot() {
+		m.logger.Info().Msg("Snapshot is already present, not loading it from database")
+		return
+	}
+
 	snapshotStart := time.Now()
 
 	snapshot, err := m.database.GetLastSnapshot(m.config.Name)
@@ -81,6 +120,119 @@ func (m *Manager) Init() {
 	}
 }
 
+// LoadWindow loads the persisted signatures window, so it does not need
+// to be counted from scratch. If it's outdated, it's ignored.
+func (m *Manager) LoadWindow() {
+	rawWindow, err := m.database.GetValueByKey(m.config.Name, constants.SignaturesWindowKey)
+	if err != nil {
+		m.logger.Info().Err(err).Msg("Could not get signatures window from the database, counting from scratch")
+		return
+	}
+
+	window := NewSignaturesWindow(0, 0)
+	if err := json.Unmarshal(rawWindow, window); err != nil {
+		m.logger.Warn().Err(err).Msg("Could not unmarshal signatures window, counting from scratch")
+		return
+	}
+
+	if !m.state.IsWindowValid(window) {
+		m.logger.Warn().
+			Int64("start", window.Start).
+			Int64("end", window.End).
+			Msg("Signatures window is outdated, counting from scratch")
+		return
+	}
+
+	m.state.SetWindow(window)
+}
+
+func (m *Manager) SaveWindow() {
+	window := m.state.GetWindow()
+
+	if err := m.database.SetValueByKey(
+		m.config.Name,
+		constants.SignaturesWindowKey,
+		utils.MustJSONMarshall(window),
+	); err != nil {
+		m.logger.Warn().Err(err).Msg("Could not save signatures window")
+	}
+}
+
+// LoadUnjailReminders loads when jailed validators were reminded about unjailing,
+// so the reminders are not sent again after a restart.
+func (m *Manager) LoadUnjailReminders() (types.UnjailReminders, error) {
+	reminders := types.UnjailReminders{}
+
+	rawReminders, err := m.database.GetValueByKey(m.config.Name, constants.UnjailRemindersKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return reminders, nil
+	} else if err != nil {
+		return reminders, err
+	}
+
+	if err := json.Unmarshal(rawReminders, &reminders); err != nil {
+		m.logger.Warn().Err(err).Msg("Could not unmarshal unjail reminders, ignoring them")
+		return types.UnjailReminders{}, nil
+	}
+
+	return reminders, nil
+}
+
+func (m *Manager) SaveUnjailReminders(reminders types.UnjailReminders) {
+	if err := m.database.SetValueByKey(
+		m.config.Name,
+		constants.UnjailRemindersKey,
+		utils.MustJSONMarshall(reminders),
+	); err != nil {
+		m.logger.Warn().Err(err).Msg("Could not save unjail reminders")
+	}
+}
+
+// LoadSlashingParams loads the slashing params last fetched from the chain,
+// so a change while the app was stopped is noticed. Returns false if none were stored.
+func (m *Manager) LoadSlashingParams() (types.SlashingParams, bool, error) {
+	var params types.SlashingParams
+
+	rawParams, err := m.database.GetValueByKey(m.config.Name, constants.SlashingParamsKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return params, false, nil
+	} else if err != nil {
+		return params, false, err
+	}
+
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		m.logger.Warn().Err(err).Msg("Could not unmarshal slashing params, ignoring them")
+		return types.SlashingParams{}, false, nil
+	}
+
+	return params, true, nil
+}
+
+func (m *Manager) SaveSlashingParams(params types.SlashingParams) {
+	if err := m.database.SetValueByKey(
+		m.config.Name,
+		constants.SlashingParamsKey,
+		utils.MustJSONMarshall(params),
+	); err != nil {
+		m.logger.Warn().Err(err).Msg("Could not save slashing params")
+	}
+}
+
+// GetBlocksInRange implements BlocksLoader.
+func (m *Manager) GetBlocksInRange(fromHeight, toHeight int64) (map[int64]*types.Block, error) {
+	return m.database.GetBlocksInRange(m.config.Name, fromHeight, toHeight)
+}
+
+func (m *Manager) GetBlocksToCheck() int64 {
+	return m.GetWindowBlocksToCheck(m.config.BlocksWindow)
+}
+
+// GetWindowBlocksToCheck returns how many last blocks a window of the given size
+// can cover, as there might be fewer blocks since the first block.
+func (m *Manager) GetWindowBlocksToCheck(windowBlocks int64) int64 {
+	return utils.MinInt64(windowBlocks, m.GetLastBlockHeight()-m.config.FirstBlock-1)
+}
+
 func (m *Manager) GetLastBlockHeight() int64 {
 	return m.state.GetLastBlockHeight()
 }
@@ -99,11 +251,38 @@ func (m *Manager) AddBlock(block *types.Block) error {
 		return err
 	}
 
+	if err := m.database.InsertMissedBlocks(m.config.Name, block); err != nil {
+		return err
+	}
+
+	if lastBlock := m.state.GetLastBlockHeight(); lastBlock == block.Height {
+		if err := m.state.UpdateWindow(m.GetBlocksToCheck()); err != nil {
+			return err
+		}
+
+		m.SaveWindow()
+	}
+
 	m.metricsManager.LogTotalBlocksAmount(m.config.Name, m.GetBlocksCountSinceLatest(m.config.StoreBlocks))
 
 	return nil
 }
 
+// ReplayBlock adds a block to the state without storing it, keeping only the blocks
+// within the signing windows, used to replay the stored blocks.
+func (m *Manager) ReplayBlock(block *types.Block) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	keepBlocks := m.config.BlocksWindow
+	for _, window := range m.config.SigningWindows {
+		keepBlocks = max(keepBlocks, window.Blocks)
+	}
+
+	m.state.AddBlock(block)
+	return m.state.TrimBlocksBefore(m.state.GetLastBlockHeight() - keepBlocks)
+}
+
 func (m *Manager) TrimBlocks() error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -114,11 +293,40 @@ func (m *Manager) TrimBlocks() error {
 		Int64("trim_height", trimHeight).
 		Msg("Need to trim blocks")
 
-	m.state.TrimBlocksBefore(trimHeight)
+	stateErr := m.state.TrimBlocksBefore(trimHeight)
 	if err := m.database.TrimBlocksBefore(m.config.Name, trimHeight); err != nil {
 		return err
 	}
 
+	if stateErr != nil {
+		return stateErr
+	}
+
+	m.SaveWindow()
+	return nil
+}
+
+// RemoveBlocks removes the stored blocks at the heights, so they are fetched again
+// when populating blocks. The latest block is kept, as the state relies on it.
+func (m *Manager) RemoveBlocks(heights []int64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	lastHeight := m.state.GetLastBlockHeight()
+	heights = utils.Filter(heights, func(height int64) bool {
+		return height != lastHeight
+	})
+
+	stateErr := m.state.RemoveBlocks(heights)
+	if err := m.database.DeleteBlocks(m.config.Name, heights); err != nil {
+		return err
+	}
+
+	if stateErr != nil {
+		return stateErr
+	}
+
+	m.SaveWindow()
 	return nil
 }
 
@@ -140,7 +348,7 @@ func (m *Manager) GetSnapshot() (snapshotPkg.Snapshot, error) {
 	validators := m.state.GetValidators()
 	entries := make(types.Entries, len(validators))
 
-	neededBlocks := utils.MinInt64(m.config.BlocksWindow, m.GetLastBlockHeight()-m.config.FirstBlock-1)
+	neededBlocks := m.GetBlocksToCheck()
 
 	for _, validator := range validators {
 		// Taking the active status from the last block, as there might be a case
@@ -156,14 +364,31 @@ func (m *Manager) GetSnapshot() (snapshotPkg.Snapshot, error) {
 			return snapshotPkg.Snapshot{}, err
 		}
 
+		windowsSignatureInfo := make(map[string]types.SignatureInto, len(m.config.SigningWindows))
+
+		for _, window := range m.config.SigningWindows {
+			windowSignatureInfo, err := m.state.GetValidatorMissedBlocksInWindow(
+				window.Name,
+				validator,
+				m.GetWindowBlocksToCheck(window.Blocks),
+			)
+			if err != nil {
+				return snapshotPkg.Snapshot{}, err
+			}
+
+			windowsSignatureInfo[window.Name] = windowSignatureInfo
+		}
+
 		entries[validator.OperatorAddress] = &types.Entry{
-			IsActive:      isActiveAtLastBlock,
-			Validator:     validator,
-			SignatureInfo: signatureInfo,
+			IsActive:             isActiveAtLastBlock,
+			Validator:            validator,
+			SignatureInfo:        signatureInfo,
+			WindowsSignatureInfo: windowsSignatureInfo,
 		}
 	}
 
 	entries.SetVotingPowerPercent()
+	entries.SetNeedsToSign(m.config.GetSoftOptOutThreshold())
 
 	return snapshotPkg.Snapshot{Entries: entries}, nil
 }
@@ -202,6 +427,11 @@ func (m *Manager) GetNotifiersForReporter(
 	return m.state.GetNotifiersForReporter(operatorAddress, reporter)
 }
 
+// HasNotifiers returns whether anyone is subscribed to a validator's notifications via any reporter.
+func (m *Manager) HasNotifiers(operatorAddress string) bool {
+	return m.state.HasNotifiers(operatorAddress)
+}
+
 func (m *Manager) GetValidatorsForNotifier(
 	reporter constants.ReporterName,
 	notifier string,
@@ -227,6 +457,10 @@ func (m *Manager) FindLastEventsByValidator(operatorAddress string) ([]types.His
 	)
 }
 
+func (m *Manager) FindLastMissedBlocks(consensusAddress string, count int64) ([]types.MissedBlock, error) {
+	return m.database.FindLastMissedBlocks(m.config.Name, consensusAddress, count)
+}
+
 func (m *Manager) FindAllJailsCount() ([]types.ValidatorWithJailsCount, error) {
 	return m.database.FindAllJailsCount(m.config.Name)
 }
@@ -273,3 +507,12 @@ func (m *Manager) SaveReport(height int64, report *types.Report) error {
 
 	return nil
 }
+
+// SaveEvidence stores the double sign evidence and returns whether it was not stored before.
+func (m *Manager) SaveEvidence(evidence *types.Evidence) (bool, error) {
+	return m.database.InsertEvidence(m.config.Name, evidence)
+}
+
+func (m *Manager) FindLastEvidence() ([]*types.Evidence, error) {
+	return m.database.FindLastEvidence(m.config.Name)
+}
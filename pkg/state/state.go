@@ -5,6 +5,8 @@ import (
 	"main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/types"
+	"main/pkg/utils"
+	"slices"
 	"sync"
 	"time"
 )
@@ -15,19 +17,30 @@ type LastBlockHeight struct {
 	report       int64
 }
 
+// BlocksLoader loads blocks that are not kept in memory.
+type BlocksLoader interface {
+	GetBlocksInRange(fromHeight, toHeight int64) (map[int64]*types.Block, error)
+}
+
 type State struct {
 	blocks          *Blocks
+	blocksLoader    BlocksLoader
+	window          *SignaturesWindow
+	extraWindows    map[string]*SignaturesWindow
 	validators      types.ValidatorsMap
 	notifiers       *types.Notifiers
 	lastBlockHeight *LastBlockHeight
 	mutex           sync.RWMutex
+	windowMutex     sync.Mutex
 }
 
 func NewState() *State {
 	return &State{
-		blocks:     NewBlocks(),
-		validators: make(types.ValidatorsMap),
-		notifiers:  &types.Notifiers{},
+		blocks:       NewBlocks(),
+		window:       NewSignaturesWindow(0, 0),
+		extraWindows: map[string]*SignaturesWindow{},
+		validators:   make(types.ValidatorsMap),
+		notifiers:    &types.Notifiers{},
 		lastBlockHeight: &LastBlockHeight{
 			signingInfos: 0,
 			validators:   0,
@@ -36,8 +49,201 @@ func NewState() *State {
 	}
 }
 
+// SetBlocksLoader sets where to load blocks from when they are not in memory.
+// Without it, all blocks are kept in memory.
+func (s *State) SetBlocksLoader(loader BlocksLoader) {
+	s.blocksLoader = loader
+}
+
 func (s *State) AddBlock(block *types.Block) {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	exists := s.blocks.HasBlockAtHeight(block.Height)
+
+	// blocks older than the latest one, like the ones fetched when populating blocks,
+	// are counted right away if they are in the window, the newer ones
+	// are counted when the window is moved
+	if s.window.Contains(block.Height) {
+		s.window = s.replaceInWindow(s.window, block, exists)
+	}
+
+	for name, window := range s.extraWindows {
+		if window.Contains(block.Height) {
+			s.extraWindows[name] = s.replaceInWindow(window, block, exists)
+		}
+	}
+
 	s.blocks.AddBlock(block)
+
+	if s.blocksLoader != nil {
+		s.blocks.EvictBefore(s.GetLastBlockHeight() - constants.StateRecentBlocksCount)
+	}
+}
+
+// replaceInWindow counts the block in the window, discounting the previously stored
+// block at the same height if there's one, so re-adding a block does not count it twice.
+// Returns the window to use from now on.
+func (s *State) replaceInWindow(window *SignaturesWindow, block *types.Block, exists bool) *SignaturesWindow {
+	if exists {
+		previous, err := s.getBlocks([]int64{block.Height})
+		if err != nil {
+			// cannot discount the previous block, so the window is counted from scratch on next update
+			return NewSignaturesWindow(window.End, window.End)
+		}
+
+		window.RemoveBlock(previous[block.Height])
+	}
+
+	window.AddBlock(block)
+	return window
+}
+
+func (s *State) SetBlocksTimes(times map[int64]time.Time) {
+	s.blocks.SetTimes(times)
+}
+
+func (s *State) CacheBlocks(blocks map[int64]*types.Block) {
+	for _, block := range blocks {
+		s.blocks.AddBlock(block)
+	}
+}
+
+func (s *State) GetWindow() SignaturesWindow {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	window := *s.window
+	window.Validators = make(map[string]*ValidatorCounters, len(s.window.Validators))
+
+	for address, counters := range s.window.Validators {
+		countersCopy := *counters
+		window.Validators[address] = &countersCopy
+	}
+
+	return window
+}
+
+// SetWindow sets the window, which should have all the stored blocks
+// in its range counted.
+func (s *State) SetWindow(window *SignaturesWindow) {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	s.window = window
+}
+
+// IsWindowValid checks whether the window has as many blocks counted
+// as there are stored blocks in its range.
+func (s *State) IsWindowValid(window *SignaturesWindow) bool {
+	return int64(len(s.blocks.GetHeightsInRange(window.Start+1, window.End))) == window.BlocksCount
+}
+
+func (s *State) UpdateWindow(blocksToCheck int64) error {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	return s.updateWindow(blocksToCheck)
+}
+
+func (s *State) updateWindow(blocksToCheck int64) error {
+	window, err := s.moveWindow(s.window, blocksToCheck)
+	s.window = window
+	return err
+}
+
+// moveWindow moves the window so it covers the last blocksToCheck heights,
+// only counting the blocks entering it and discounting the ones leaving it.
+// Returns the window to use from now on, which is reset if it could not be moved.
+func (s *State) moveWindow(window *SignaturesWindow, blocksToCheck int64) (*SignaturesWindow, error) {
+	end := s.GetLastBlockHeight()
+	start := end - max(blocksToCheck, 0)
+
+	if window.Start == start && window.End == end {
+		return window, nil
+	}
+
+	// if the new window does not overlap the old one, it's cheaper to count it from scratch
+	if start >= window.End || end <= window.Start {
+		window = NewSignaturesWindow(start, start)
+	}
+
+	toRemove := append(
+		s.blocks.GetHeightsInRange(window.Start+1, min(start, window.End)),
+		s.blocks.GetHeightsInRange(max(end, window.Start)+1, window.End)...,
+	)
+	toAdd := append(
+		s.blocks.GetHeightsInRange(start+1, min(window.Start, end)),
+		s.blocks.GetHeightsInRange(max(window.End, start)+1, end)...,
+	)
+
+	if err := s.applyToWindow(toRemove, window.RemoveBlock); err != nil {
+		return NewSignaturesWindow(end, end), err
+	}
+
+	if err := s.applyToWindow(toAdd, window.AddBlock); err != nil {
+		return NewSignaturesWindow(end, end), err
+	}
+
+	window.Start = start
+	window.End = end
+	return window, nil
+}
+
+func (s *State) applyToWindow(heights []int64, apply func(block *types.Block)) error {
+	slices.Sort(heights)
+
+	for _, chunk := range utils.SplitIntoChunks(heights, constants.StateBlocksLoadBatchSize) {
+		blocks, err := s.getBlocks(chunk)
+		if err != nil {
+			return err
+		}
+
+		for _, height := range chunk {
+			apply(blocks[height])
+		}
+	}
+
+	return nil
+}
+
+// getBlocks returns blocks at sorted heights, taking them from memory
+// if they are there and loading the rest.
+func (s *State) getBlocks(heights []int64) (map[int64]*types.Block, error) {
+	blocks := make(map[int64]*types.Block, len(heights))
+	missing := make([]int64, 0)
+
+	for _, height := range heights {
+		if block, ok := s.blocks.GetBlock(height); ok {
+			blocks[height] = block
+		} else {
+			missing = append(missing, height)
+		}
+	}
+
+	if len(missing) == 0 {
+		return blocks, nil
+	}
+
+	if s.blocksLoader == nil {
+		return nil, fmt.Errorf("block at height %d is not in memory", missing[0])
+	}
+
+	loaded, err := s.blocksLoader.GetBlocksInRange(missing[0], missing[len(missing)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, height := range missing {
+		block, ok := loaded[height]
+		if !ok {
+			return nil, fmt.Errorf("block at height %d is not found", height)
+		}
+
+		blocks[height] = block
+	}
+
+	return blocks, nil
 }
 
 func (s *State) GetBlocksCountSinceLatest(expected int64) int64 {
@@ -52,8 +258,64 @@ func (s *State) HasBlockAtHeight(height int64) bool {
 	return s.blocks.HasBlockAtHeight(height)
 }
 
-func (s *State) TrimBlocksBefore(trimHeight int64) {
+func (s *State) TrimBlocksBefore(trimHeight int64) error {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	var trimErr error
+
+	s.window, trimErr = s.trimWindow(s.window, trimHeight)
+
+	for name, window := range s.extraWindows {
+		var err error
+		if s.extraWindows[name], err = s.trimWindow(window, trimHeight); err != nil && trimErr == nil {
+			trimErr = err
+		}
+	}
+
 	s.blocks.TrimBefore(trimHeight)
+	return trimErr
+}
+
+// trimWindow discounts the blocks before trimHeight from the window, as they are about
+// to be removed. Returns the window to use from now on, which is reset if they could not be discounted.
+func (s *State) trimWindow(window *SignaturesWindow, trimHeight int64) (*SignaturesWindow, error) {
+	return s.discountFromWindow(window, s.blocks.GetHeightsInRange(window.Start+1, min(trimHeight, window.End)))
+}
+
+// discountFromWindow discounts the stored blocks at the heights within the window, as they are about
+// to be removed. Returns the window to use from now on, which is reset if they could not be discounted.
+func (s *State) discountFromWindow(window *SignaturesWindow, heights []int64) (*SignaturesWindow, error) {
+	toRemove := utils.Filter(heights, func(height int64) bool {
+		return window.Contains(height) && s.blocks.HasBlockAtHeight(height)
+	})
+
+	if err := s.applyToWindow(toRemove, window.RemoveBlock); err != nil {
+		return NewSignaturesWindow(window.End, window.End), err
+	}
+
+	return window, nil
+}
+
+// RemoveBlocks removes the blocks at the heights, discounting them from the windows,
+// so they are fetched again.
+func (s *State) RemoveBlocks(heights []int64) error {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	var removeErr error
+
+	s.window, removeErr = s.discountFromWindow(s.window, heights)
+
+	for name, window := range s.extraWindows {
+		var err error
+		if s.extraWindows[name], err = s.discountFromWindow(window, heights); err != nil && removeErr == nil {
+			removeErr = err
+		}
+	}
+
+	s.blocks.RemoveBlocks(heights)
+	return removeErr
 }
 
 func (s *State) SetValidators(validators types.ValidatorsMap) {
@@ -130,6 +392,10 @@ func (s *State) GetNotifiersForReporter(
 	return s.notifiers.GetNotifiersForReporter(operatorAddress, reporter)
 }
 
+func (s *State) HasNotifiers(operatorAddress string) bool {
+	return s.notifiers.HasNotifiers(operatorAddress)
+}
+
 func (s *State) GetValidatorsForNotifier(
 	reporter constants.ReporterName,
 	notifier string,
@@ -142,7 +408,7 @@ func (s *State) GetLastBlockHeight() int64 {
 }
 
 func (s *State) GetLastBlock() *types.Block {
-	return s.blocks.blocks[s.blocks.lastHeight]
+	return s.blocks.GetLatestBlock()
 }
 
 func (s *State) GetValidators() types.ValidatorsMap {
@@ -161,41 +427,15 @@ func (s *State) GetValidatorMissedBlocks(
 	validator *types.Validator,
 	blocksToCheck int64,
 ) (types.SignatureInto, error) {
-	signatureInfo := types.SignatureInto{}
-
-	errors := 0
-
-	for height := s.blocks.lastHeight; height > s.blocks.lastHeight-blocksToCheck; height-- {
-		block, exists := s.blocks.GetBlock(height)
-		if !exists {
-			errors += 1
-			continue
-		}
-
-		signatureInfo.BlocksCount++
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
 
-		if _, ok := block.Validators[validator.ConsensusAddressHex]; !ok {
-			signatureInfo.NotActive++
-			continue
-		} else {
-			signatureInfo.Active++
-		}
-
-		if block.Proposer == validator.ConsensusAddressHex {
-			signatureInfo.Proposed++
-		}
-
-		value, ok := block.Signatures[validator.ConsensusAddressHex]
-
-		if !ok {
-			signatureInfo.NoSignature++
-		} else if value != constants.ValidatorSigned && value != constants.ValidatorNilSignature {
-			signatureInfo.NotSigned++
-		} else {
-			signatureInfo.Signed++
-		}
+	if err := s.updateWindow(blocksToCheck); err != nil {
+		return types.SignatureInto{}, err
 	}
 
+	signatureInfo := s.window.GetSignatureInfo(validator.ConsensusAddressHex)
+
 	// if a validator was not active during the whole period,
 	// we do not know for sure the missed blocks counter for this validator
 	// and therefore are taking it from signing-info
@@ -205,11 +445,42 @@ func (s *State) GetValidatorMissedBlocks(
 		signatureInfo.Signed = blocksToCheck - validator.SigningInfo.MissedBlocksCounter
 	}
 
-	if errors > 0 {
-		return signatureInfo, fmt.Errorf("could not get info on %d blocks", errors)
+	return signatureInfo, checkWindowBlocks(signatureInfo, blocksToCheck)
+}
+
+// GetValidatorMissedBlocksInWindow is the same as GetValidatorMissedBlocks, but for an additional
+// signing window. Its signatures window is created on first use. Signing info is not used here,
+// as its missed blocks counter is for the slashing window.
+func (s *State) GetValidatorMissedBlocksInWindow(
+	windowName string,
+	validator *types.Validator,
+	blocksToCheck int64,
+) (types.SignatureInto, error) {
+	s.windowMutex.Lock()
+	defer s.windowMutex.Unlock()
+
+	window, ok := s.extraWindows[windowName]
+	if !ok {
+		window = NewSignaturesWindow(0, 0)
+	}
+
+	window, err := s.moveWindow(window, blocksToCheck)
+	s.extraWindows[windowName] = window
+
+	if err != nil {
+		return types.SignatureInto{}, err
+	}
+
+	signatureInfo := window.GetSignatureInfo(validator.ConsensusAddressHex)
+	return signatureInfo, checkWindowBlocks(signatureInfo, blocksToCheck)
+}
+
+func checkWindowBlocks(signatureInfo types.SignatureInto, blocksToCheck int64) error {
+	if missing := max(blocksToCheck, 0) - signatureInfo.BlocksCount; missing > 0 {
+		return fmt.Errorf("could not get info on %d blocks", missing)
 	}
 
-	return signatureInfo, nil
+	return nil
 }
 
 func (s *State) GetEarliestBlock() *types.Block {
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"errors"
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/types"
@@ -352,3 +353,205 @@ func TestGetActiveValidators(t *testing.T) {
 	validator := validators2[0]
 	assert.Equal(t, "validator2", validator.OperatorAddress)
 }
+
+type stubBlocksLoader struct {
+	blocks map[int64]*types.Block
+	err    error
+	calls  int
+}
+
+func (l *stubBlocksLoader) GetBlocksInRange(fromHeight, toHeight int64) (map[int64]*types.Block, error) {
+	l.calls++
+
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	blocks := map[int64]*types.Block{}
+	for height, block := range l.blocks {
+		if height >= fromHeight && height <= toHeight {
+			blocks[height] = block
+		}
+	}
+
+	return blocks, nil
+}
+
+func TestValidatorsMissedBlocksSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{ConsensusAddressHex: "address"}
+	state := NewState()
+
+	state.AddBlock(&types.Block{Height: 1, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 2, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 3, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+
+	signature, err := state.GetValidatorMissedBlocks(validator, 2)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(1), signature.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(1), signature.NoSignature, "Argument mismatch!")
+
+	state.AddBlock(&types.Block{Height: 4, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+
+	signature, err = state.GetValidatorMissedBlocks(validator, 2)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(2), signature.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(0), signature.NoSignature, "Argument mismatch!")
+
+}
+
+func TestValidatorsMissedBlocksBackfill(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{ConsensusAddressHex: "address"}
+	state := NewState()
+
+	state.AddBlock(&types.Block{Height: 1, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 3, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+
+	_, err := state.GetValidatorMissedBlocks(validator, 3)
+	require.Error(t, err, "Error should be present!")
+
+	// a block that was missing is counted into the current window
+	state.AddBlock(&types.Block{Height: 2, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+	// adding the same block again should not count it twice
+	state.AddBlock(&types.Block{Height: 2, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+
+	signature, err := state.GetValidatorMissedBlocks(validator, 3)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(1), signature.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(2), signature.NoSignature, "Argument mismatch!")
+}
+
+func TestValidatorsMissedBlocksLoadFromLoader(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{ConsensusAddressHex: "address"}
+	loader := &stubBlocksLoader{blocks: map[int64]*types.Block{}}
+
+	state := NewState()
+	state.SetBlocksLoader(loader)
+
+	for height := int64(1); height <= constants.StateRecentBlocksCount+10; height++ {
+		block := &types.Block{Height: height, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}}
+		loader.blocks[height] = block
+		state.AddBlock(block)
+	}
+
+	_, found := state.blocks.GetBlock(1)
+	assert.False(t, found, "Old blocks should be evicted!")
+
+	signature, err := state.GetValidatorMissedBlocks(validator, constants.StateRecentBlocksCount+10)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(constants.StateRecentBlocksCount+10), signature.NoSignature, "Argument mismatch!")
+	assert.Equal(t, 1, loader.calls, "Blocks should be loaded once!")
+}
+
+func TestValidatorsMissedBlocksLoaderFail(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{ConsensusAddressHex: "address"}
+	loader := &stubBlocksLoader{err: errors.New("custom error")}
+
+	state := NewState()
+	state.SetBlocksLoader(loader)
+
+	for height := int64(1); height <= constants.StateRecentBlocksCount+10; height++ {
+		state.AddBlock(&types.Block{Height: height, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	}
+
+	_, err := state.GetValidatorMissedBlocks(validator, constants.StateRecentBlocksCount+10)
+	require.Error(t, err, "Error should be present!")
+	require.ErrorContains(t, err, "custom error")
+}
+
+func TestTrimBlocksBeforeUpdatesWindow(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{ConsensusAddressHex: "address"}
+	state := NewState()
+
+	state.AddBlock(&types.Block{Height: 1, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 2, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 3, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+
+	require.NoError(t, state.UpdateWindow(3))
+	require.NoError(t, state.TrimBlocksBefore(1))
+
+	window := state.GetWindow()
+	assert.Equal(t, int64(2), window.BlocksCount, "Argument mismatch!")
+	assert.True(t, state.IsWindowValid(&window), "Window should be valid!")
+
+	signature, err := state.GetValidatorMissedBlocks(validator, 3)
+	require.Error(t, err, "Error should be present!")
+	assert.Equal(t, int64(1), signature.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(1), signature.NoSignature, "Argument mismatch!")
+}
+
+func TestValidatorsMissedBlocksInWindow(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{
+		ConsensusAddressHex: "address",
+		SigningInfo:         &types.SigningInfo{MissedBlocksCounter: 100},
+	}
+	state := NewState()
+
+	state.AddBlock(&types.Block{Height: 1, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 2, Signatures: map[string]int32{}, Validators: map[string]bool{}})
+	state.AddBlock(&types.Block{Height: 3, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+
+	signature, err := state.GetValidatorMissedBlocks(validator, 3)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(100), signature.NotSigned, "Argument mismatch!")
+
+	// additional windows are counted separately and do not use signing info
+	signature, err = state.GetValidatorMissedBlocksInWindow("short", validator, 2)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(1), signature.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(1), signature.NotActive, "Argument mismatch!")
+	assert.Equal(t, int64(0), signature.GetNotSigned(), "Argument mismatch!")
+
+	state.AddBlock(&types.Block{Height: 4, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	// re-adding a block should replace it in all windows
+	state.AddBlock(&types.Block{Height: 3, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+
+	signature, err = state.GetValidatorMissedBlocksInWindow("short", validator, 2)
+	require.NoError(t, err, "Error should not be present!")
+	assert.Equal(t, int64(0), signature.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(2), signature.NoSignature, "Argument mismatch!")
+
+	require.NoError(t, state.TrimBlocksBefore(4))
+
+	_, err = state.GetValidatorMissedBlocksInWindow("short", validator, 2)
+	require.Error(t, err, "Error should be present!")
+}
+
+func TestRemoveBlocksUpdatesWindows(t *testing.T) {
+	t.Parallel()
+
+	validator := &types.Validator{ConsensusAddressHex: "address"}
+	state := NewState()
+
+	state.AddBlock(&types.Block{Height: 1, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 2, Signatures: map[string]int32{}, Validators: map[string]bool{"address": true}})
+	state.AddBlock(&types.Block{Height: 3, Signatures: map[string]int32{"address": 2}, Validators: map[string]bool{"address": true}})
+
+	require.NoError(t, state.UpdateWindow(3))
+	_, err := state.GetValidatorMissedBlocksInWindow("short", validator, 2)
+	require.NoError(t, err, "Error should not be present!")
+
+	// height 4 is not stored, so it should be ignored
+	require.NoError(t, state.RemoveBlocks([]int64{2, 4}))
+
+	assert.False(t, state.HasBlockAtHeight(2), "Block should be removed!")
+	assert.True(t, state.HasBlockAtHeight(1), "Block should be present!")
+
+	window := state.GetWindow()
+	assert.Equal(t, int64(2), window.BlocksCount, "Argument mismatch!")
+	assert.Equal(t, int64(1), window.Validators["address"].NoSignature, "Argument mismatch!")
+
+	_, err = state.GetValidatorMissedBlocksInWindow("short", validator, 2)
+	require.Error(t, err, "Error should be present!")
+}
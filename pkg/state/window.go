@@ -0,0 +1,97 @@
+package state
+
+import (
+	"main/pkg/constants"
+	"main/pkg/types"
+)
+
+type ValidatorCounters struct {
+	Active      int64 `json:"active"`
+	Proposed    int64 `json:"proposed"`
+	NoSignature int64 `json:"no_signature"`
+	NotSigned   int64 `json:"not_signed"`
+	Signed      int64 `json:"signed"`
+}
+
+// SignaturesWindow holds per-validator counters of signatures for blocks
+// with heights in the (Start, End] range, so getting a validator's missed blocks
+// does not require iterating over all blocks in the window.
+type SignaturesWindow struct {
+	Start       int64                         `json:"start"`
+	End         int64                         `json:"end"`
+	BlocksCount int64                         `json:"blocks_count"`
+	Validators  map[string]*ValidatorCounters `json:"validators"`
+}
+
+func NewSignaturesWindow(start, end int64) *SignaturesWindow {
+	return &SignaturesWindow{
+		Start:      start,
+		End:        end,
+		Validators: map[string]*ValidatorCounters{},
+	}
+}
+
+func (w *SignaturesWindow) Contains(height int64) bool {
+	return height > w.Start && height <= w.End
+}
+
+func (w *SignaturesWindow) AddBlock(block *types.Block) {
+	w.apply(block, 1)
+}
+
+func (w *SignaturesWindow) RemoveBlock(block *types.Block) {
+	w.apply(block, -1)
+}
+
+func (w *SignaturesWindow) apply(block *types.Block, delta int64) {
+	w.BlocksCount += delta
+
+	for address := range block.Validators {
+		counters, ok := w.Validators[address]
+		if !ok {
+			counters = &ValidatorCounters{}
+			w.Validators[address] = counters
+		}
+
+		counters.Active += delta
+
+		if block.Proposer == address {
+			counters.Proposed += delta
+		}
+
+		value, ok := block.Signatures[address]
+
+		if !ok {
+			counters.NoSignature += delta
+		} else if value != constants.ValidatorSigned && value != constants.ValidatorNilSignature {
+			counters.NotSigned += delta
+		} else {
+			counters.Signed += delta
+		}
+
+		if *counters == (ValidatorCounters{}) {
+			delete(w.Validators, address)
+		}
+	}
+}
+
+func (w *SignaturesWindow) GetSignatureInfo(address string) types.SignatureInto {
+	signatureInfo := types.SignatureInto{
+		BlocksCount: w.BlocksCount,
+		NotActive:   w.BlocksCount,
+	}
+
+	counters, ok := w.Validators[address]
+	if !ok {
+		return signatureInfo
+	}
+
+	signatureInfo.Active = counters.Active
+	signatureInfo.NotActive = w.BlocksCount - counters.Active
+	signatureInfo.Proposed = counters.Proposed
+	signatureInfo.NoSignature = counters.NoSignature
+	signatureInfo.NotSigned = counters.NotSigned
+	signatureInfo.Signed = counters.Signed
+
+	return signatureInfo
+}
@@ -0,0 +1,191 @@
+package state
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"main/pkg/types"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	benchmarkBlocksCount     = 10000
+	benchmarkValidatorsCount = 200
+)
+
+// getSignatureInfoByLoop is how signatures were counted before the window was introduced,
+// by walking over all blocks, used as a reference to compare against.
+func getSignatureInfoByLoop(blocks map[int64]*types.Block, lastHeight int64, address string, blocksToCheck int64) types.SignatureInto {
+	signatureInfo := types.SignatureInto{}
+
+	for height := lastHeight; height > lastHeight-blocksToCheck; height-- {
+		block, exists := blocks[height]
+		if !exists {
+			continue
+		}
+
+		signatureInfo.BlocksCount++
+
+		if _, ok := block.Validators[address]; !ok {
+			signatureInfo.NotActive++
+			continue
+		}
+
+		signatureInfo.Active++
+
+		if block.Proposer == address {
+			signatureInfo.Proposed++
+		}
+
+		value, ok := block.Signatures[address]
+
+		if !ok {
+			signatureInfo.NoSignature++
+		} else if value != constants.ValidatorSigned && value != constants.ValidatorNilSignature {
+			signatureInfo.NotSigned++
+		} else {
+			signatureInfo.Signed++
+		}
+	}
+
+	return signatureInfo
+}
+
+func generateValidators(count int) []*types.Validator {
+	validators := make([]*types.Validator, count)
+	for index := range validators {
+		validators[index] = &types.Validator{ConsensusAddressHex: fmt.Sprintf("validator%d", index)}
+	}
+
+	return validators
+}
+
+func generateBlock(random *rand.Rand, height int64, validators []*types.Validator) *types.Block {
+	block := &types.Block{
+		Height:     height,
+		Proposer:   validators[random.Intn(len(validators))].ConsensusAddressHex,
+		Signatures: make(map[string]int32, len(validators)),
+		Validators: make(map[string]bool, len(validators)),
+	}
+
+	for _, validator := range validators {
+		// some validators are out of the active set, some are absent,
+		// some are not signing and the rest are signing
+		switch value := random.Intn(20); {
+		case value == 0:
+			continue
+		case value == 1:
+			block.Validators[validator.ConsensusAddressHex] = true
+		case value == 2:
+			block.Validators[validator.ConsensusAddressHex] = true
+			block.Signatures[validator.ConsensusAddressHex] = 1
+		default:
+			block.Validators[validator.ConsensusAddressHex] = true
+			block.Signatures[validator.ConsensusAddressHex] = constants.ValidatorSigned
+		}
+	}
+
+	return block
+}
+
+func TestSignaturesWindowMatchesLoopOutOfOrder(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(1))
+	validators := generateValidators(10)
+
+	blocks := make(map[int64]*types.Block)
+	heights := make([]int64, 0)
+
+	for height := int64(1); height <= 500; height++ {
+		blocks[height] = generateBlock(random, height, validators)
+		heights = append(heights, height)
+	}
+
+	random.Shuffle(len(heights), func(i, j int) {
+		heights[i], heights[j] = heights[j], heights[i]
+	})
+
+	state := NewState()
+	added := make(map[int64]*types.Block)
+	var lastHeight int64
+
+	for index, height := range heights {
+		state.AddBlock(blocks[height])
+		added[height] = blocks[height]
+		lastHeight = max(lastHeight, height)
+
+		// re-adding a block with different signatures, as if it was refetched
+		if index%7 == 0 {
+			block := generateBlock(random, height, validators)
+			state.AddBlock(block)
+			added[height] = block
+		}
+
+		if index%10 != 0 {
+			continue
+		}
+
+		blocksToCheck := int64(random.Intn(100) + 1)
+		for _, validator := range validators {
+			expected := getSignatureInfoByLoop(added, lastHeight, validator.ConsensusAddressHex, blocksToCheck)
+			actual, _ := state.GetValidatorMissedBlocks(validator, blocksToCheck)
+			require.Equal(t, expected, actual, "Signature info mismatch at %d!", height)
+		}
+	}
+}
+
+func prepareBenchmarkState() (*State, map[int64]*types.Block, []*types.Validator, *rand.Rand) {
+	random := rand.New(rand.NewSource(1))
+	validators := generateValidators(benchmarkValidatorsCount)
+	blocks := make(map[int64]*types.Block, benchmarkBlocksCount)
+
+	state := NewState()
+
+	for height := int64(1); height <= benchmarkBlocksCount; height++ {
+		blocks[height] = generateBlock(random, height, validators)
+		state.AddBlock(blocks[height])
+	}
+
+	return state, blocks, validators, random
+}
+
+func BenchmarkGetValidatorMissedBlocksLoop(b *testing.B) {
+	_, blocks, validators, random := prepareBenchmarkState()
+	lastHeight := int64(benchmarkBlocksCount)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lastHeight++
+		blocks[lastHeight] = generateBlock(random, lastHeight, validators)
+
+		for _, validator := range validators {
+			getSignatureInfoByLoop(blocks, lastHeight, validator.ConsensusAddressHex, benchmarkBlocksCount)
+		}
+	}
+}
+
+func BenchmarkGetValidatorMissedBlocksWindow(b *testing.B) {
+	state, _, validators, random := prepareBenchmarkState()
+	lastHeight := int64(benchmarkBlocksCount)
+
+	if err := state.UpdateWindow(benchmarkBlocksCount); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lastHeight++
+		state.AddBlock(generateBlock(random, lastHeight, validators))
+
+		for _, validator := range validators {
+			if _, err := state.GetValidatorMissedBlocks(validator, benchmarkBlocksCount); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
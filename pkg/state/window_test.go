@@ -0,0 +1,60 @@
+package state
+
+import (
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignaturesWindowContains(t *testing.T) {
+	t.Parallel()
+
+	window := NewSignaturesWindow(5, 10)
+
+	assert.False(t, window.Contains(5), "Height should not be in window!")
+	assert.True(t, window.Contains(6), "Height should be in window!")
+	assert.True(t, window.Contains(10), "Height should be in window!")
+	assert.False(t, window.Contains(11), "Height should not be in window!")
+}
+
+func TestSignaturesWindowAddAndRemoveBlock(t *testing.T) {
+	t.Parallel()
+
+	window := NewSignaturesWindow(0, 3)
+	window.AddBlock(&types.Block{
+		Height:     1,
+		Proposer:   "first",
+		Signatures: map[string]int32{"first": 2, "second": 1},
+		Validators: map[string]bool{"first": true, "second": true},
+	})
+	window.AddBlock(&types.Block{
+		Height:     2,
+		Signatures: map[string]int32{"first": 3},
+		Validators: map[string]bool{"first": true},
+	})
+
+	first := window.GetSignatureInfo("first")
+	assert.Equal(t, int64(2), first.BlocksCount, "Argument mismatch!")
+	assert.Equal(t, int64(2), first.Active, "Argument mismatch!")
+	assert.Equal(t, int64(2), first.Signed, "Argument mismatch!")
+	assert.Equal(t, int64(1), first.Proposed, "Argument mismatch!")
+
+	second := window.GetSignatureInfo("second")
+	assert.Equal(t, int64(1), second.NotSigned, "Argument mismatch!")
+	assert.Equal(t, int64(1), second.NotActive, "Argument mismatch!")
+
+	window.RemoveBlock(&types.Block{
+		Height:     1,
+		Proposer:   "first",
+		Signatures: map[string]int32{"first": 2, "second": 1},
+		Validators: map[string]bool{"first": true, "second": true},
+	})
+
+	assert.Len(t, window.Validators, 1, "Empty counters should be removed!")
+
+	second = window.GetSignatureInfo("second")
+	assert.Equal(t, int64(1), second.BlocksCount, "Argument mismatch!")
+	assert.Equal(t, int64(1), second.NotActive, "Argument mismatch!")
+	assert.Equal(t, int64(0), second.NotSigned, "Argument mismatch!")
+}
@@ -0,0 +1,40 @@
+package tendermint
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/http"
+	"main/pkg/metrics"
+	"main/pkg/types"
+
+	"github.com/rs/zerolog"
+)
+
+// BlockSource is where blocks come from: Listen and GetChannel provide new blocks
+// as they are produced until the context is done, GetBlock and GetBlocks are used
+// to backfill older ones.
+type BlockSource interface {
+	Listen(ctx context.Context)
+	GetChannel() chan types.WebsocketEmittable
+	GetBlock(height int64) (*types.Block, error)
+	GetBlocks(heights []int64) (map[int64]*types.Block, []error)
+}
+
+func GetBlockSource(
+	logger zerolog.Logger,
+	config *configPkg.ChainConfig,
+	metricsManager *metrics.Manager,
+	pools *http.Pools,
+) BlockSource {
+	rpc := NewRPC(config, logger, pools)
+
+	switch config.BlockSource {
+	case constants.BlockSourcePoll:
+		return NewPollBlockSource(logger, config, rpc)
+	case constants.BlockSourceRange:
+		return NewRangeBlockSource(logger, rpc, NewWebsocketManager(logger, config, metricsManager, pools))
+	default:
+		return NewWebsocketBlockSource(rpc, NewWebsocketManager(logger, config, metricsManager, pools))
+	}
+}
@@ -0,0 +1,299 @@
+package tendermint
+
+import (
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/http"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func getTestBlockSource(blockSource string) BlockSource {
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+		BlockSource:  blockSource,
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	return GetBlockSource(*logger, config, metricsManager, http.NewPools(*logger, metricsManager, config))
+}
+
+func TestGetBlockSource(t *testing.T) {
+	t.Parallel()
+
+	require.IsType(t, &WebsocketBlockSource{}, getTestBlockSource(constants.BlockSourceWebsocket))
+	require.IsType(t, &RangeBlockSource{}, getTestBlockSource(constants.BlockSourceRange))
+	require.IsType(t, &PollBlockSource{}, getTestBlockSource(constants.BlockSourcePoll))
+	require.NotNil(t, getTestBlockSource(constants.BlockSourceRange).GetChannel())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestWebsocketBlockSourceGetBlock(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceWebsocket)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block?height=123",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-block.json")),
+	)
+
+	block, err := blockSource.GetBlock(123)
+
+	require.NoError(t, err)
+	require.NotNil(t, block)
+	require.Equal(t, int64(21955288), block.Height)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestWebsocketBlockSourceGetBlocksFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceWebsocket)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block?height=123",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{123})
+
+	require.Empty(t, blocks)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "custom error")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestWebsocketBlockSourceGetBlocksOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceWebsocket)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block?height=123",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-block.json")),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{123})
+
+	require.Empty(t, errs)
+	require.Len(t, blocks, 1)
+	require.Len(t, blocks[123].Signatures, 180)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRangeBlockSourceGetBlocksBlockchainFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceRange)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/blockchain?minHeight=122&maxHeight=123",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-commits.json")),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{123, 122})
+
+	require.Empty(t, blocks)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "custom error")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRangeBlockSourceGetBlocksCommitsError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceRange)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/blockchain?minHeight=122&maxHeight=123",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-blockchain.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-commits-error.json")),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{123, 122})
+
+	require.Empty(t, blocks)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "height 121 is not available")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRangeBlockSourceGetBlocksCommitsMissing(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceRange)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/blockchain?minHeight=122&maxHeight=124",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-blockchain.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-commits.json")),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{122, 123, 124})
+
+	require.Empty(t, blocks)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "no commit at height 123")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRangeBlockSourceGetBlocksOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceRange)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/blockchain?minHeight=122&maxHeight=123",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-blockchain.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-commits.json")),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{123, 122})
+
+	require.Empty(t, errs)
+	require.Len(t, blocks, 2)
+	require.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	require.Equal(t, int64(122), blocks[122].Height)
+	require.Len(t, blocks[122].Signatures, 3)
+
+	require.Equal(t, int64(123), blocks[123].Height)
+	require.Len(t, blocks[123].Signatures, 3)
+	require.Equal(t, int32(1), blocks[123].Signatures[""])
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRangeBlockSourceGetBlocksEvidence(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceRange)
+
+	// only the block at height 123 has evidence
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/blockchain?minHeight=122&maxHeight=123",
+		httpmock.NewStringResponder(200, strings.Replace(
+			string(assets.GetBytesOrPanic("rpc-blockchain.json")),
+			`"evidence_hash": "`+constants.EmptyEvidenceHash+`"`,
+			`"evidence_hash": "ABCDEF"`,
+			1,
+		)),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-commits.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block?height=123",
+		httpmock.NewStringResponder(200, strings.ReplaceAll(
+			string(assets.GetBytesOrPanic("rpc-block.json")),
+			`"evidence": []`,
+			`"evidence": [{
+				"type": "tendermint/DuplicateVoteEvidence",
+				"value": {
+					"vote_a": {"height": "120", "timestamp": "2024-08-29T23:44:38Z", "validator_address": "validator"},
+					"vote_b": {"height": "120", "timestamp": "2024-08-29T23:44:38Z", "validator_address": "validator"}
+				}
+			}]`,
+		)),
+	)
+
+	blocks, errs := blockSource.GetBlocks([]int64{123, 122})
+
+	require.Empty(t, errs)
+	require.Len(t, blocks, 2)
+	require.Equal(t, 3, httpmock.GetTotalCallCount())
+	require.Empty(t, blocks[122].Evidence)
+	require.Len(t, blocks[123].Evidence, 1)
+	require.Equal(t, int64(120), blocks[123].Evidence[0].Height)
+	require.Equal(t, "validator", blocks[123].Evidence[0].ValidatorAddress)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRangeBlockSourceGetBlocksEvidenceFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestBlockSource(constants.BlockSourceRange)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/blockchain?minHeight=122&maxHeight=123",
+		httpmock.NewStringResponder(200, strings.Replace(
+			string(assets.GetBytesOrPanic("rpc-blockchain.json")),
+			`"evidence_hash": "`+constants.EmptyEvidenceHash+`"`,
+			`"evidence_hash": "ABCDEF"`,
+			1,
+		)),
+	)
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-commits.json")),
+	)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block?height=123",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	// the range is not stored without evidence, so it's fetched again later
+	blocks, errs := blockSource.GetBlocks([]int64{123, 122})
+
+	require.Empty(t, blocks)
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "custom error")
+}
@@ -6,34 +6,25 @@ import (
 	configPkg "main/pkg/config"
 	"main/pkg/constants"
 	"main/pkg/http"
-	"main/pkg/metrics"
 	"main/pkg/types/responses"
-	"main/pkg/utils"
 	"strconv"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 )
 
 type RPC struct {
-	config         *configPkg.ChainConfig
-	metricsManager *metrics.Manager
-	logger         zerolog.Logger
-
-	clients []*http.Client
+	config *configPkg.ChainConfig
+	logger zerolog.Logger
+	pool   *http.Pool
 }
 
-func NewRPC(config *configPkg.ChainConfig, logger zerolog.Logger, metricsManager *metrics.Manager) *RPC {
-	clients := make([]*http.Client, len(config.RPCEndpoints))
-	for index, host := range config.RPCEndpoints {
-		clients[index] = http.NewClient(logger, metricsManager, host, config.Name)
-	}
-
+func NewRPC(config *configPkg.ChainConfig, logger zerolog.Logger, pools *http.Pools) *RPC {
 	return &RPC{
-		config:         config,
-		metricsManager: metricsManager,
-		logger:         logger.With().Str("component", "rpc").Logger(),
-		clients:        clients,
+		config: config,
+		logger: logger.With().Str("component", "rpc").Logger(),
+		pool:   pools.RPC,
 	}
 }
 
@@ -44,7 +35,7 @@ func (rpc *RPC) GetBlock(height int64) (*responses.SingleBlockResponse, error) {
 	}
 
 	var blockResponse responses.SingleBlockResponse
-	if err := rpc.Get(queryURL, constants.QueryTypeBlock, &blockResponse, rpc.clients, func(v interface{}) error {
+	if err := rpc.Get(queryURL, constants.QueryTypeBlock, &blockResponse, func(v interface{}, node *http.Node) error {
 		response, _ := v.(*responses.SingleBlockResponse)
 		if response.Error != nil {
 			return fmt.Errorf("error in Tendermint response: %s", response.Error.Data)
@@ -54,6 +45,12 @@ func (rpc *RPC) GetBlock(height int64) (*responses.SingleBlockResponse, error) {
 			return errors.New("malformed result of block: empty block height")
 		}
 
+		if height == 0 {
+			if latestHeight, err := strconv.ParseInt(response.Result.Block.Header.Height, 10, 64); err == nil {
+				rpc.pool.SetLatestHeight(node, latestHeight)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -62,6 +59,78 @@ func (rpc *RPC) GetBlock(height int64) (*responses.SingleBlockResponse, error) {
 	return &blockResponse, nil
 }
 
+// GetBlockchain returns block headers in the [minHeight, maxHeight] range,
+// the node returns at most MaxBlockchainRange headers per query.
+func (rpc *RPC) GetBlockchain(minHeight, maxHeight int64) (*responses.BlockchainResponse, error) {
+	queryURL := fmt.Sprintf("/blockchain?minHeight=%d&maxHeight=%d", minHeight, maxHeight)
+
+	var blockchainResponse responses.BlockchainResponse
+	if err := rpc.Get(queryURL, constants.QueryTypeBlockchain, &blockchainResponse, func(v interface{}, node *http.Node) error {
+		response, _ := v.(*responses.BlockchainResponse)
+		if response.Error != nil {
+			return fmt.Errorf("error in Tendermint response: %s", response.Error.Data)
+		}
+
+		if response.Result == nil {
+			return errors.New("malformed result of blockchain: empty result")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &blockchainResponse, nil
+}
+
+// GetCommits fetches commits at multiple heights with a single JSON-RPC batch request.
+func (rpc *RPC) GetCommits(heights []int64) (map[int64]*responses.Commit, error) {
+	requests := make([]responses.CommitRequest, len(heights))
+	for index, height := range heights {
+		requests[index] = responses.CommitRequest{
+			JSONRPC: "2.0",
+			ID:      height,
+			Method:  "commit",
+			Params:  map[string]string{"height": strconv.FormatInt(height, 10)},
+		}
+	}
+
+	var commitsResponse []responses.CommitResponse
+	if err := rpc.Post("/", constants.QueryTypeCommits, requests, &commitsResponse, func(v interface{}, node *http.Node) error {
+		response, _ := v.(*[]responses.CommitResponse)
+		received := make(map[int64]bool, len(*response))
+
+		for _, commit := range *response {
+			if commit.Error != nil {
+				return fmt.Errorf("error in Tendermint response: %s", commit.Error.Data)
+			}
+
+			if commit.Result == nil {
+				return fmt.Errorf("malformed result of commit at height %d: empty result", commit.ID)
+			}
+
+			received[commit.ID] = true
+		}
+
+		for _, height := range heights {
+			if !received[height] {
+				return fmt.Errorf("malformed result of commits: no commit at height %d", height)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	commits := make(map[int64]*responses.Commit, len(commitsResponse))
+	for _, commit := range commitsResponse {
+		commits[commit.ID] = &commit.Result.SignedHeader.Commit
+	}
+
+	return commits, nil
+}
+
 func (rpc *RPC) GetActiveSetAtBlock(height int64) (map[string]bool, error) {
 	page := 1
 
@@ -75,7 +144,7 @@ func (rpc *RPC) GetActiveSetAtBlock(height int64) (map[string]bool, error) {
 		)
 
 		var validatorsResponse responses.ValidatorsResponse
-		if err := rpc.Get(queryURL, constants.QueryTypeHistoricalValidators, &validatorsResponse, rpc.clients, func(v interface{}) error {
+		if err := rpc.Get(queryURL, constants.QueryTypeHistoricalValidators, &validatorsResponse, func(v interface{}, node *http.Node) error {
 			response, _ := v.(*responses.ValidatorsResponse)
 
 			if response.Error != nil {
@@ -113,57 +182,116 @@ func (rpc *RPC) GetActiveSetAtBlock(height int64) (map[string]bool, error) {
 	return activeSetMap, nil
 }
 
-func (rpc *RPC) Get(
-	url string,
-	queryType constants.QueryType,
-	target interface{},
-	clients []*http.Client,
-	predicate func(interface{}) error,
-) error {
-	errorsArray := make([]error, len(clients))
+func (rpc *RPC) GetNodeHeight(node *http.Node) (int64, error) {
+	var response responses.StatusResponse
+	if err := node.Client.Get("/status", constants.QueryTypeStatus, &response); err != nil {
+		return 0, err
+	}
 
-	indexesShuffled := utils.MakeShuffledArray(len(clients))
-	clientsShuffled := make([]*http.Client, len(clients))
+	if response.Error != nil {
+		return 0, fmt.Errorf("error in Tendermint response: %s", response.Error.Data)
+	}
 
-	for index, indexShuffled := range indexesShuffled {
-		clientsShuffled[index] = clients[indexShuffled]
+	if response.Result == nil {
+		return 0, errors.New("malformed result of status: empty result")
 	}
 
-	for index := range indexesShuffled {
-		client := clientsShuffled[index]
+	return strconv.ParseInt(response.Result.SyncInfo.LatestBlockHeight, 10, 64)
+}
 
-		fullURL := client.Host + url
-		rpc.logger.Trace().Str("url", fullURL).Msg("Trying making request to LCD")
+// CheckNodesLag queries the latest height of each RPC node and compares it with the highest
+// height across all nodes, so nodes lagging more than max-node-lag blocks are excluded from queries.
+func (rpc *RPC) CheckNodesLag() error {
+	nodes := rpc.pool.GetNodes()
+	heights := make([]int64, len(nodes))
+	errs := make([]error, len(nodes))
 
-		err := client.Get(
-			url,
-			queryType,
-			target,
-		)
+	var wg sync.WaitGroup
 
-		if err != nil {
-			rpc.logger.Warn().Str("url", fullURL).Err(err).Msg("LCD request failed")
-			errorsArray[index] = err
+	for index, node := range nodes {
+		wg.Add(1)
+		go func(index int, node *http.Node) {
+			defer wg.Done()
+
+			start := time.Now()
+			heights[index], errs[index] = rpc.GetNodeHeight(node)
+			rpc.pool.RecordResult(node, time.Since(start), errs[index])
+		}(index, node)
+	}
+
+	wg.Wait()
+
+	var maxHeight int64
+	for index, node := range nodes {
+		if errs[index] != nil {
+			rpc.logger.Warn().
+				Str("host", node.Host).
+				Err(errs[index]).
+				Msg("Error getting node status")
+
+			// the node's lag is unknown now, so it's not judged by the previous one
+			rpc.pool.ResetLag(node)
 			continue
 		}
 
-		if predicateErr := predicate(target); predicateErr != nil {
-			rpc.logger.Warn().Str("url", fullURL).Err(predicateErr).Msg("LCD precondition failed")
-			errorsArray[index] = fmt.Errorf("precondition failed: %s", predicateErr)
+		maxHeight = max(maxHeight, heights[index])
+	}
+
+	if maxHeight == 0 {
+		return errors.New("could not get latest height from any of the nodes")
+	}
+
+	for index, node := range nodes {
+		if errs[index] != nil {
 			continue
 		}
 
-		return nil
+		lag := maxHeight - heights[index]
+		rpc.pool.SetLag(node, heights[index], lag, lag > rpc.config.MaxNodeLag)
 	}
 
-	rpc.logger.Warn().Str("url", url).Msg("All LCD requests failed")
+	return nil
+}
 
-	var sb strings.Builder
+func (rpc *RPC) IsNodeLagging(host string) bool {
+	return rpc.pool.IsLagging(host)
+}
 
-	sb.WriteString("All LCD requests failed:\n")
-	for index, client := range clientsShuffled {
-		sb.WriteString(fmt.Sprintf("#%d: %s -> %s\n", index+1, client.Host, errorsArray[index]))
-	}
+func (rpc *RPC) Get(
+	url string,
+	queryType constants.QueryType,
+	target interface{},
+	predicate func(interface{}, *http.Node) error,
+) error {
+	return rpc.pool.Query(url, func(node *http.Node) error {
+		if err := node.Client.Get(url, queryType, target); err != nil {
+			return err
+		}
+
+		if predicateErr := predicate(target, node); predicateErr != nil {
+			return fmt.Errorf("precondition failed: %s", predicateErr)
+		}
 
-	return errors.New(sb.String())
+		return nil
+	})
+}
+
+func (rpc *RPC) Post(
+	url string,
+	queryType constants.QueryType,
+	payload interface{},
+	target interface{},
+	predicate func(interface{}, *http.Node) error,
+) error {
+	return rpc.pool.Query(url, func(node *http.Node) error {
+		if err := node.Client.Post(url, queryType, payload, target); err != nil {
+			return err
+		}
+
+		if predicateErr := predicate(target, node); predicateErr != nil {
+			return fmt.Errorf("precondition failed: %s", predicateErr)
+		}
+
+		return nil
+	})
 }
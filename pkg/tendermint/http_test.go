@@ -4,6 +4,7 @@ import (
 	"errors"
 	"main/assets"
 	configPkg "main/pkg/config"
+	"main/pkg/http"
 	loggerPkg "main/pkg/logger"
 	"main/pkg/metrics"
 	"testing"
@@ -25,7 +26,7 @@ func TestLcdGetBlockFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -52,7 +53,7 @@ func TestLcdGetBlockError(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -79,7 +80,7 @@ func TestLcdGetBlockInvalid(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -106,7 +107,7 @@ func TestLcdGetBlockOkLatest(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -132,7 +133,7 @@ func TestLcdGetBlockOkNotLatest(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -158,7 +159,7 @@ func TestLcdGetValidatorsFail(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -185,7 +186,7 @@ func TestLcdGetValidatorsTendermintError(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -212,7 +213,7 @@ func TestLcdGetValidatorsNoValidators(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -239,7 +240,7 @@ func TestLcdGetValidatorsInvalidPagination(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -266,7 +267,7 @@ func TestLcdGetValidatorsOk(t *testing.T) {
 	logger := loggerPkg.GetNopLogger()
 
 	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
-	lcdFetcher := NewRPC(config, *logger, metricsManager)
+	lcdFetcher := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
 
 	httpmock.RegisterResponder(
 		"GET",
@@ -286,3 +287,123 @@ func TestLcdGetValidatorsOk(t *testing.T) {
 	require.NotNil(t, response)
 	require.Len(t, response, 180)
 }
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRpcCheckNodesLagAllFailed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	rpc := NewRPC(config, *logger, http.NewPools(*logger, metricsManager, config))
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-tendermint-error.json")),
+	)
+
+	err := rpc.CheckNodesLag()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "could not get latest height")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRpcCheckNodesLagOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com", "https://example2.com", "https://example3.com"},
+		MaxNodeLag:   10,
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	pools := http.NewPools(*logger, metricsManager, config)
+	rpc := NewRPC(config, *logger, pools)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-status.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example2.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-status-lagging.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example3.com/status",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	err := rpc.CheckNodesLag()
+	require.NoError(t, err)
+
+	stats := pools.RPC.GetStats()
+	require.Len(t, stats, 3)
+	require.Equal(t, int64(1000), stats[0].LatestHeight)
+	require.Zero(t, stats[0].Lag)
+	require.False(t, stats[0].IsLagging)
+	require.Equal(t, int64(980), stats[1].LatestHeight)
+	require.Equal(t, int64(20), stats[1].Lag)
+	require.True(t, stats[1].IsLagging)
+	require.False(t, stats[2].IsLagging)
+
+	require.False(t, rpc.IsNodeLagging("https://example.com"))
+	require.True(t, rpc.IsNodeLagging("https://example2.com"))
+
+	for _, node := range pools.RPC.GetOrdered() {
+		require.NotEqual(t, "https://example2.com", node.Host)
+	}
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestRpcCheckNodesLagResetOnError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com", "https://example2.com"},
+		MaxNodeLag:   10,
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	pools := http.NewPools(*logger, metricsManager, config)
+	rpc := NewRPC(config, *logger, pools)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-status.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example2.com/status",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rpc-status-lagging.json")),
+	)
+
+	require.NoError(t, rpc.CheckNodesLag())
+	require.True(t, rpc.IsNodeLagging("https://example2.com"))
+
+	// the lag is unknown once the node's status cannot be queried
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example2.com/status",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	require.NoError(t, rpc.CheckNodesLag())
+	require.False(t, rpc.IsNodeLagging("https://example2.com"))
+	require.Zero(t, pools.RPC.GetStats()[1].Lag)
+}
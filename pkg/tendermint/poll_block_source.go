@@ -0,0 +1,132 @@
+package tendermint
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// PollBlockSource is used when websockets are not available: it polls the latest block
+// at a fixed interval and sends new blocks to the same channel websockets would.
+// If more than one block was produced since the previous poll, the blocks in between
+// are fetched as well, up to blocks-search blocks, the older ones are left
+// to the blocks populator.
+type PollBlockSource struct {
+	*SingleBlocksFetcher
+
+	logger     zerolog.Logger
+	config     *configPkg.ChainConfig
+	lastHeight int64
+	mutex      sync.Mutex
+
+	Channel chan types.WebsocketEmittable
+}
+
+func NewPollBlockSource(
+	logger zerolog.Logger,
+	config *configPkg.ChainConfig,
+	rpc *RPC,
+) *PollBlockSource {
+	return &PollBlockSource{
+		SingleBlocksFetcher: NewSingleBlocksFetcher(rpc),
+		logger:              logger.With().Str("component", "poll_block_source").Logger(),
+		config:              config,
+		Channel:             make(chan types.WebsocketEmittable),
+	}
+}
+
+func (s *PollBlockSource) Listen(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.config.Intervals.Poll * time.Second)
+		defer ticker.Stop()
+
+		for {
+			s.Poll(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.logger.Debug().Msg("Stopped polling for blocks")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *PollBlockSource) GetChannel() chan types.WebsocketEmittable {
+	return s.Channel
+}
+
+// Poll sends the blocks produced since the previous poll to Channel, it returns
+// without sending them if the context is done, as nothing reads the channel then.
+func (s *PollBlockSource) Poll(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	block, err := s.GetBlock(0)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Error polling for the latest block")
+		return
+	}
+
+	if block.Height <= s.lastHeight {
+		s.logger.Trace().
+			Int64("height", block.Height).
+			Int64("last_height", s.lastHeight).
+			Msg("No new blocks since last poll")
+		return
+	}
+
+	if s.lastHeight != 0 {
+		s.FillGap(ctx, s.lastHeight+1, block.Height-1)
+	}
+
+	s.logger.Trace().Int64("height", block.Height).Msg("Got new block")
+
+	select {
+	case s.Channel <- block:
+		s.lastHeight = block.Height
+	case <-ctx.Done():
+	}
+}
+
+func (s *PollBlockSource) FillGap(ctx context.Context, fromHeight, toHeight int64) {
+	if fromHeight > toHeight {
+		return
+	}
+
+	fromHeight = utils.MaxInt64(fromHeight, toHeight-int64(s.config.Pagination.BlocksSearch)+1)
+
+	heights := make([]int64, 0, toHeight-fromHeight+1)
+	for height := fromHeight; height <= toHeight; height++ {
+		heights = append(heights, height)
+	}
+
+	s.logger.Debug().
+		Int64("from", fromHeight).
+		Int64("to", toHeight).
+		Msg("Filling the gap since last poll")
+
+	blocks, errs := s.GetBlocks(heights)
+	if len(errs) > 0 {
+		s.logger.Error().Errs("errors", errs).Msg("Error querying for blocks since last poll")
+	}
+
+	for _, height := range heights {
+		block, found := blocks[height]
+		if !found {
+			continue
+		}
+
+		select {
+		case s.Channel <- block:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package tendermint
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/http"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/metrics"
+	"main/pkg/types"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func getTestPollBlockSource() *PollBlockSource {
+	config := &configPkg.ChainConfig{
+		Name:         "chain",
+		RPCEndpoints: []string{"https://example.com"},
+		BlockSource:  constants.BlockSourcePoll,
+		Pagination:   configPkg.ChainPagination{BlocksSearch: 2},
+	}
+	logger := loggerPkg.GetNopLogger()
+
+	metricsManager := metrics.NewManager(*logger, configPkg.MetricsConfig{Enabled: null.BoolFrom(false)})
+	blockSource := GetBlockSource(*logger, config, metricsManager, http.NewPools(*logger, metricsManager, config))
+
+	pollBlockSource, _ := blockSource.(*PollBlockSource)
+	return pollBlockSource
+}
+
+func registerBlockAtHeight(url string, height int64) {
+	block := strings.ReplaceAll(
+		string(assets.GetBytesOrPanic("rpc-block.json")),
+		`"height": "21955288"`,
+		`"height": "`+strconv.FormatInt(height, 10)+`"`,
+	)
+
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, block))
+}
+
+func pollAndCollect(blockSource *PollBlockSource) []int64 {
+	heights := make([]int64, 0)
+	done := make(chan bool)
+
+	go func() {
+		for emittable := range blockSource.GetChannel() {
+			block, _ := emittable.(*types.Block)
+			heights = append(heights, block.Height)
+		}
+
+		done <- true
+	}()
+
+	blockSource.Poll(context.Background())
+	close(blockSource.Channel)
+	<-done
+
+	return heights
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPollBlockSourcePollFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestPollBlockSource()
+	require.NotNil(t, blockSource)
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	require.Empty(t, pollAndCollect(blockSource))
+	require.Zero(t, blockSource.lastHeight)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPollBlockSourcePollFirstBlock(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestPollBlockSource()
+	registerBlockAtHeight("https://example.com/block", 100)
+
+	require.Equal(t, []int64{100}, pollAndCollect(blockSource))
+	require.Equal(t, int64(100), blockSource.lastHeight)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPollBlockSourcePollNoNewBlocks(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestPollBlockSource()
+	blockSource.lastHeight = 100
+	registerBlockAtHeight("https://example.com/block", 100)
+
+	require.Empty(t, pollAndCollect(blockSource))
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPollBlockSourcePollFillGap(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestPollBlockSource()
+	blockSource.lastHeight = 95
+	registerBlockAtHeight("https://example.com/block", 100)
+	registerBlockAtHeight("https://example.com/block?height=98", 98)
+	registerBlockAtHeight("https://example.com/block?height=99", 99)
+
+	// only blocks-search blocks before the latest one are fetched, 96 and 97 are left
+	// for the blocks populator
+	require.Equal(t, []int64{98, 99, 100}, pollAndCollect(blockSource))
+	require.Equal(t, int64(100), blockSource.lastHeight)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPollBlockSourcePollFillGapFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestPollBlockSource()
+	blockSource.lastHeight = 98
+	registerBlockAtHeight("https://example.com/block", 100)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/block?height=99",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	require.Equal(t, []int64{100}, pollAndCollect(blockSource))
+	require.Equal(t, int64(100), blockSource.lastHeight)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPollBlockSourcePollStopped(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	blockSource := getTestPollBlockSource()
+	blockSource.lastHeight = 98
+	registerBlockAtHeight("https://example.com/block", 100)
+	registerBlockAtHeight("https://example.com/block?height=99", 99)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// nothing reads the channel, so it would block forever if the context was not checked
+	blockSource.Poll(ctx)
+	require.Equal(t, int64(98), blockSource.lastHeight)
+}
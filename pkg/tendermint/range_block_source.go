@@ -0,0 +1,165 @@
+package tendermint
+
+import (
+	"fmt"
+	"main/pkg/types"
+	"main/pkg/types/responses"
+	"main/pkg/utils"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// MaxBlockchainRange is the maximum amount of block headers a node returns per /blockchain query.
+const MaxBlockchainRange = 20
+
+// RangeBlockSource receives new blocks and fetches the latest block the same way
+// WebsocketBlockSource does, but backfills older blocks in ranges: headers are taken
+// from /blockchain and signatures from a batch of /commit queries, so a range
+// of up to MaxBlockchainRange blocks takes 2 requests instead of one per block.
+// Headers do not include evidence, so blocks that have any are fetched with /block.
+type RangeBlockSource struct {
+	*WebsocketBlockSource
+
+	logger zerolog.Logger
+	rpc    *RPC
+}
+
+func NewRangeBlockSource(
+	logger zerolog.Logger,
+	rpc *RPC,
+	websocketManager *WebsocketManager,
+) *RangeBlockSource {
+	return &RangeBlockSource{
+		WebsocketBlockSource: NewWebsocketBlockSource(rpc, websocketManager),
+		logger:               logger.With().Str("component", "range_block_source").Logger(),
+		rpc:                  rpc,
+	}
+}
+
+func (s *RangeBlockSource) GetBlocks(heights []int64) (map[int64]*types.Block, []error) {
+	blocksMap := make(map[int64]*types.Block)
+	errors := make([]error, 0)
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	for _, heightsRange := range utils.SplitIntoRanges(heights, MaxBlockchainRange) {
+		wg.Add(1)
+		go func(heightsRange []int64) {
+			defer wg.Done()
+
+			blocks, err := s.GetBlocksRange(heightsRange[0], heightsRange[len(heightsRange)-1])
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				errors = append(errors, err)
+				return
+			}
+
+			for _, block := range blocks {
+				blocksMap[block.Height] = block
+			}
+		}(heightsRange)
+	}
+
+	wg.Wait()
+	return blocksMap, errors
+}
+
+func (s *RangeBlockSource) GetBlocksRange(minHeight, maxHeight int64) ([]*types.Block, error) {
+	s.logger.Trace().
+		Int64("min_height", minHeight).
+		Int64("max_height", maxHeight).
+		Msg("Fetching blocks range")
+
+	var (
+		wg                 sync.WaitGroup
+		blockchainResponse *responses.BlockchainResponse
+		blockchainErr      error
+		commits            map[int64]*responses.Commit
+		commitsErr         error
+	)
+
+	commitsHeights := make([]int64, 0, maxHeight-minHeight+1)
+	for height := minHeight - 1; height < maxHeight; height++ {
+		if height > 0 {
+			commitsHeights = append(commitsHeights, height)
+		}
+	}
+
+	wg.Add(2)
+	go func() {
+		blockchainResponse, blockchainErr = s.rpc.GetBlockchain(minHeight, maxHeight)
+		wg.Done()
+	}()
+
+	go func() {
+		if len(commitsHeights) > 0 {
+			commits, commitsErr = s.rpc.GetCommits(commitsHeights)
+		}
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	if blockchainErr != nil {
+		return nil, blockchainErr
+	}
+
+	if commitsErr != nil {
+		return nil, commitsErr
+	}
+
+	blocks := make([]*types.Block, 0, len(blockchainResponse.Result.BlockMetas))
+
+	for _, meta := range blockchainResponse.Result.BlockMetas {
+		height, err := strconv.ParseInt(meta.Header.Height, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if height < minHeight || height > maxHeight {
+			continue
+		}
+
+		lastCommit, found := commits[height-1]
+		if !found && height > 1 {
+			return nil, fmt.Errorf("no commit for block at height %d", height)
+		}
+
+		block, err := meta.ToBlock(lastCommit)
+		if err != nil {
+			return nil, err
+		}
+
+		if meta.Header.HasEvidence() {
+			if block.Evidence, err = s.GetBlockEvidence(height); err != nil {
+				return nil, err
+			}
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// GetBlockEvidence returns the evidence included in the block at the given height.
+func (s *RangeBlockSource) GetBlockEvidence(height int64) ([]*types.Evidence, error) {
+	s.logger.Debug().Int64("height", height).Msg("Fetching block with evidence")
+
+	response, err := s.rpc.GetBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := response.Result.Block.ToBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return block.Evidence, nil
+}
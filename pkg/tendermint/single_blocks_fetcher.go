@@ -0,0 +1,52 @@
+package tendermint
+
+import (
+	"main/pkg/types"
+	"sync"
+)
+
+// SingleBlocksFetcher fetches blocks one by one with a /block query per height.
+type SingleBlocksFetcher struct {
+	rpc *RPC
+}
+
+func NewSingleBlocksFetcher(rpc *RPC) *SingleBlocksFetcher {
+	return &SingleBlocksFetcher{rpc: rpc}
+}
+
+func (f *SingleBlocksFetcher) GetBlock(height int64) (*types.Block, error) {
+	blockRaw, err := f.rpc.GetBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return blockRaw.Result.Block.ToBlock()
+}
+
+func (f *SingleBlocksFetcher) GetBlocks(heights []int64) (map[int64]*types.Block, []error) {
+	blocksMap := make(map[int64]*types.Block)
+	errors := make([]error, 0)
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	for _, height := range heights {
+		wg.Add(1)
+		go func(height int64) {
+			block, err := f.GetBlock(height)
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				errors = append(errors, err)
+			} else {
+				blocksMap[height] = block
+			}
+
+			wg.Done()
+		}(height)
+	}
+
+	wg.Wait()
+	return blocksMap, errors
+}
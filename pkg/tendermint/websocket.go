@@ -66,7 +66,11 @@ func SetUnexportedField(field reflect.Value, value interface{}) {
 		Set(reflect.ValueOf(value))
 }
 
-func (t *WebsocketClient) Listen() {
+// Listen connects to the node and sends the received blocks and errors to Channel
+// until the context is done, then closes it.
+func (t *WebsocketClient) Listen(ctx context.Context) {
+	defer close(t.Channel)
+
 	client, err := tmClient.NewWS(
 		t.url,
 		"/websocket",
@@ -95,10 +99,10 @@ func (t *WebsocketClient) Listen() {
 
 	t.client = client
 	t.logger.Trace().Msg("Connecting to a node...")
-	t.ConnectAndListen()
+	t.ConnectAndListen(ctx)
 }
 
-func (t *WebsocketClient) ConnectAndListen() {
+func (t *WebsocketClient) ConnectAndListen(ctx context.Context) {
 	t.active = false
 	t.metricsManager.LogNodeConnection(t.config.Name, t.url, false)
 
@@ -112,7 +116,12 @@ func (t *WebsocketClient) ConnectAndListen() {
 			t.Channel <- &types.WSError{Error: err}
 			t.logger.Warn().Err(err).Msg("Error connecting to node")
 
-			time.Sleep(time.Minute)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-time.After(time.Minute):
+			}
 		} else {
 			t.logger.Info().Msg("Connected to a node")
 			t.active = true
@@ -128,6 +137,9 @@ func (t *WebsocketClient) ConnectAndListen() {
 	loop := true
 	for loop {
 		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
 		case <-t.reconnectTimer.C:
 			t.logger.Debug().Float64("seconds", time.Since(t.lastEventTime).Seconds()).Msg("Last block info")
 			if time.Since(t.lastEventTime) > StaleTime {
@@ -141,10 +153,10 @@ func (t *WebsocketClient) ConnectAndListen() {
 	}
 
 	t.logger.Debug().Msg("Finished listening")
-	t.Reconnect()
+	t.Reconnect(ctx)
 }
 
-func (t *WebsocketClient) Reconnect() {
+func (t *WebsocketClient) Reconnect(ctx context.Context) {
 	if t.client == nil {
 		t.logger.Debug().Msg("No client, return")
 		return
@@ -162,7 +174,7 @@ func (t *WebsocketClient) Reconnect() {
 	t.logger.Debug().Msg("Node disconnected, reconnecting...")
 
 	time.Sleep(time.Second)
-	t.ConnectAndListen()
+	t.ConnectAndListen(ctx)
 }
 
 func (t *WebsocketClient) Stop() {
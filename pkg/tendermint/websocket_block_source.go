@@ -0,0 +1,29 @@
+package tendermint
+
+import (
+	"context"
+	"main/pkg/types"
+)
+
+// WebsocketBlockSource receives new blocks via websockets
+// and backfills older blocks by querying them one by one.
+type WebsocketBlockSource struct {
+	*SingleBlocksFetcher
+
+	websocketManager *WebsocketManager
+}
+
+func NewWebsocketBlockSource(rpc *RPC, websocketManager *WebsocketManager) *WebsocketBlockSource {
+	return &WebsocketBlockSource{
+		SingleBlocksFetcher: NewSingleBlocksFetcher(rpc),
+		websocketManager:    websocketManager,
+	}
+}
+
+func (s *WebsocketBlockSource) Listen(ctx context.Context) {
+	s.websocketManager.Listen(ctx)
+}
+
+func (s *WebsocketBlockSource) GetChannel() chan types.WebsocketEmittable {
+	return s.websocketManager.Channel
+}
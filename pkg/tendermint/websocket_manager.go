@@ -1,6 +1,8 @@
 package tendermint
 
 import (
+	"context"
+	"main/pkg/http"
 	"main/pkg/metrics"
 	"sync"
 
@@ -14,6 +16,7 @@ type WebsocketManager struct {
 	logger         zerolog.Logger
 	nodes          []*WebsocketClient
 	metricsManager *metrics.Manager
+	rpcPool        *http.Pool
 	queue          Queue
 	mutex          sync.Mutex
 
@@ -24,6 +27,7 @@ func NewWebsocketManager(
 	logger zerolog.Logger,
 	config *config.ChainConfig,
 	metricsManager *metrics.Manager,
+	pools *http.Pools,
 ) *WebsocketManager {
 	nodes := make([]*WebsocketClient, len(config.RPCEndpoints))
 
@@ -35,23 +39,34 @@ func NewWebsocketManager(
 		logger:         logger.With().Str("component", "websocket_manager").Logger(),
 		nodes:          nodes,
 		metricsManager: metricsManager,
+		rpcPool:        pools.RPC,
 		queue:          NewQueue(100),
 		Channel:        make(chan types.WebsocketEmittable),
 	}
 }
 
-func (m *WebsocketManager) Listen() {
+func (m *WebsocketManager) Listen(ctx context.Context) {
 	for _, node := range m.nodes {
-		go node.Listen()
+		go node.Listen(ctx)
 	}
 
 	for _, node := range m.nodes {
-		go m.ProcessNode(node)
+		go m.ProcessNode(ctx, node)
 	}
 }
 
-func (m *WebsocketManager) ProcessNode(node *WebsocketClient) {
+// ProcessNode forwards messages from a node to Channel, skipping the ones already received
+// from other nodes, until the node's channel is closed once the node stops listening.
+func (m *WebsocketManager) ProcessNode(ctx context.Context, node *WebsocketClient) {
 	for msg := range node.Channel {
+		if m.rpcPool.IsLagging(node.url) {
+			m.logger.Trace().
+				Str("url", node.url).
+				Str("hash", msg.Hash()).
+				Msg("Node is lagging behind, skipping message")
+			continue
+		}
+
 		m.mutex.Lock()
 
 		if m.queue.Has(msg) {
@@ -62,8 +77,14 @@ func (m *WebsocketManager) ProcessNode(node *WebsocketClient) {
 			continue
 		}
 
-		m.Channel <- msg
-		m.queue.Add(msg)
+		// nobody reads the channel once the context is done, messages are dropped
+		// so the node is not blocked
+		select {
+		case m.Channel <- msg:
+			m.queue.Add(msg)
+		case <-ctx.Done():
+		}
+
 		m.mutex.Unlock()
 	}
 }
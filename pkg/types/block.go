@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"main/pkg/constants"
+	"slices"
 	"time"
 )
 
@@ -11,6 +13,8 @@ type Block struct {
 	Proposer   string
 	Signatures map[string]int32
 	Validators map[string]bool
+	// Evidence is only set for blocks fetched with /block queries or received via websocket.
+	Evidence []*Evidence
 }
 
 func (b *Block) Hash() string {
@@ -20,3 +24,19 @@ func (b *Block) Hash() string {
 func (b *Block) SetValidators(validators map[string]bool) {
 	b.Validators = validators
 }
+
+// GetMissedValidators returns sorted addresses of validators that were in the active set
+// but have not signed the block.
+func (b *Block) GetMissedValidators() []string {
+	missed := make([]string, 0)
+
+	for address := range b.Validators {
+		value, ok := b.Signatures[address]
+		if !ok || (value != constants.ValidatorSigned && value != constants.ValidatorNilSignature) {
+			missed = append(missed, address)
+		}
+	}
+
+	slices.Sort(missed)
+	return missed
+}
@@ -23,3 +23,14 @@ func TestBlockSetValidators(t *testing.T) {
 	assert.Len(t, block.Validators, 1, "Validators length should be 1!")
 	assert.True(t, block.Validators["1"], "Validators mismatch!")
 }
+
+func TestBlockGetMissedValidators(t *testing.T) {
+	t.Parallel()
+
+	block := Block{
+		Height:     123,
+		Signatures: map[string]int32{"signed": 2, "nil": 3, "absent": 1, "inactive": 1},
+		Validators: map[string]bool{"signed": true, "nil": true, "absent": true, "nosignature": true},
+	}
+	assert.Equal(t, []string{"absent", "nosignature"}, block.GetMissedValidators(), "Missed validators mismatch!")
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"main/pkg/utils"
 	"sort"
+	"strconv"
 
 	"cosmossdk.io/math"
 )
@@ -12,6 +13,8 @@ type Entry struct {
 	NeedsToSign   bool
 	Validator     *Validator
 	SignatureInfo SignatureInto
+	// WindowsSignatureInfo holds signatures info in additional signing windows, by window name.
+	WindowsSignatureInfo map[string]SignatureInto
 }
 
 type Entries map[string]*Entry
@@ -86,3 +89,35 @@ func (e Entries) SetVotingPowerPercent() {
 		entry.Validator.CumulativeVotingPowerPercent = cumulativeVotingPowerPercent
 	}
 }
+
+// SetNeedsToSign marks active validators which are required to sign blocks, the same way
+// as Interchain Security does it: validators are sorted by voting power desc, and everyone
+// until the cumulative voting power exceeds 1 - softOptOutThreshold has to sign, as well as
+// everyone with the same voting power as the last of them.
+// For sovereign chains the threshold is 0, so all active validators need to sign.
+func (e Entries) SetNeedsToSign(softOptOutThreshold float64) {
+	activeAndSortedEntries := e.GetActive()
+
+	sort.Slice(activeAndSortedEntries, func(first, second int) bool {
+		return activeAndSortedEntries[first].Validator.VotingPower.GT(activeAndSortedEntries[second].Validator.VotingPower)
+	})
+
+	threshold := math.LegacyMustNewDecFromStr(strconv.FormatFloat(softOptOutThreshold, 'f', 10, 64))
+	maxPowerSum := e.GetTotalVotingPower().Mul(math.LegacyOneDec().Sub(threshold))
+	powerSum := math.LegacyZeroDec()
+
+	var smallestNonOptOutPower *math.LegacyDec
+
+	for _, entry := range activeAndSortedEntries {
+		powerSum = powerSum.Add(entry.Validator.VotingPower)
+		if powerSum.GT(maxPowerSum) {
+			smallestNonOptOutPower = &entry.Validator.VotingPower
+			break
+		}
+	}
+
+	for _, entry := range e {
+		entry.NeedsToSign = entry.IsActive &&
+			(smallestNonOptOutPower == nil || entry.Validator.VotingPower.GTE(*smallestNonOptOutPower))
+	}
+}
@@ -116,3 +116,47 @@ func TestEntriesGetByValidatorAddresses(t *testing.T) {
 	filteredEntries := entries.ByValidatorAddresses([]string{"firstaddr", "secondaddr"})
 	assert.Len(t, filteredEntries, 2)
 }
+
+func TestEntriesSetNeedsToSign(t *testing.T) {
+	t.Parallel()
+
+	newEntry := func(address string, votingPower int64, isActive bool) *Entry {
+		return &Entry{
+			IsActive:  isActive,
+			Validator: &Validator{OperatorAddress: address, VotingPower: math.LegacyNewDec(votingPower)},
+		}
+	}
+
+	entries := Entries{
+		"first":    newEntry("first", 50, true),
+		"second":   newEntry("second", 30, true),
+		"third":    newEntry("third", 15, true),
+		"fourth":   newEntry("fourth", 3, true),
+		"fifth":    newEntry("fifth", 2, true),
+		"inactive": newEntry("inactive", 10, false),
+	}
+
+	// top 95% is 50 + 30 + 15, and the one after it, who crosses the 95% boundary, has to sign too
+	entries.SetNeedsToSign(0.05)
+	assert.True(t, entries["first"].NeedsToSign)
+	assert.True(t, entries["second"].NeedsToSign)
+	assert.True(t, entries["third"].NeedsToSign)
+	assert.True(t, entries["fourth"].NeedsToSign)
+	assert.False(t, entries["fifth"].NeedsToSign)
+	assert.False(t, entries["inactive"].NeedsToSign)
+
+	// validators with the same voting power as the last one who has to sign have to sign too
+	entries["fifth"].Validator.VotingPower = math.LegacyNewDec(3)
+	entries.SetNeedsToSign(0.05)
+	assert.True(t, entries["fifth"].NeedsToSign)
+
+	entries.SetNeedsToSign(0.5)
+	assert.True(t, entries["first"].NeedsToSign)
+	assert.True(t, entries["second"].NeedsToSign)
+	assert.False(t, entries["third"].NeedsToSign)
+
+	entries.SetNeedsToSign(0)
+	for address, entry := range entries {
+		assert.Equal(t, address != "inactive", entry.NeedsToSign)
+	}
+}
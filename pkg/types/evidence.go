@@ -0,0 +1,23 @@
+package types
+
+import (
+	"time"
+)
+
+// Evidence is a duplicate vote (double sign) evidence included in a block.
+type Evidence struct {
+	// Height is the height the validator has signed two different blocks at.
+	Height int64
+	// BlockHeight is the height of the block the evidence was included in.
+	BlockHeight int64
+	// ValidatorAddress is the hex consensus address of the validator that has double signed.
+	ValidatorAddress string
+	// OperatorAddress is set once the validator is found by its consensus address.
+	OperatorAddress string
+	Time            time.Time
+}
+
+// FormatTime returns the double sign time in UTC, so it can be matched against node logs.
+func (e Evidence) FormatTime() string {
+	return e.Time.UTC().Format(time.DateTime) + " UTC"
+}
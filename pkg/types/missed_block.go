@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+type MissedBlock struct {
+	Height int64
+	Time   time.Time
+}
+
+// MissedBlocksRange is a range of consecutive missed blocks, From is the earliest one.
+type MissedBlocksRange struct {
+	From MissedBlock
+	To   MissedBlock
+}
+
+func (r MissedBlocksRange) FormatHeights() string {
+	if r.From.Height == r.To.Height {
+		return fmt.Sprintf("%d", r.From.Height)
+	}
+
+	return fmt.Sprintf("%d–%d", r.From.Height, r.To.Height)
+}
+
+// FormatTime returns the range time in UTC, so it can be matched against node logs.
+func (r MissedBlocksRange) FormatTime() string {
+	if r.From.Height == r.To.Height {
+		return r.From.Time.UTC().Format(time.DateTime) + " UTC"
+	}
+
+	return fmt.Sprintf(
+		"%s – %s UTC",
+		r.From.Time.UTC().Format(time.DateTime),
+		r.To.Time.UTC().Format(time.DateTime),
+	)
+}
+
+// GroupMissedBlocks collapses missed blocks sorted by height descending
+// into ranges of consecutive heights, the most recent range first.
+func GroupMissedBlocks(missedBlocks []MissedBlock) []MissedBlocksRange {
+	ranges := make([]MissedBlocksRange, 0)
+
+	for _, missedBlock := range missedBlocks {
+		if len(ranges) > 0 && ranges[len(ranges)-1].From.Height-1 == missedBlock.Height {
+			ranges[len(ranges)-1].From = missedBlock
+			continue
+		}
+
+		ranges = append(ranges, MissedBlocksRange{From: missedBlock, To: missedBlock})
+	}
+
+	return ranges
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupMissedBlocks(t *testing.T) {
+	t.Parallel()
+
+	blockTime := time.Date(2025, 1, 19, 11, 3, 0, 0, time.UTC)
+	missedBlocks := []MissedBlock{
+		{Height: 12045, Time: blockTime},
+		{Height: 12044, Time: blockTime.Add(-time.Second)},
+		{Height: 12001, Time: blockTime.Add(-time.Minute)},
+		{Height: 11000, Time: blockTime.Add(-time.Hour)},
+		{Height: 10999, Time: blockTime.Add(-time.Hour - time.Second)},
+	}
+
+	ranges := GroupMissedBlocks(missedBlocks)
+	require.Len(t, ranges, 3)
+
+	require.Equal(t, "12044–12045", ranges[0].FormatHeights())
+	require.Equal(t, "2025-01-19 11:02:59 – 2025-01-19 11:03:00 UTC", ranges[0].FormatTime())
+	require.Equal(t, "12001", ranges[1].FormatHeights())
+	require.Equal(t, "2025-01-19 11:02:00 UTC", ranges[1].FormatTime())
+	require.Equal(t, "10999–11000", ranges[2].FormatHeights())
+}
+
+func TestGroupMissedBlocksEmpty(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, GroupMissedBlocks([]MissedBlock{}))
+}
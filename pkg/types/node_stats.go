@@ -0,0 +1,34 @@
+package types
+
+import (
+	"time"
+)
+
+type NodeStats struct {
+	Pool                string
+	Host                string
+	Successes           int64
+	Failures            int64
+	ConsecutiveFailures int64
+	ErrorRate           float64
+	Latency             time.Duration
+	LatestHeight        int64
+	Lag                 int64
+	IsLagging           bool
+	QuarantinedUntil    time.Time
+}
+
+func (s NodeStats) IsQuarantined(now time.Time) bool {
+	return now.Before(s.QuarantinedUntil)
+}
+
+// Score returns a number between 0 and 1 describing how healthy the node is,
+// based on its recent error rate and latency. Nodes that were never queried
+// are considered healthy, so they get a chance to be queried.
+func (s NodeStats) Score() float64 {
+	if s.Successes+s.Failures == 0 {
+		return 1
+	}
+
+	return (1 - s.ErrorRate) / (1 + s.Latency.Seconds())
+}
@@ -58,6 +58,14 @@ func (n Notifiers) GetNotifiersForReporter(
 	return notifiers
 }
 
+func (n Notifiers) HasNotifiers(operatorAddress string) bool {
+	_, found := utils.Find(n, func(notifier *Notifier) bool {
+		return notifier.OperatorAddress == operatorAddress
+	})
+
+	return found
+}
+
 func (n Notifiers) GetValidatorsForNotifier(
 	reporter constants.ReporterName,
 	userID string,
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"fmt"
+	"main/pkg/constants"
+	"time"
+)
+
+// OutboxReport is a report rendered for a reporter, stored until it's delivered.
+// SentChunks is how many chunks of a message split into multiple ones are delivered already,
+// so if sending a chunk fails, only the rest are sent on retry.
+type OutboxReport struct {
+	ID            int64
+	Chain         string
+	Reporter      constants.ReporterName
+	Height        int64
+	Message       string
+	Status        constants.OutboxStatus
+	Attempts      int64
+	SentChunks    int64
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// RateLimitedError is returned by reporters when the messenger asks
+// to retry sending no earlier than after some time.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s: %s", e.RetryAfter, e.Err)
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
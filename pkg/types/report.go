@@ -17,6 +17,11 @@ type ReportEvent interface {
 	Render(formatType constants.FormatType, renderData ReportEventRenderData) string
 }
 
+// WindowedReportEvent is a report event that happened in a specific signing window.
+type WindowedReportEvent interface {
+	GetWindow() string
+}
+
 type Report struct {
 	Events []ReportEvent
 }
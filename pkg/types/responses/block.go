@@ -2,6 +2,7 @@ package responses
 
 import (
 	"encoding/json"
+	"main/pkg/constants"
 	"main/pkg/types"
 	"strconv"
 	"time"
@@ -58,12 +59,19 @@ type ResponseError struct {
 type TendermintBlock struct {
 	Header     BlockHeader     `json:"header"`
 	LastCommit BlockLastCommit `json:"last_commit"`
+	Evidence   BlockEvidence   `json:"evidence"`
 }
 
 type BlockHeader struct {
-	Height   string    `json:"height"`
-	Time     time.Time `json:"time"`
-	Proposer string    `json:"proposer_address"`
+	Height       string    `json:"height"`
+	Time         time.Time `json:"time"`
+	Proposer     string    `json:"proposer_address"`
+	EvidenceHash string    `json:"evidence_hash"`
+}
+
+// HasEvidence returns whether the block with this header includes any evidence.
+func (h BlockHeader) HasEvidence() bool {
+	return h.EvidenceHash != "" && h.EvidenceHash != constants.EmptyEvidenceHash
 }
 
 type BlockLastCommit struct {
@@ -75,6 +83,27 @@ type BlockSignature struct {
 	ValidatorAddress string `json:"validator_address"`
 }
 
+type BlockEvidence struct {
+	Evidence []BlockEvidenceItem `json:"evidence"`
+}
+
+type BlockEvidenceItem struct {
+	Type  string            `json:"type"`
+	Value BlockEvidenceData `json:"value"`
+}
+
+// BlockEvidenceData is the duplicate vote evidence data, other evidence types are not parsed.
+type BlockEvidenceData struct {
+	VoteA *EvidenceVote `json:"vote_a"`
+	VoteB *EvidenceVote `json:"vote_b"`
+}
+
+type EvidenceVote struct {
+	Height           string    `json:"height"`
+	Timestamp        time.Time `json:"timestamp"`
+	ValidatorAddress string    `json:"validator_address"`
+}
+
 func (b *TendermintBlock) ToBlock() (*types.Block, error) {
 	height, err := strconv.ParseInt(b.Header.Height, 10, 64)
 	if err != nil {
@@ -87,10 +116,33 @@ func (b *TendermintBlock) ToBlock() (*types.Block, error) {
 		signatures[signature.ValidatorAddress] = int32(signature.BlockIDFlag)
 	}
 
+	evidence := make([]*types.Evidence, 0)
+
+	for _, evidenceItem := range b.Evidence.Evidence {
+		if evidenceItem.Type != constants.DuplicateVoteEvidenceType || evidenceItem.Value.VoteA == nil {
+			continue
+		}
+
+		vote := evidenceItem.Value.VoteA
+
+		evidenceHeight, err := strconv.ParseInt(vote.Height, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		evidence = append(evidence, &types.Evidence{
+			Height:           evidenceHeight,
+			BlockHeight:      height,
+			ValidatorAddress: vote.ValidatorAddress,
+			Time:             vote.Timestamp,
+		})
+	}
+
 	return &types.Block{
 		Height:     height,
 		Time:       b.Header.Time,
 		Proposer:   b.Header.Proposer,
 		Signatures: signatures,
+		Evidence:   evidence,
 	}, nil
 }
@@ -2,7 +2,9 @@ package responses
 
 import (
 	"encoding/json"
+	"main/pkg/constants"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -43,6 +45,56 @@ func TestToBlockValid(t *testing.T) {
 	assert.Equal(t, int32(2), block.Signatures["second"], "Block signature mismatch!")
 }
 
+func TestToBlockEvidenceInvalid(t *testing.T) {
+	t.Parallel()
+
+	blockRaw := &TendermintBlock{
+		Header: BlockHeader{Height: "100"},
+		Evidence: BlockEvidence{
+			Evidence: []BlockEvidenceItem{
+				{
+					Type:  constants.DuplicateVoteEvidenceType,
+					Value: BlockEvidenceData{VoteA: &EvidenceVote{Height: "invalid"}},
+				},
+			},
+		},
+	}
+
+	block, err := blockRaw.ToBlock()
+	require.Error(t, err, "Error should be presented!")
+	assert.Nil(t, block, "Block should not be presented!")
+}
+
+func TestToBlockEvidenceValid(t *testing.T) {
+	t.Parallel()
+
+	evidenceTime := time.Unix(1700000000, 0)
+
+	blockRaw := &TendermintBlock{
+		Header: BlockHeader{Height: "100"},
+		Evidence: BlockEvidence{
+			Evidence: []BlockEvidenceItem{
+				{
+					Type: constants.DuplicateVoteEvidenceType,
+					Value: BlockEvidenceData{
+						VoteA: &EvidenceVote{Height: "98", ValidatorAddress: "first", Timestamp: evidenceTime},
+						VoteB: &EvidenceVote{Height: "98", ValidatorAddress: "first", Timestamp: evidenceTime},
+					},
+				},
+				{Type: "tendermint/LightClientAttackEvidence"},
+			},
+		},
+	}
+
+	block, err := blockRaw.ToBlock()
+	require.NoError(t, err, "Error should not be presented!")
+	require.Len(t, block.Evidence, 1, "Block should have 1 evidence!")
+	assert.Equal(t, int64(98), block.Evidence[0].Height, "Evidence height mismatch!")
+	assert.Equal(t, int64(100), block.Evidence[0].BlockHeight, "Evidence block height mismatch!")
+	assert.Equal(t, "first", block.Evidence[0].ValidatorAddress, "Evidence validator mismatch!")
+	assert.Equal(t, evidenceTime, block.Evidence[0].Time, "Evidence time mismatch!")
+}
+
 func TestBlockResponseUnmarshalJsonInvalidJson(t *testing.T) {
 	t.Parallel()
 
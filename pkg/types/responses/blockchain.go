@@ -0,0 +1,56 @@
+package responses
+
+import "main/pkg/types"
+
+type BlockchainResponse struct {
+	Result *BlockchainResult `json:"result"`
+	Error  *ResponseError    `json:"error"`
+}
+
+type BlockchainResult struct {
+	LastHeight string      `json:"last_height"`
+	BlockMetas []BlockMeta `json:"block_metas"`
+}
+
+type BlockMeta struct {
+	Header BlockHeader `json:"header"`
+}
+
+type CommitRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      int64             `json:"id"`
+	Method  string            `json:"method"`
+	Params  map[string]string `json:"params"`
+}
+
+type CommitResponse struct {
+	ID     int64          `json:"id"`
+	Result *CommitResult  `json:"result"`
+	Error  *ResponseError `json:"error"`
+}
+
+type CommitResult struct {
+	SignedHeader SignedHeader `json:"signed_header"`
+}
+
+type SignedHeader struct {
+	Header BlockHeader `json:"header"`
+	Commit Commit      `json:"commit"`
+}
+
+type Commit struct {
+	Height     string           `json:"height"`
+	Signatures []BlockSignature `json:"signatures"`
+}
+
+// ToBlock builds a block the same way TendermintBlock.ToBlock does, with signatures taken
+// from the block's last commit, which is the commit for the previous height.
+// The commit can be nil for the first block of the chain, which has no last commit.
+func (m *BlockMeta) ToBlock(lastCommit *Commit) (*types.Block, error) {
+	block := TendermintBlock{Header: m.Header}
+	if lastCommit != nil {
+		block.LastCommit.Signatures = lastCommit.Signatures
+	}
+
+	return block.ToBlock()
+}
@@ -0,0 +1,15 @@
+package responses
+
+type StatusResponse struct {
+	Result *StatusResult  `json:"result"`
+	Error  *ResponseError `json:"error"`
+}
+
+type StatusResult struct {
+	SyncInfo StatusSyncInfo `json:"sync_info"`
+}
+
+type StatusSyncInfo struct {
+	LatestBlockHeight string `json:"latest_block_height"`
+	CatchingUp        bool   `json:"catching_up"`
+}
@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+// SlashingParams are the chain slashing params the app relies on.
+type SlashingParams struct {
+	SignedBlocksWindow      int64         `json:"signed_blocks_window"`
+	MinSignedPerWindow      float64       `json:"min_signed_per_window"`
+	DowntimeJailDuration    time.Duration `json:"downtime_jail_duration"`
+	SlashFractionDowntime   float64       `json:"slash_fraction_downtime"`
+	SlashFractionDoubleSign float64       `json:"slash_fraction_double_sign"`
+}
@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+// UnjailReminder is how many times and when last a jailed validator was reminded about unjailing.
+type UnjailReminder struct {
+	LastReminded time.Time `json:"last_reminded"`
+	Count        int       `json:"count"`
+}
+
+// UnjailReminders are reminders by validator operator address.
+type UnjailReminders map[string]UnjailReminder
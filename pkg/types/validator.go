@@ -1,12 +1,18 @@
 package types
 
 import (
+	"time"
+
 	"cosmossdk.io/math"
+	"gopkg.in/guregu/null.v4"
 )
 
 type SigningInfo struct {
 	Tombstoned          bool
 	MissedBlocksCounter int64
+	JailedUntil         time.Time
+	StartHeight         int64
+	IndexOffset         int64
 }
 
 type Validator struct {
@@ -21,9 +27,27 @@ type Validator struct {
 	Commission              float64
 	Jailed                  bool
 	SigningInfo             *SigningInfo
+	// OptedIn is whether the validator opted in to validate a consumer chain, it's not set
+	// for sovereign chains or if the opted-in validators could not be fetched.
+	OptedIn null.Bool
+	// ProviderConsensusAddressValcons is the validator's consensus address on the provider chain,
+	// it's set only for consumer chains, where ConsensusAddressValcons is the consumer chain one.
+	ProviderConsensusAddressValcons string
+	// AssignedConsumerKey is whether the validator has assigned a separate key for a consumer chain.
+	AssignedConsumerKey bool
 
 	VotingPower                  math.LegacyDec
 	VotingPowerPercent           float64
 	CumulativeVotingPowerPercent float64
 	Rank                         int
 }
+
+// GetUnjailTime returns the time after which a jailed validator can unjail. It returns false
+// if it's unknown, e.g. if there's no signing info or the validator is tombstoned and can never unjail.
+func (v *Validator) GetUnjailTime() (time.Time, bool) {
+	if v.SigningInfo == nil || v.SigningInfo.Tombstoned || v.SigningInfo.JailedUntil.Unix() <= 0 {
+		return time.Time{}, false
+	}
+
+	return v.SigningInfo.JailedUntil, true
+}
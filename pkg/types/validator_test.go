@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorGetUnjailTimeNoSigningInfo(t *testing.T) {
+	t.Parallel()
+
+	validator := &Validator{}
+	_, found := validator.GetUnjailTime()
+	require.False(t, found)
+}
+
+func TestValidatorGetUnjailTimeTombstoned(t *testing.T) {
+	t.Parallel()
+
+	validator := &Validator{SigningInfo: &SigningInfo{
+		Tombstoned:  true,
+		JailedUntil: time.Now(),
+	}}
+	_, found := validator.GetUnjailTime()
+	require.False(t, found)
+}
+
+func TestValidatorGetUnjailTimeNeverJailed(t *testing.T) {
+	t.Parallel()
+
+	validator := &Validator{SigningInfo: &SigningInfo{JailedUntil: time.Unix(0, 0)}}
+	_, found := validator.GetUnjailTime()
+	require.False(t, found)
+}
+
+func TestValidatorGetUnjailTimeOk(t *testing.T) {
+	t.Parallel()
+
+	jailedUntil := time.Unix(1700000000, 0)
+	validator := &Validator{SigningInfo: &SigningInfo{JailedUntil: jailedUntil}}
+	unjailTime, found := validator.GetUnjailTime()
+	require.True(t, found)
+	require.Equal(t, jailedUntil, unjailTime)
+}
@@ -0,0 +1,9 @@
+package types
+
+// WatchdogAlert is sent when the checker itself is degraded,
+// and once again with Resolved set when it recovers.
+type WatchdogAlert struct {
+	Key         string
+	Description string
+	Resolved    bool
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
@@ -147,6 +148,29 @@ func SplitIntoChunks[T any](items []T, chunkSize int) [][]T {
 	return divided
 }
 
+// SplitIntoRanges sorts heights and splits them into groups of consecutive heights,
+// each group having at most maxRangeSize heights.
+func SplitIntoRanges(heights []int64, maxRangeSize int) [][]int64 {
+	sorted := slices.Clone(heights)
+	slices.Sort(sorted)
+
+	ranges := make([][]int64, 0)
+	start := 0
+
+	for index := 1; index <= len(sorted); index++ {
+		if index < len(sorted) &&
+			sorted[index] == sorted[index-1]+1 &&
+			index-start < maxRangeSize {
+			continue
+		}
+
+		ranges = append(ranges, sorted[start:index])
+		start = index
+	}
+
+	return ranges
+}
+
 func MakeShuffledArray(length int) []int {
 	array := make([]int, length)
 	for i := range array {
@@ -127,6 +127,20 @@ func TestSplitIntoChunks(t *testing.T) {
 	assert.Empty(t, anotherChunks, "There should be 0 chunks!")
 }
 
+func TestSplitIntoRanges(t *testing.T) {
+	t.Parallel()
+
+	ranges := SplitIntoRanges([]int64{7, 1, 2, 3, 5, 6, 4, 10}, 3)
+
+	assert.Len(t, ranges, 4, "There should be 4 ranges!")
+	assert.Equal(t, []int64{1, 2, 3}, ranges[0], "Value mismatch!")
+	assert.Equal(t, []int64{4, 5, 6}, ranges[1], "Value mismatch!")
+	assert.Equal(t, []int64{7}, ranges[2], "Value mismatch!")
+	assert.Equal(t, []int64{10}, ranges[3], "Value mismatch!")
+
+	assert.Empty(t, SplitIntoRanges([]int64{}, 3), "There should be 0 ranges!")
+}
+
 func TestSplitStringIntoChunksLessThanOneChunk(t *testing.T) {
 	t.Parallel()
 
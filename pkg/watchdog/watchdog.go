@@ -0,0 +1,258 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Pool is a nodes pool the watchdog checks for queries failing on all nodes.
+type Pool interface {
+	Name() string
+	Len() int
+	GetFailedQueriesInRow() int64
+}
+
+type reporterFailures struct {
+	count     int64
+	lastError error
+}
+
+type check struct {
+	key                 string
+	failing             bool
+	description         string
+	resolvedDescription string
+	reporter            constants.ReporterName
+}
+
+// Watchdog alerts when the checker itself is degraded for a chain: it does not get
+// new blocks, nodes do not respond, snapshots cannot be generated or reports cannot
+// be sent. Alerts are sent via all the reporters that are not failing and via the
+// fallback webhook, if it's set, once when a problem appears and once when it's resolved.
+type Watchdog struct {
+	logger      zerolog.Logger
+	config      configPkg.WatchdogConfig
+	chainConfig *configPkg.ChainConfig
+	reporters   []reportersPkg.Reporter
+	pools       []Pool
+	fallback    *Webhook
+
+	lastBlockTime    time.Time
+	snapshotFailures int64
+	lastSnapshotErr  error
+	reporterFailures map[constants.ReporterName]*reporterFailures
+	activeAlerts     map[string]bool
+	mutex            sync.Mutex
+}
+
+func NewWatchdog(
+	logger zerolog.Logger,
+	config configPkg.WatchdogConfig,
+	chainConfig *configPkg.ChainConfig,
+	reporters []reportersPkg.Reporter,
+	pools []Pool,
+) *Watchdog {
+	watchdogLogger := logger.With().Str("component", "watchdog").Logger()
+
+	var fallback *Webhook
+	if config.FallbackWebhookURL != "" {
+		fallback = NewWebhook(watchdogLogger, config.FallbackWebhookURL)
+	}
+
+	return &Watchdog{
+		logger:      watchdogLogger,
+		config:      config,
+		chainConfig: chainConfig,
+		reporters:   reporters,
+		pools: utils.Filter(pools, func(pool Pool) bool {
+			return pool.Len() > 0
+		}),
+		fallback:         fallback,
+		lastBlockTime:    time.Now(),
+		reporterFailures: map[constants.ReporterName]*reporterFailures{},
+		activeAlerts:     map[string]bool{},
+	}
+}
+
+// Start checks whether the checker is degraded at the interval until the context is done.
+func (w *Watchdog) Start(ctx context.Context) {
+	if !w.config.Enabled.Bool {
+		w.logger.Info().Msg("Watchdog is disabled")
+		return
+	}
+
+	w.mutex.Lock()
+	w.lastBlockTime = time.Now()
+	w.mutex.Unlock()
+
+	ticker := time.NewTicker(constants.WatchdogCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+func (w *Watchdog) OnBlockProcessed() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.lastBlockTime = time.Now()
+}
+
+func (w *Watchdog) OnSnapshotGenerated(err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err == nil {
+		w.snapshotFailures = 0
+		return
+	}
+
+	w.snapshotFailures++
+	w.lastSnapshotErr = err
+}
+
+func (w *Watchdog) OnReportSent(reporter constants.ReporterName, err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	failures, ok := w.reporterFailures[reporter]
+	if !ok {
+		failures = &reporterFailures{}
+		w.reporterFailures[reporter] = failures
+	}
+
+	if err == nil {
+		failures.count = 0
+		return
+	}
+
+	failures.count++
+	failures.lastError = err
+}
+
+func (w *Watchdog) getChecks() []check {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	sinceLastBlock := time.Since(w.lastBlockTime)
+
+	checks := []check{
+		{
+			key:     "no-blocks",
+			failing: sinceLastBlock > w.config.NoBlocksTimeout*time.Second,
+			description: fmt.Sprintf(
+				"no new blocks were processed for %s",
+				utils.FormatDuration(sinceLastBlock.Round(time.Second)),
+			),
+			resolvedDescription: "new blocks are processed again",
+		},
+		{
+			key:     "snapshot-failures",
+			failing: w.snapshotFailures >= w.config.SnapshotFailuresThreshold,
+			description: fmt.Sprintf(
+				"could not generate a snapshot %d times in a row: %s",
+				w.snapshotFailures,
+				w.lastSnapshotErr,
+			),
+			resolvedDescription: "snapshots are generated again",
+		},
+	}
+
+	for _, pool := range w.pools {
+		failedQueries := pool.GetFailedQueriesInRow()
+
+		checks = append(checks, check{
+			key:     "pool-failures-" + pool.Name(),
+			failing: failedQueries >= w.config.RPCFailuresThreshold,
+			description: fmt.Sprintf(
+				"all %s endpoints failed %d queries in a row",
+				pool.Name(),
+				failedQueries,
+			),
+			resolvedDescription: fmt.Sprintf("%s endpoints are responding again", pool.Name()),
+		})
+	}
+
+	for _, reporter := range w.reporters {
+		failures, ok := w.reporterFailures[reporter.Name()]
+		if !ok {
+			continue
+		}
+
+		checks = append(checks, check{
+			key:     "reporter-failures-" + string(reporter.Name()),
+			failing: failures.count >= w.config.ReporterFailuresThreshold,
+			description: fmt.Sprintf(
+				"could not send reports via %s %d times in a row: %s",
+				reporter.Name(),
+				failures.count,
+				failures.lastError,
+			),
+			resolvedDescription: fmt.Sprintf("reports are sent via %s again", reporter.Name()),
+			reporter:            reporter.Name(),
+		})
+	}
+
+	return checks
+}
+
+// Check sends alerts for the problems that appeared since the last check
+// and for the ones that were resolved.
+func (w *Watchdog) Check() {
+	checks := w.getChecks()
+
+	for _, check := range checks {
+		active := w.activeAlerts[check.key]
+
+		if check.failing && !active {
+			w.activeAlerts[check.key] = true
+			w.Send(types.WatchdogAlert{Key: check.key, Description: check.description})
+		} else if !check.failing && active {
+			delete(w.activeAlerts, check.key)
+			w.Send(types.WatchdogAlert{Key: check.key, Description: check.resolvedDescription, Resolved: true})
+		}
+	}
+}
+
+// Send sends the alert via all the reporters which are not failing and via the fallback webhook.
+func (w *Watchdog) Send(alert types.WatchdogAlert) {
+	w.logger.Warn().
+		Str("key", alert.Key).
+		Bool("resolved", alert.Resolved).
+		Msg(alert.Description)
+
+	for _, reporter := range w.reporters {
+		if !reporter.Enabled() || w.activeAlerts["reporter-failures-"+string(reporter.Name())] {
+			continue
+		}
+
+		if err := reporter.SendWatchdogAlert(alert); err != nil {
+			w.logger.Error().
+				Err(err).
+				Str("name", string(reporter.Name())).
+				Msg("Error sending watchdog alert")
+		}
+	}
+
+	if w.fallback != nil {
+		if err := w.fallback.Send(w.chainConfig.GetName(), alert); err != nil {
+			w.logger.Error().Err(err).Msg("Error sending watchdog alert to fallback webhook")
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package watchdog
+
+import (
+	"errors"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	reportersPkg "main/pkg/reporters"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+type testPool struct {
+	name          string
+	len           int
+	failedQueries int64
+}
+
+func (p *testPool) Name() string                 { return p.name }
+func (p *testPool) Len() int                     { return p.len }
+func (p *testPool) GetFailedQueriesInRow() int64 { return p.failedQueries }
+
+func getTestWatchdog(config configPkg.WatchdogConfig, pools ...Pool) (*Watchdog, *reportersPkg.StubReporter, *reportersPkg.StubReporter) {
+	logger := loggerPkg.GetNopLogger()
+	first := &reportersPkg.StubReporter{ReporterName: "first"}
+	second := &reportersPkg.StubReporter{ReporterName: "second"}
+
+	watchdog := NewWatchdog(
+		*logger,
+		config,
+		&configPkg.ChainConfig{Name: "chain"},
+		[]reportersPkg.Reporter{first, second},
+		pools,
+	)
+
+	return watchdog, first, second
+}
+
+func getTestWatchdogConfig() configPkg.WatchdogConfig {
+	return configPkg.WatchdogConfig{
+		Enabled:                   null.BoolFrom(true),
+		NoBlocksTimeout:           600,
+		RPCFailuresThreshold:      3,
+		SnapshotFailuresThreshold: 2,
+		ReporterFailuresThreshold: 2,
+	}
+}
+
+func TestWatchdogNoAlerts(t *testing.T) {
+	t.Parallel()
+
+	watchdog, first, second := getTestWatchdog(getTestWatchdogConfig(), &testPool{name: "rpc", len: 1})
+	watchdog.OnBlockProcessed()
+	watchdog.OnSnapshotGenerated(errors.New("custom error"))
+	watchdog.OnReportSent("first", errors.New("custom error"))
+	watchdog.Check()
+
+	require.Empty(t, first.Alerts)
+	require.Empty(t, second.Alerts)
+}
+
+func TestWatchdogNoBlocks(t *testing.T) {
+	t.Parallel()
+
+	watchdog, first, _ := getTestWatchdog(getTestWatchdogConfig())
+	watchdog.lastBlockTime = time.Now().Add(-20 * time.Minute)
+
+	watchdog.Check()
+	watchdog.Check()
+	require.Len(t, first.Alerts, 1)
+	require.Equal(t, "no-blocks", first.Alerts[0].Key)
+	require.False(t, first.Alerts[0].Resolved)
+	require.Contains(t, first.Alerts[0].Description, "no new blocks were processed for 20 minutes")
+
+	watchdog.OnBlockProcessed()
+	watchdog.Check()
+	require.Len(t, first.Alerts, 2)
+	require.True(t, first.Alerts[1].Resolved)
+	require.Equal(t, "new blocks are processed again", first.Alerts[1].Description)
+}
+
+func TestWatchdogPoolFailures(t *testing.T) {
+	t.Parallel()
+
+	pool := &testPool{name: "rpc", len: 1, failedQueries: 3}
+	watchdog, first, _ := getTestWatchdog(
+		getTestWatchdogConfig(),
+		pool,
+		&testPool{name: "lcd", len: 0, failedQueries: 10},
+	)
+
+	watchdog.Check()
+	require.Len(t, first.Alerts, 1)
+	require.Equal(t, "pool-failures-rpc", first.Alerts[0].Key)
+	require.Equal(t, "all rpc endpoints failed 3 queries in a row", first.Alerts[0].Description)
+
+	pool.failedQueries = 0
+	watchdog.Check()
+	require.Len(t, first.Alerts, 2)
+	require.True(t, first.Alerts[1].Resolved)
+}
+
+func TestWatchdogSnapshotFailures(t *testing.T) {
+	t.Parallel()
+
+	watchdog, first, _ := getTestWatchdog(getTestWatchdogConfig())
+	watchdog.OnSnapshotGenerated(errors.New("could not get info on 10 blocks"))
+	watchdog.OnSnapshotGenerated(errors.New("could not get info on 10 blocks"))
+
+	watchdog.Check()
+	require.Len(t, first.Alerts, 1)
+	require.Equal(t, "snapshot-failures", first.Alerts[0].Key)
+	require.Equal(
+		t,
+		"could not generate a snapshot 2 times in a row: could not get info on 10 blocks",
+		first.Alerts[0].Description,
+	)
+
+	watchdog.OnSnapshotGenerated(nil)
+	watchdog.Check()
+	require.Len(t, first.Alerts, 2)
+	require.True(t, first.Alerts[1].Resolved)
+}
+
+func TestWatchdogReporterFailures(t *testing.T) {
+	t.Parallel()
+
+	watchdog, first, second := getTestWatchdog(getTestWatchdogConfig())
+	watchdog.OnReportSent("first", errors.New("custom error"))
+	watchdog.OnReportSent("first", errors.New("custom error"))
+	watchdog.OnReportSent("second", nil)
+
+	watchdog.Check()
+	require.Empty(t, first.Alerts)
+	require.Len(t, second.Alerts, 1)
+	require.Equal(t, "reporter-failures-first", second.Alerts[0].Key)
+	require.Equal(t, "could not send reports via first 2 times in a row: custom error", second.Alerts[0].Description)
+
+	watchdog.OnReportSent("first", nil)
+	watchdog.Check()
+	require.Len(t, first.Alerts, 1)
+	require.True(t, first.Alerts[0].Resolved)
+	require.Equal(t, "reports are sent via first again", first.Alerts[0].Description)
+	require.Len(t, second.Alerts, 2)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestWatchdogFallbackWebhook(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		func(request *http.Request) (*http.Response, error) {
+			require.Equal(t, "application/json", request.Header.Get("Content-Type"))
+			return httpmock.NewStringResponse(200, "ok"), nil
+		},
+	)
+
+	config := getTestWatchdogConfig()
+	config.FallbackWebhookURL = "https://example.com/webhook"
+
+	watchdog, _, _ := getTestWatchdog(config)
+	watchdog.Send(types.WatchdogAlert{Key: "no-blocks", Description: "description"})
+
+	require.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestWebhookSendFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		httpmock.NewStringResponder(500, "error"),
+	)
+
+	webhook := NewWebhook(*loggerPkg.GetNopLogger(), "https://example.com/webhook")
+	err := webhook.Send("chain", types.WatchdogAlert{Key: "no-blocks", Description: "description", Resolved: true})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "got unexpected status code: 500")
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestWebhookSendError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/webhook",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	webhook := NewWebhook(*loggerPkg.GetNopLogger(), "https://example.com/webhook")
+	err := webhook.Send("chain", types.WatchdogAlert{Key: "no-blocks", Description: "description"})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "custom error")
+}
@@ -0,0 +1,68 @@
+package watchdog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"main/pkg/types"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Webhook is a fallback channel for watchdog alerts, so they are delivered even
+// if all the reporters are failing. The message is sent both as "text" and "content",
+// so it works with Slack and Discord webhooks and with most of the others.
+type Webhook struct {
+	logger zerolog.Logger
+	url    string
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Text     string `json:"text"`
+	Content  string `json:"content"`
+	Key      string `json:"key"`
+	Resolved bool   `json:"resolved"`
+}
+
+func NewWebhook(logger zerolog.Logger, url string) *Webhook {
+	return &Webhook{
+		logger: logger.With().Str("component", "watchdog_webhook").Logger(),
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *Webhook) Send(chainName string, alert types.WatchdogAlert) error {
+	emoji := "🚨"
+	if alert.Resolved {
+		emoji = "✅"
+	}
+
+	message := fmt.Sprintf("%s %s: %s", emoji, chainName, alert.Description)
+
+	body, err := json.Marshal(webhookPayload{
+		Text:     message,
+		Content:  message,
+		Key:      alert.Key,
+		Resolved: alert.Resolved,
+	})
+	if err != nil {
+		return err
+	}
+
+	response, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("got unexpected status code: %d", response.StatusCode)
+	}
+
+	w.logger.Debug().Str("key", alert.Key).Msg("Sent watchdog alert to webhook")
+	return nil
+}